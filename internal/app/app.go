@@ -3,6 +3,11 @@ package app
 import (
 	"context"
 	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -10,24 +15,37 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
-	"github.com/uptrace/bun/extra/bundebug"
+	"google.golang.org/grpc"
 
 	"github.com/clevertechware/todo-bun-app/internal/app/db"
 	"github.com/clevertechware/todo-bun-app/internal/app/handlers"
 	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
 	"github.com/clevertechware/todo-bun-app/internal/config"
 	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/metrics"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/requestid"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/tracing"
+	"github.com/clevertechware/todo-bun-app/migrations"
 )
 
 // App holds all application dependencies
 type App struct {
-	DB          *bun.DB
-	httpHandler *handlers.HTTPHandler
-	logger      *zerolog.Logger
+	DB                 *bun.DB
+	Pool               *pgxpool.Pool // pool under DB, for what database/sql cannot do such as COPY
+	httpHandler        *handlers.HTTPHandler
+	adminHandler       *handlers.HTTPAdminHandler
+	grpcHandler        *handlers.GRPCHandler
+	taskUsecase        usecases.TaskUsecase
+	idempotencyUsecase usecases.IdempotencyUsecase
+	healthUsecase      usecases.HealthUsecase
+	metrics            *metrics.Metrics
+	logger             *zerolog.Logger
+	config             *atomic.Pointer[config.Config] // configuration in effect, see ApplyConfig
+	configMu           sync.Mutex                     // serializes ApplyConfig
 }
 
 // NewApp creates a new App instance with all dependencies wired
-func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
+func NewApp(ctx context.Context, cfg *config.Config) (_ *App, err error) {
 	globalLogger := logger.GetLogger()
 	globalLogger.Info().Msg("Initializing database connection")
 
@@ -50,6 +68,12 @@ func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
 		globalLogger.Error().Err(err).Msg("Failed to create database pool")
 		return nil, fmt.Errorf("failed to create database pool: %w", err)
 	}
+	// The pool is closed when a later step fails
+	defer func() {
+		if err != nil {
+			pool.Close()
+		}
+	}()
 
 	// Validate the connection by acquiring a connection from the pool
 	conn, err := pool.Acquire(ctx)
@@ -62,16 +86,13 @@ func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
 	// Convert pgxpool to database/sql for Bun compatibility
 	sqldb := stdlib.OpenDBFromPool(pool)
 	bunDB := bun.NewDB(sqldb, pgdialect.New())
-	bunDB.AddQueryHook(
-		bundebug.NewQueryHook(
-			// disable the hook
-			bundebug.WithEnabled(false),
-
-			// BUNDEBUG=1 logs failed queries
-			// BUNDEBUG=2 logs all queries
-			bundebug.FromEnv("BUNDEBUG"),
-		),
+	slowQueryUsecase := usecases.NewSlowQueryUsecase(
+		db.NewSlowQueryPlanRepository(bunDB), cfg.Database.SlowQuery.ExplainSampleRatio,
 	)
+	bunDB.AddQueryHook(logger.QueryHook(queryHookConfig(&cfg.Database.SlowQuery, slowQueryUsecase)))
+	appMetrics := metrics.New()
+	bunDB.AddQueryHook(appMetrics.QueryHook())
+	bunDB.AddQueryHook(tracing.QueryHook())
 
 	globalLogger.Info().
 		Str("host", cfg.Database.Host).
@@ -84,25 +105,179 @@ func NewApp(ctx context.Context, cfg *config.Config) (*App, error) {
 	// Wire dependencies: Repository -> Usecase -> Handler
 	globalLogger.Debug().Msg("Wiring dependencies")
 	taskRepo := db.NewTaskRepository(bunDB)
-	taskUsecase := usecases.NewTaskUsecase(taskRepo)
+	transactor := db.NewTransactor(bunDB)
+	syncRepo := db.NewSyncRepository(bunDB)
+	bulkRepo := db.NewBulkRepository(pool)
+	taskUsecase := usecases.NewTracingTaskUsecase(usecases.NewTaskUsecase(taskRepo, syncRepo, bulkRepo, transactor))
 	taskHandler := handlers.NewHTTPTaskHandler(taskUsecase)
-	httpHandler := handlers.NewHTTPHandler(taskHandler)
+	idempotencyRepo := db.NewIdempotencyRepository(bunDB)
+	idempotencyUsecase := usecases.NewIdempotencyUsecase(idempotencyRepo, usecases.DefaultIdempotencyKeyTTL)
+	idempotencyHandler := handlers.NewHTTPIdempotencyHandler(idempotencyUsecase)
+	graphQLHandler := handlers.NewGraphQLHandler(taskUsecase)
+	openAPIHandler, err := handlers.NewOpenAPIHandler()
+	if err != nil {
+		globalLogger.Error().Err(err).Msg("Failed to load OpenAPI spec")
+		return nil, err
+	}
+	calendarFeedRepo := db.NewCalendarFeedRepository(bunDB)
+	calendarUsecase := usecases.NewCalendarUsecase(calendarFeedRepo, taskUsecase)
+	calendarHandler := handlers.NewHTTPCalendarHandler(calendarUsecase)
+	caldavUsecase := usecases.NewCalDAVUsecase(taskUsecase, syncRepo, transactor)
+	caldavHandler := handlers.NewHTTPCalDAVHandler(caldavUsecase)
+	expectedVersion, err := migrations.LatestVersion()
+	if err != nil {
+		globalLogger.Error().Err(err).Msg("Failed to read migrations")
+		return nil, err
+	}
+	healthRepo := db.NewHealthRepository(bunDB, pool)
+	healthUsecase := usecases.NewHealthUsecase(
+		usecases.DefaultHealthCheckTimeout,
+		usecases.NewDatabaseHealthCheckers(healthRepo, expectedVersion)...,
+	)
+	healthHandler := handlers.NewHTTPHealthHandler(healthUsecase)
+	statsRepo := db.NewStatsRepository(bunDB)
+	if err = appMetrics.Register(metrics.NewPoolCollector(pool), metrics.NewBusinessCollector(statsRepo.Count)); err != nil {
+		globalLogger.Error().Err(err).Msg("Failed to register metrics")
+		return nil, fmt.Errorf("failed to register metrics: %w", err)
+	}
+	httpHandler := handlers.NewHTTPHandler(
+		taskHandler, idempotencyHandler, calendarHandler, caldavHandler, graphQLHandler, openAPIHandler, healthHandler,
+	)
+	adminUsecase := usecases.NewAdminUsecase(healthRepo)
+	currentConfig := new(atomic.Pointer[config.Config])
+	currentConfig.Store(cfg)
+	adminHandler := handlers.NewHTTPAdminHandler(adminUsecase, slowQueryUsecase, currentConfig.Load)
+	grpcTaskHandler := handlers.NewGRPCTaskHandler(taskUsecase)
+	grpcHandler := handlers.NewGRPCHandler(grpcTaskHandler)
 
 	return &App{
-		DB:          bunDB,
-		httpHandler: httpHandler,
-		logger:      globalLogger,
+		DB:                 bunDB,
+		Pool:               pool,
+		httpHandler:        httpHandler,
+		adminHandler:       adminHandler,
+		grpcHandler:        grpcHandler,
+		taskUsecase:        taskUsecase,
+		idempotencyUsecase: idempotencyUsecase,
+		healthUsecase:      healthUsecase,
+		metrics:            appMetrics,
+		logger:             globalLogger,
+		config:             currentConfig,
 	}, nil
 }
 
-// Close closes all application resources
+// queryHookConfig logs the queries slower than the threshold of cfg, capturing the plan of a sample of them in
+// the background if enabled; the sample is drawn before any goroutine is started
+func queryHookConfig(cfg *config.SlowQueryConfig, slowQueryUsecase usecases.SlowQueryUsecase) logger.QueryHookConfig {
+	hookConfig := logger.QueryHookConfig{SlowThreshold: cfg.GetThreshold()}
+	if !cfg.Explain {
+		return hookConfig
+	}
+
+	hookConfig.OnSlowQuery = func(ctx context.Context, event *bun.QueryEvent, duration time.Duration) {
+		params := usecases.CaptureSlowQueryParams{
+			Query:     event.Query,
+			Operation: event.Operation(),
+			Duration:  duration,
+		}
+		capture := slowQueryUsecase.SampleSlowQuery(ctx, params)
+		if capture == nil {
+			return
+		}
+		go func() {
+			if err := capture(); err != nil {
+				logger.FromContext(ctx).Warn().Err(err).Msg("Failed to capture slow query plan")
+			}
+		}()
+	}
+	return hookConfig
+}
+
+// MetricsMiddleware returns a middleware recording the HTTP metrics
+func (a *App) MetricsMiddleware() gin.HandlerFunc {
+	return a.metrics.GinMiddleware()
+}
+
+// MetricsHandler serves the Prometheus metrics
+func (a *App) MetricsHandler() http.Handler {
+	return a.metrics.Handler()
+}
+
+// RegisterHealthChecker adds a check to the readiness and health endpoints
+func (a *App) RegisterHealthChecker(checker usecases.HealthChecker) {
+	a.healthUsecase.Register(checker)
+}
+
+// Drain makes the HTTP and gRPC health checks fail ahead of a shutdown, requests still being served
+func (a *App) Drain() {
+	a.httpHandler.Drain()
+	a.grpcHandler.Shutdown()
+}
+
+// Close closes all application resources, once the servers and background workers are stopped
 func (a *App) Close() error {
+	if a.grpcHandler != nil {
+		a.grpcHandler.Shutdown()
+	}
+	var err error
 	if a.DB != nil {
-		return a.DB.Close()
+		err = a.DB.Close()
+	}
+	// Closing DB leaves the pool it was opened from open
+	if a.Pool != nil {
+		a.Pool.Close()
 	}
-	return nil
+	return err
 }
 
 func (a *App) RegisterRoutes(router gin.IRouter) {
 	a.httpHandler.RegisterRoutes(router)
 }
+
+// RegisterAdminRoutes registers the routes of operators: pprof, the log level, the configuration, the pool stats
+// and the slow query plans, never to be served on the public port
+func (a *App) RegisterAdminRoutes(router gin.IRouter) {
+	a.adminHandler.RegisterRoutes(router)
+}
+
+// OpenAPIValidationMiddleware returns a middleware validating HTTP traffic against the OpenAPI spec
+func (a *App) OpenAPIValidationMiddleware() gin.HandlerFunc {
+	return a.httpHandler.OpenAPIValidationMiddleware()
+}
+
+func (a *App) RegisterGRPCServices(server *grpc.Server) {
+	a.grpcHandler.RegisterServices(server)
+}
+
+// ExportTasks writes every task with its items to w in the given format
+func (a *App) ExportTasks(ctx context.Context, format usecases.TransferFormat, w io.Writer) error {
+	return a.taskUsecase.ExportTasks(ctx, format, w)
+}
+
+// ImportTasks creates the tasks read from an export file
+func (a *App) ImportTasks(ctx context.Context, params usecases.ImportTasksParams) (*usecases.ImportResult, error) {
+	return a.taskUsecase.ImportTasks(ctx, params)
+}
+
+// PurgeExpiredIdempotencyKeys deletes expired idempotency keys every interval until ctx is done
+func (a *App) PurgeExpiredIdempotencyKeys(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Each run gets its own ID, so that its queries can be told apart in the logs
+			runLogger := a.logger.With().Str("requestId", requestid.New()).Str("job", "purge_idempotency_keys").Logger()
+			runCtx := logger.WithContext(ctx, runLogger)
+
+			purged, err := a.idempotencyUsecase.PurgeExpired(runCtx)
+			if err != nil {
+				runLogger.Warn().Err(err).Msg("Failed to purge expired idempotency keys")
+				continue
+			}
+			runLogger.Debug().Int64("purged", purged).Msg("Purged expired idempotency keys")
+		}
+	}
+}
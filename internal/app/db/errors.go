@@ -1,8 +1,53 @@
 package db
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/uptrace/bun/driver/pgdriver"
+)
 
 var (
 	// ErrTaskNotFound is returned when a task is not found
 	ErrTaskNotFound = errors.New("task not found")
+
+	// ErrTaskItemNotFound is returned when a task item is not found
+	ErrTaskItemNotFound = errors.New("task item not found")
+
+	// ErrCalendarFeedNotFound is returned when a calendar feed is not found
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found")
+
+	// ErrIdempotencyClaimLost is returned when the claim of an idempotency key was taken over by another request
+	ErrIdempotencyClaimLost = errors.New("idempotency key claimed by another request")
 )
+
+// isForeignKeyViolation reports whether err is a PostgreSQL foreign key violation (SQLSTATE 23503)
+func isForeignKeyViolation(err error) bool {
+	return sqlState(err) == "23503"
+}
+
+// isRetryable reports whether err aborted a transaction that can be retried as is:
+// a serialization failure (SQLSTATE 40001) or a deadlock (SQLSTATE 40P01)
+func isRetryable(err error) bool {
+	switch sqlState(err) {
+	case "40001", "40P01":
+		return true
+	default:
+		return false
+	}
+}
+
+// sqlState extracts the SQLSTATE code of a PostgreSQL error, for both the pgx and pgdriver drivers
+func sqlState(err error) string {
+	var pgxErr *pgconn.PgError
+	if errors.As(err, &pgxErr) {
+		return pgxErr.Code
+	}
+
+	var pgdriverErr pgdriver.Error
+	if errors.As(err, &pgdriverErr) {
+		return pgdriverErr.Field('C')
+	}
+
+	return ""
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	"context"
 
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
 	"github.com/clevertechware/todo-bun-app/internal/app/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -95,6 +96,63 @@ func (_c *TaskRepository_Create_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// CreateItem provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) CreateItem(ctx context.Context, item *models.TaskItem) error {
+	ret := _mock.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateItem")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.TaskItem) error); ok {
+		r0 = returnFunc(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskRepository_CreateItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateItem'
+type TaskRepository_CreateItem_Call struct {
+	*mock.Call
+}
+
+// CreateItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *models.TaskItem
+func (_e *TaskRepository_Expecter) CreateItem(ctx interface{}, item interface{}) *TaskRepository_CreateItem_Call {
+	return &TaskRepository_CreateItem_Call{Call: _e.mock.On("CreateItem", ctx, item)}
+}
+
+func (_c *TaskRepository_CreateItem_Call) Run(run func(ctx context.Context, item *models.TaskItem)) *TaskRepository_CreateItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.TaskItem
+		if args[1] != nil {
+			arg1 = args[1].(*models.TaskItem)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_CreateItem_Call) Return(err error) *TaskRepository_CreateItem_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskRepository_CreateItem_Call) RunAndReturn(run func(ctx context.Context, item *models.TaskItem) error) *TaskRepository_CreateItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function for the type TaskRepository
 func (_mock *TaskRepository) Delete(ctx context.Context, taskID int64) error {
 	ret := _mock.Called(ctx, taskID)
@@ -152,6 +210,194 @@ func (_c *TaskRepository_Delete_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// DeleteItem provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) DeleteItem(ctx context.Context, taskID int64, itemID int64) error {
+	ret := _mock.Called(ctx, taskID, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteItem")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = returnFunc(ctx, taskID, itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskRepository_DeleteItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteItem'
+type TaskRepository_DeleteItem_Call struct {
+	*mock.Call
+}
+
+// DeleteItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID int64
+//   - itemID int64
+func (_e *TaskRepository_Expecter) DeleteItem(ctx interface{}, taskID interface{}, itemID interface{}) *TaskRepository_DeleteItem_Call {
+	return &TaskRepository_DeleteItem_Call{Call: _e.mock.On("DeleteItem", ctx, taskID, itemID)}
+}
+
+func (_c *TaskRepository_DeleteItem_Call) Run(run func(ctx context.Context, taskID int64, itemID int64)) *TaskRepository_DeleteItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_DeleteItem_Call) Return(err error) *TaskRepository_DeleteItem_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskRepository_DeleteItem_Call) RunAndReturn(run func(ctx context.Context, taskID int64, itemID int64) error) *TaskRepository_DeleteItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ForEach provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) ForEach(ctx context.Context, fn func(task *models.Task) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ForEach")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(task *models.Task) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskRepository_ForEach_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ForEach'
+type TaskRepository_ForEach_Call struct {
+	*mock.Call
+}
+
+// ForEach is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(task *models.Task) error
+func (_e *TaskRepository_Expecter) ForEach(ctx interface{}, fn interface{}) *TaskRepository_ForEach_Call {
+	return &TaskRepository_ForEach_Call{Call: _e.mock.On("ForEach", ctx, fn)}
+}
+
+func (_c *TaskRepository_ForEach_Call) Run(run func(ctx context.Context, fn func(task *models.Task) error)) *TaskRepository_ForEach_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(task *models.Task) error
+		if args[1] != nil {
+			arg1 = args[1].(func(task *models.Task) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_ForEach_Call) Return(err error) *TaskRepository_ForEach_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskRepository_ForEach_Call) RunAndReturn(run func(ctx context.Context, fn func(task *models.Task) error) error) *TaskRepository_ForEach_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByICalUID provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) GetByICalUID(ctx context.Context, uid string) (*models.Task, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByICalUID")
+	}
+
+	var r0 *models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.Task, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.Task); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_GetByICalUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByICalUID'
+type TaskRepository_GetByICalUID_Call struct {
+	*mock.Call
+}
+
+// GetByICalUID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *TaskRepository_Expecter) GetByICalUID(ctx interface{}, uid interface{}) *TaskRepository_GetByICalUID_Call {
+	return &TaskRepository_GetByICalUID_Call{Call: _e.mock.On("GetByICalUID", ctx, uid)}
+}
+
+func (_c *TaskRepository_GetByICalUID_Call) Run(run func(ctx context.Context, uid string)) *TaskRepository_GetByICalUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_GetByICalUID_Call) Return(task *models.Task, err error) *TaskRepository_GetByICalUID_Call {
+	_c.Call.Return(task, err)
+	return _c
+}
+
+func (_c *TaskRepository_GetByICalUID_Call) RunAndReturn(run func(ctx context.Context, uid string) (*models.Task, error)) *TaskRepository_GetByICalUID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function for the type TaskRepository
 func (_mock *TaskRepository) GetByID(ctx context.Context, taskID int64) (*models.Task, error) {
 	ret := _mock.Called(ctx, taskID)
@@ -220,64 +466,592 @@ func (_c *TaskRepository_GetByID_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
-// List provides a mock function for the type TaskRepository
-func (_mock *TaskRepository) List(ctx context.Context) ([]*models.Task, error) {
-	ret := _mock.Called(ctx)
+// GetForUpdate provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) GetForUpdate(ctx context.Context, taskID int64) (*models.Task, error) {
+	ret := _mock.Called(ctx, taskID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for List")
+		panic("no return value specified for GetForUpdate")
 	}
 
-	var r0 []*models.Task
+	var r0 *models.Task
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*models.Task, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*models.Task, error)); ok {
+		return returnFunc(ctx, taskID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []*models.Task); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *models.Task); ok {
+		r0 = returnFunc(ctx, taskID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*models.Task)
+			r0 = ret.Get(0).(*models.Task)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, taskID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// TaskRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
-type TaskRepository_List_Call struct {
+// TaskRepository_GetForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetForUpdate'
+type TaskRepository_GetForUpdate_Call struct {
 	*mock.Call
 }
 
-// List is a helper method to define mock.On call
+// GetForUpdate is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *TaskRepository_Expecter) List(ctx interface{}) *TaskRepository_List_Call {
-	return &TaskRepository_List_Call{Call: _e.mock.On("List", ctx)}
+//   - taskID int64
+func (_e *TaskRepository_Expecter) GetForUpdate(ctx interface{}, taskID interface{}) *TaskRepository_GetForUpdate_Call {
+	return &TaskRepository_GetForUpdate_Call{Call: _e.mock.On("GetForUpdate", ctx, taskID)}
 }
 
-func (_c *TaskRepository_List_Call) Run(run func(ctx context.Context)) *TaskRepository_List_Call {
+func (_c *TaskRepository_GetForUpdate_Call) Run(run func(ctx context.Context, taskID int64)) *TaskRepository_GetForUpdate_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *TaskRepository_List_Call) Return(tasks []*models.Task, err error) *TaskRepository_List_Call {
-	_c.Call.Return(tasks, err)
+func (_c *TaskRepository_GetForUpdate_Call) Return(task *models.Task, err error) *TaskRepository_GetForUpdate_Call {
+	_c.Call.Return(task, err)
 	return _c
 }
 
-func (_c *TaskRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]*models.Task, error)) *TaskRepository_List_Call {
+func (_c *TaskRepository_GetForUpdate_Call) RunAndReturn(run func(ctx context.Context, taskID int64) (*models.Task, error)) *TaskRepository_GetForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetItemForUpdate provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) GetItemForUpdate(ctx context.Context, taskID int64, itemID int64) (*models.TaskItem, error) {
+	ret := _mock.Called(ctx, taskID, itemID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetItemForUpdate")
+	}
+
+	var r0 *models.TaskItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) (*models.TaskItem, error)); ok {
+		return returnFunc(ctx, taskID, itemID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) *models.TaskItem); ok {
+		r0 = returnFunc(ctx, taskID, itemID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaskItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = returnFunc(ctx, taskID, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_GetItemForUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetItemForUpdate'
+type TaskRepository_GetItemForUpdate_Call struct {
+	*mock.Call
+}
+
+// GetItemForUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID int64
+//   - itemID int64
+func (_e *TaskRepository_Expecter) GetItemForUpdate(ctx interface{}, taskID interface{}, itemID interface{}) *TaskRepository_GetItemForUpdate_Call {
+	return &TaskRepository_GetItemForUpdate_Call{Call: _e.mock.On("GetItemForUpdate", ctx, taskID, itemID)}
+}
+
+func (_c *TaskRepository_GetItemForUpdate_Call) Run(run func(ctx context.Context, taskID int64, itemID int64)) *TaskRepository_GetItemForUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_GetItemForUpdate_Call) Return(taskItem *models.TaskItem, err error) *TaskRepository_GetItemForUpdate_Call {
+	_c.Call.Return(taskItem, err)
+	return _c
+}
+
+func (_c *TaskRepository_GetItemForUpdate_Call) RunAndReturn(run func(ctx context.Context, taskID int64, itemID int64) (*models.TaskItem, error)) *TaskRepository_GetItemForUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) List(ctx context.Context) ([]*models.Task, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []*models.Task
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]*models.Task, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []*models.Task); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type TaskRepository_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TaskRepository_Expecter) List(ctx interface{}) *TaskRepository_List_Call {
+	return &TaskRepository_List_Call{Call: _e.mock.On("List", ctx)}
+}
+
+func (_c *TaskRepository_List_Call) Run(run func(ctx context.Context)) *TaskRepository_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_List_Call) Return(tasks []*models.Task, err error) *TaskRepository_List_Call {
+	_c.Call.Return(tasks, err)
+	return _c
+}
+
+func (_c *TaskRepository_List_Call) RunAndReturn(run func(ctx context.Context) ([]*models.Task, error)) *TaskRepository_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListItemsByTaskIDs provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) ListItemsByTaskIDs(ctx context.Context, taskIDs []int64) ([]*models.TaskItem, error) {
+	ret := _mock.Called(ctx, taskIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListItemsByTaskIDs")
+	}
+
+	var r0 []*models.TaskItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int64) ([]*models.TaskItem, error)); ok {
+		return returnFunc(ctx, taskIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int64) []*models.TaskItem); ok {
+		r0 = returnFunc(ctx, taskIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TaskItem)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = returnFunc(ctx, taskIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_ListItemsByTaskIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListItemsByTaskIDs'
+type TaskRepository_ListItemsByTaskIDs_Call struct {
+	*mock.Call
+}
+
+// ListItemsByTaskIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskIDs []int64
+func (_e *TaskRepository_Expecter) ListItemsByTaskIDs(ctx interface{}, taskIDs interface{}) *TaskRepository_ListItemsByTaskIDs_Call {
+	return &TaskRepository_ListItemsByTaskIDs_Call{Call: _e.mock.On("ListItemsByTaskIDs", ctx, taskIDs)}
+}
+
+func (_c *TaskRepository_ListItemsByTaskIDs_Call) Run(run func(ctx context.Context, taskIDs []int64)) *TaskRepository_ListItemsByTaskIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []int64
+		if args[1] != nil {
+			arg1 = args[1].([]int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_ListItemsByTaskIDs_Call) Return(taskItems []*models.TaskItem, err error) *TaskRepository_ListItemsByTaskIDs_Call {
+	_c.Call.Return(taskItems, err)
+	return _c
+}
+
+func (_c *TaskRepository_ListItemsByTaskIDs_Call) RunAndReturn(run func(ctx context.Context, taskIDs []int64) ([]*models.TaskItem, error)) *TaskRepository_ListItemsByTaskIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTitles provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) ListTitles(ctx context.Context, titles []string) ([]string, error) {
+	ret := _mock.Called(ctx, titles)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTitles")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]string, error)); ok {
+		return returnFunc(ctx, titles)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []string); ok {
+		r0 = returnFunc(ctx, titles)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, titles)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_ListTitles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTitles'
+type TaskRepository_ListTitles_Call struct {
+	*mock.Call
+}
+
+// ListTitles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - titles []string
+func (_e *TaskRepository_Expecter) ListTitles(ctx interface{}, titles interface{}) *TaskRepository_ListTitles_Call {
+	return &TaskRepository_ListTitles_Call{Call: _e.mock.On("ListTitles", ctx, titles)}
+}
+
+func (_c *TaskRepository_ListTitles_Call) Run(run func(ctx context.Context, titles []string)) *TaskRepository_ListTitles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_ListTitles_Call) Return(strings []string, err error) *TaskRepository_ListTitles_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *TaskRepository_ListTitles_Call) RunAndReturn(run func(ctx context.Context, titles []string) ([]string, error)) *TaskRepository_ListTitles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Search provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) Search(ctx context.Context, filter db.TaskFilter) ([]*models.Task, int, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Search")
+	}
+
+	var r0 []*models.Task
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.TaskFilter) ([]*models.Task, int, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.TaskFilter) []*models.Task); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Task)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.TaskFilter) int); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, db.TaskFilter) error); ok {
+		r2 = returnFunc(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// TaskRepository_Search_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Search'
+type TaskRepository_Search_Call struct {
+	*mock.Call
+}
+
+// Search is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter db.TaskFilter
+func (_e *TaskRepository_Expecter) Search(ctx interface{}, filter interface{}) *TaskRepository_Search_Call {
+	return &TaskRepository_Search_Call{Call: _e.mock.On("Search", ctx, filter)}
+}
+
+func (_c *TaskRepository_Search_Call) Run(run func(ctx context.Context, filter db.TaskFilter)) *TaskRepository_Search_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.TaskFilter
+		if args[1] != nil {
+			arg1 = args[1].(db.TaskFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_Search_Call) Return(tasks []*models.Task, n int, err error) *TaskRepository_Search_Call {
+	_c.Call.Return(tasks, n, err)
+	return _c
+}
+
+func (_c *TaskRepository_Search_Call) RunAndReturn(run func(ctx context.Context, filter db.TaskFilter) ([]*models.Task, int, error)) *TaskRepository_Search_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Stats provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) Stats(ctx context.Context) (*db.TaskStats, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 *db.TaskStats
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*db.TaskStats, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *db.TaskStats); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*db.TaskStats)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskRepository_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type TaskRepository_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TaskRepository_Expecter) Stats(ctx interface{}) *TaskRepository_Stats_Call {
+	return &TaskRepository_Stats_Call{Call: _e.mock.On("Stats", ctx)}
+}
+
+func (_c *TaskRepository_Stats_Call) Run(run func(ctx context.Context)) *TaskRepository_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_Stats_Call) Return(taskStats *db.TaskStats, err error) *TaskRepository_Stats_Call {
+	_c.Call.Return(taskStats, err)
+	return _c
+}
+
+func (_c *TaskRepository_Stats_Call) RunAndReturn(run func(ctx context.Context) (*db.TaskStats, error)) *TaskRepository_Stats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Update provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) Update(ctx context.Context, task *models.Task) error {
+	ret := _mock.Called(ctx, task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Task) error); ok {
+		r0 = returnFunc(ctx, task)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskRepository_Update_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Update'
+type TaskRepository_Update_Call struct {
+	*mock.Call
+}
+
+// Update is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *models.Task
+func (_e *TaskRepository_Expecter) Update(ctx interface{}, task interface{}) *TaskRepository_Update_Call {
+	return &TaskRepository_Update_Call{Call: _e.mock.On("Update", ctx, task)}
+}
+
+func (_c *TaskRepository_Update_Call) Run(run func(ctx context.Context, task *models.Task)) *TaskRepository_Update_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.Task
+		if args[1] != nil {
+			arg1 = args[1].(*models.Task)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_Update_Call) Return(err error) *TaskRepository_Update_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskRepository_Update_Call) RunAndReturn(run func(ctx context.Context, task *models.Task) error) *TaskRepository_Update_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateItem provides a mock function for the type TaskRepository
+func (_mock *TaskRepository) UpdateItem(ctx context.Context, item *models.TaskItem) error {
+	ret := _mock.Called(ctx, item)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateItem")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.TaskItem) error); ok {
+		r0 = returnFunc(ctx, item)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskRepository_UpdateItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateItem'
+type TaskRepository_UpdateItem_Call struct {
+	*mock.Call
+}
+
+// UpdateItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - item *models.TaskItem
+func (_e *TaskRepository_Expecter) UpdateItem(ctx interface{}, item interface{}) *TaskRepository_UpdateItem_Call {
+	return &TaskRepository_UpdateItem_Call{Call: _e.mock.On("UpdateItem", ctx, item)}
+}
+
+func (_c *TaskRepository_UpdateItem_Call) Run(run func(ctx context.Context, item *models.TaskItem)) *TaskRepository_UpdateItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.TaskItem
+		if args[1] != nil {
+			arg1 = args[1].(*models.TaskItem)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskRepository_UpdateItem_Call) Return(err error) *TaskRepository_UpdateItem_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskRepository_UpdateItem_Call) RunAndReturn(run func(ctx context.Context, item *models.TaskItem) error) *TaskRepository_UpdateItem_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,312 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// BulkDuplicateReason is the reason of the tasks left out of a bulk import as duplicates
+const BulkDuplicateReason = "duplicate title"
+
+// BulkTask is a task of a bulk import, along with its items
+type BulkTask struct {
+	Title       string
+	Description string
+	DueAt       *time.Time
+	// ICalUID is derived from the ID of the task when empty
+	ICalUID string
+	Items   []BulkTaskItem
+}
+
+// BulkTaskItem is an item of a task of a bulk import
+type BulkTaskItem struct {
+	Title     string
+	Completed bool
+	// ICalUID is derived from the ID of the item when empty
+	ICalUID string
+}
+
+// BulkImportOptions holds the options of a bulk import
+type BulkImportOptions struct {
+	// AllowDuplicates imports the tasks whose title already exists instead of rejecting them
+	AllowDuplicates bool
+	// DryRun rolls the import back once the rejects are known
+	DryRun bool
+}
+
+// BulkReject is a task or an item left out of a bulk import
+type BulkReject struct {
+	// TaskRef is the position of the task in the import, from 1
+	TaskRef int64
+	// ItemRef is the position of the item in its task, from 1, or 0 when the task itself is rejected
+	ItemRef int64
+	Reason  string
+	// Duplicate is true when the task was left out because its title already exists
+	Duplicate bool
+}
+
+// BulkImportResult holds the outcome of a bulk import
+type BulkImportResult struct {
+	// TaskIDs holds the ID of every task by position in the import, 0 for the rejected ones
+	TaskIDs   []int64
+	ItemCount int64
+	Rejects   []BulkReject
+}
+
+// BulkRepository defines the interface for importing large numbers of tasks
+type BulkRepository interface {
+	// ImportTasks inserts tasks with their items in a single transaction, leaving out the invalid rows
+	// instead of failing; the items of a rejected task are left out along with it
+	ImportTasks(ctx context.Context, tasks []BulkTask, opts BulkImportOptions) (*BulkImportResult, error)
+}
+
+// bulkRepository implements BulkRepository using PostgreSQL COPY
+type bulkRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewBulkRepository creates a new instance of BulkRepository
+// It works on the pgx pool directly, COPY being out of reach of database/sql, so it never takes part in the
+// transaction of a Transactor
+func NewBulkRepository(pool *pgxpool.Pool) BulkRepository {
+	return &bulkRepository{pool: pool}
+}
+
+// ImportTasks copies the tasks and items into staging tables, records the rejected rows, then inserts the others
+// with set-based statements: the IDs of the tasks are drawn beforehand so that their items can be mapped to them
+func (r *bulkRepository) ImportTasks(ctx context.Context, tasks []BulkTask, opts BulkImportOptions) (*BulkImportResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	// Hold the change lock like every other writer, see trackChanges
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock_shared($1)", changeLockID); err != nil {
+		return nil, err
+	}
+	if _, err = tx.Exec(ctx, createBulkStagingTables); err != nil {
+		return nil, err
+	}
+	if err = copyBulkTasks(ctx, tx, tasks); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	taskVersions := models.FieldVersions{}
+	taskVersions.Init(now, models.FieldTitle, models.FieldDescription, models.FieldDueAt)
+	itemVersions := models.FieldVersions{}
+	itemVersions.Init(now, models.FieldTitle, models.FieldCompleted)
+
+	if _, err = tx.Exec(ctx, rejectBulkTasks, opts.AllowDuplicates, BulkDuplicateReason); err != nil {
+		return nil, err
+	}
+	if _, err = tx.Exec(ctx, allocateBulkTaskIDs); err != nil {
+		return nil, err
+	}
+	if _, err = tx.Exec(ctx, insertBulkTasks, now, fieldVersionsJSON(taskVersions)); err != nil {
+		return nil, err
+	}
+	if _, err = tx.Exec(ctx, rejectBulkTaskItems); err != nil {
+		return nil, err
+	}
+	tag, err := tx.Exec(ctx, insertBulkTaskItems, now, fieldVersionsJSON(itemVersions))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkImportResult{
+		TaskIDs:   make([]int64, len(tasks)),
+		ItemCount: tag.RowsAffected(),
+	}
+	if err = readBulkTaskIDs(ctx, tx, result.TaskIDs); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, "SELECT task_ref, item_ref, reason, duplicate FROM bulk_rejects ORDER BY task_ref, item_ref")
+	if err != nil {
+		return nil, err
+	}
+	if result.Rejects, err = pgx.CollectRows(rows, pgx.RowToStructByPos[BulkReject]); err != nil {
+		return nil, err
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// copyBulkTasks streams the tasks and items into the staging tables
+func copyBulkTasks(ctx context.Context, tx pgx.Tx, tasks []BulkTask) error {
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"bulk_tasks"},
+		[]string{"ref", "title", "description", "due_at", "ical_uid"},
+		pgx.CopyFromSlice(len(tasks), func(i int) ([]any, error) {
+			task := tasks[i]
+			var dueAt *time.Time
+			if task.DueAt != nil {
+				utc := task.DueAt.UTC()
+				dueAt = &utc
+			}
+			return []any{int64(i + 1), task.Title, task.Description, dueAt, task.ICalUID}, nil
+		}),
+	); err != nil {
+		return err
+	}
+
+	taskIndex, itemIndex := 0, 0
+	if _, err := tx.CopyFrom(ctx,
+		pgx.Identifier{"bulk_task_items"},
+		[]string{"task_ref", "item_ref", "title", "completed", "ical_uid"},
+		pgx.CopyFromFunc(func() ([]any, error) {
+			for taskIndex < len(tasks) && itemIndex >= len(tasks[taskIndex].Items) {
+				taskIndex++
+				itemIndex = 0
+			}
+			if taskIndex == len(tasks) {
+				return nil, nil
+			}
+
+			item := tasks[taskIndex].Items[itemIndex]
+			itemIndex++
+			return []any{int64(taskIndex + 1), int64(itemIndex), item.Title, item.Completed, item.ICalUID}, nil
+		}),
+	); err != nil {
+		return err
+	}
+
+	// Temporary tables are never analyzed automatically
+	_, err := tx.Exec(ctx, "ANALYZE bulk_tasks, bulk_task_items")
+	return err
+}
+
+// readBulkTaskIDs fills ids with the ID of each task by position, 0 for the rejected ones
+func readBulkTaskIDs(ctx context.Context, tx pgx.Tx, ids []int64) error {
+	rows, err := tx.Query(ctx, "SELECT ref, id FROM bulk_tasks WHERE id IS NOT NULL")
+	if err != nil {
+		return err
+	}
+
+	var ref, id int64
+	_, err = pgx.ForEachRow(rows, []any{&ref, &id}, func() error {
+		ids[ref-1] = id
+		return nil
+	})
+	return err
+}
+
+// createBulkStagingTables creates the staging tables of a bulk import, dropped with its transaction
+const createBulkStagingTables = `
+CREATE TEMP TABLE bulk_tasks (
+    ref BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL,
+    due_at TIMESTAMP,
+    ical_uid TEXT NOT NULL,
+    id BIGINT
+) ON COMMIT DROP;
+
+CREATE TEMP TABLE bulk_task_items (
+    task_ref BIGINT NOT NULL,
+    item_ref BIGINT NOT NULL,
+    title TEXT NOT NULL,
+    completed BOOLEAN NOT NULL,
+    ical_uid TEXT NOT NULL
+) ON COMMIT DROP;
+
+CREATE TEMP TABLE bulk_rejects (
+    task_ref BIGINT NOT NULL,
+    item_ref BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    duplicate BOOLEAN NOT NULL DEFAULT FALSE
+) ON COMMIT DROP;`
+
+// rejectBulkTasks records the invalid tasks, and the duplicate ones unless $1 allows them, $2 being the reason
+// of duplicates; titles and UIDs are compared to the existing tasks and to the tasks earlier in the import
+const rejectBulkTasks = `
+INSERT INTO bulk_rejects (task_ref, item_ref, reason, duplicate)
+SELECT ref, 0, reason, reason = $2
+FROM (
+    SELECT b.ref,
+        CASE
+            WHEN btrim(b.title) = '' THEN 'title is required'
+            WHEN char_length(b.title) > 255 THEN 'title is longer than 255 characters'
+            WHEN char_length(b.ical_uid) > 255 THEN 'UID is longer than 255 characters'
+            WHEN b.ical_uid <> '' AND (b.uid_rank > 1 OR EXISTS (SELECT 1 FROM tasks t WHERE t.ical_uid = b.ical_uid))
+                THEN 'UID already exists'
+            WHEN NOT $1 AND (b.title_rank > 1 OR EXISTS (SELECT 1 FROM tasks t WHERE lower(btrim(t.title)) = b.title_key))
+                THEN $2
+        END AS reason
+    FROM (
+        SELECT ref, title, ical_uid, lower(btrim(title)) AS title_key,
+            row_number() OVER (PARTITION BY lower(btrim(title)) ORDER BY ref) AS title_rank,
+            row_number() OVER (PARTITION BY ical_uid ORDER BY ref) AS uid_rank
+        FROM bulk_tasks
+    ) b
+) r
+WHERE reason IS NOT NULL`
+
+// allocateBulkTaskIDs draws the IDs of the accepted tasks, in import order
+const allocateBulkTaskIDs = `
+UPDATE bulk_tasks b SET id = s.id
+FROM (
+    SELECT ref, nextval(pg_get_serial_sequence('tasks', 'id')) AS id
+    FROM (
+        SELECT ref FROM bulk_tasks
+        WHERE NOT EXISTS (SELECT 1 FROM bulk_rejects r WHERE r.task_ref = bulk_tasks.ref)
+        ORDER BY ref
+    ) accepted
+) s
+WHERE b.ref = s.ref`
+
+// insertBulkTasks inserts the accepted tasks, created at $1 with the field versions $2
+const insertBulkTasks = `
+INSERT INTO tasks (id, title, description, due_at, ical_uid, created_at, updated_at, field_versions)
+SELECT id, title, description, due_at, NULLIF(ical_uid, ''), $1, $1, $2::jsonb
+FROM bulk_tasks
+WHERE id IS NOT NULL
+ORDER BY id`
+
+// rejectBulkTaskItems records the invalid items of the accepted tasks
+const rejectBulkTaskItems = `
+INSERT INTO bulk_rejects (task_ref, item_ref, reason)
+SELECT task_ref, item_ref, reason
+FROM (
+    SELECT i.task_ref, i.item_ref,
+        CASE
+            WHEN btrim(i.title) = '' THEN 'title is required'
+            WHEN char_length(i.title) > 255 THEN 'title is longer than 255 characters'
+            WHEN char_length(i.ical_uid) > 255 THEN 'UID is longer than 255 characters'
+            WHEN i.ical_uid <> '' AND (i.uid_rank > 1 OR EXISTS (SELECT 1 FROM task_items ti WHERE ti.ical_uid = i.ical_uid))
+                THEN 'UID already exists'
+        END AS reason
+    FROM (
+        SELECT task_ref, item_ref, title, ical_uid,
+            row_number() OVER (PARTITION BY ical_uid ORDER BY task_ref, item_ref) AS uid_rank
+        FROM bulk_task_items
+    ) i
+    JOIN bulk_tasks t ON t.ref = i.task_ref AND t.id IS NOT NULL
+) r
+WHERE reason IS NOT NULL`
+
+// insertBulkTaskItems inserts the accepted items of the accepted tasks, mapped to the IDs drawn for them,
+// created at $1 with the field versions $2
+const insertBulkTaskItems = `
+INSERT INTO task_items (task_id, title, completed, ical_uid, created_at, updated_at, field_versions)
+SELECT t.id, i.title, i.completed, NULLIF(i.ical_uid, ''), $1, $1, $2::jsonb
+FROM bulk_task_items i
+JOIN bulk_tasks t ON t.ref = i.task_ref AND t.id IS NOT NULL
+WHERE NOT EXISTS (SELECT 1 FROM bulk_rejects r WHERE r.task_ref = i.task_ref AND r.item_ref = i.item_ref)
+ORDER BY t.id, i.item_ref`
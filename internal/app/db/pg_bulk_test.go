@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func (s *PGRepositorySuite) TestPGBulk_ImportTasks() {
+	t := s.T()
+	ctx := context.Background()
+	repo := NewBulkRepository(s.pgContainer.Pool)
+
+	dueAt := time.Date(2025, 4, 15, 18, 0, 0, 0, time.UTC)
+	tasks := []BulkTask{
+		{
+			Title:   "Shopping",
+			DueAt:   &dueAt,
+			ICalUID: "bulk-shopping",
+			Items: []BulkTaskItem{
+				{Title: "Buy milk"},
+				{Title: " "},
+				{Title: "Buy bread", Completed: true, ICalUID: "bulk-bread"},
+			},
+		},
+		{Title: "", Items: []BulkTaskItem{{Title: "Orphan"}}},
+		{Title: "shopping "},
+		{Title: strings.Repeat("x", 256)},
+		{Title: "Work", ICalUID: "bulk-shopping"},
+		{Title: "Holidays"},
+	}
+
+	// A dry run reports the rejects and rolls back
+	result, err := repo.ImportTasks(ctx, tasks, BulkImportOptions{DryRun: true})
+
+	require.NoError(t, err)
+	assert.NotZero(t, result.TaskIDs[0])
+	assert.Zero(t, result.TaskIDs[1])
+	assert.EqualValues(t, 2, result.ItemCount)
+	assert.Equal(t, []BulkReject{
+		{TaskRef: 1, ItemRef: 2, Reason: "title is required"},
+		{TaskRef: 2, Reason: "title is required"},
+		{TaskRef: 3, Reason: BulkDuplicateReason, Duplicate: true},
+		{TaskRef: 4, Reason: "title is longer than 255 characters"},
+		{TaskRef: 5, Reason: "UID already exists"},
+	}, result.Rejects)
+
+	var count int
+	require.NoError(t, s.pgContainer.DB.NewSelect().Model((*models.Task)(nil)).
+		Where("id = ?", result.TaskIDs[0]).ColumnExpr("count(*)").Scan(ctx, &count))
+	assert.Zero(t, count)
+
+	// The import is committed otherwise, so the imported tasks are deleted afterwards
+	result, err = repo.ImportTasks(ctx, tasks, BulkImportOptions{AllowDuplicates: true})
+	require.NoError(t, err)
+	defer func() {
+		_, err := s.pgContainer.DB.NewDelete().Model((*models.Task)(nil)).
+			Where("id IN (?)", bun.In(result.TaskIDs)).Exec(ctx)
+		require.NoError(t, err)
+	}()
+
+	// The duplicate title is accepted
+	assert.NotZero(t, result.TaskIDs[2])
+	assert.Len(t, result.Rejects, 4)
+
+	task, err := NewTaskRepository(s.pgContainer.DB).GetByID(ctx, result.TaskIDs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "Shopping", task.Title)
+	assert.Equal(t, "bulk-shopping", task.ICalUID)
+	require.NotNil(t, task.DueAt)
+	assert.True(t, dueAt.Equal(*task.DueAt))
+	assert.NotZero(t, task.ChangeSeq)
+	require.Len(t, task.Items, 2)
+	items := map[string]*models.TaskItem{task.Items[0].Title: task.Items[0], task.Items[1].Title: task.Items[1]}
+	require.Contains(t, items, "Buy milk")
+	assert.Regexp(t, `^item-\d+@todo-bun-app$`, items["Buy milk"].ICalUID)
+	require.Contains(t, items, "Buy bread")
+	assert.True(t, items["Buy bread"].Completed)
+	assert.Equal(t, "bulk-bread", items["Buy bread"].ICalUID)
+
+	holidays, err := NewTaskRepository(s.pgContainer.DB).GetByID(ctx, result.TaskIDs[5])
+	require.NoError(t, err)
+	assert.Regexp(t, `^task-\d+@todo-bun-app$`, holidays.ICalUID)
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// CalendarFeedRepository defines the interface for calendar feed data access
+type CalendarFeedRepository interface {
+	Create(ctx context.Context, feed *models.CalendarFeed) error
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error)
+	List(ctx context.Context) ([]*models.CalendarFeed, error)
+	Delete(ctx context.Context, feedID int64) error
+}
+
+// calendarFeedRepository implements CalendarFeedRepository using Bun
+type calendarFeedRepository struct {
+	db bun.IDB
+}
+
+// NewCalendarFeedRepository creates a new instance of CalendarFeedRepository
+func NewCalendarFeedRepository(db bun.IDB) CalendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+// Create inserts a new calendar feed
+func (r *calendarFeedRepository) Create(ctx context.Context, feed *models.CalendarFeed) error {
+	feed.CreatedAt = time.Now()
+
+	_, err := r.conn(ctx).NewInsert().
+		Model(feed).
+		Returning("id").
+		Exec(ctx)
+
+	return err
+}
+
+// GetByTokenHash retrieves the calendar feed whose token hashes to tokenHash
+func (r *calendarFeedRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	feed := new(models.CalendarFeed)
+
+	err := r.conn(ctx).NewSelect().
+		Model(feed).
+		Where("cf.token_hash = ?", tokenHash).
+		Scan(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCalendarFeedNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
+
+// List retrieves all calendar feeds, oldest first
+func (r *calendarFeedRepository) List(ctx context.Context) ([]*models.CalendarFeed, error) {
+	feeds := make([]*models.CalendarFeed, 0)
+
+	err := r.conn(ctx).NewSelect().
+		Model(&feeds).
+		Order("cf.id ASC").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return feeds, nil
+}
+
+// Delete removes a calendar feed by ID, revoking its URL
+func (r *calendarFeedRepository) Delete(ctx context.Context, feedID int64) error {
+	result, err := r.conn(ctx).NewDelete().
+		Model((*models.CalendarFeed)(nil)).
+		Where("id = ?", feedID).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return ErrCalendarFeedNotFound
+	}
+
+	return nil
+}
+
+// conn returns the transaction of ctx, if any, or the repository database
+func (r *calendarFeedRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func (s *PGRepositorySuite) TestPGCalendarFeed() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	repo := NewCalendarFeedRepository(trx)
+	feed := &models.CalendarFeed{Name: "Phone", TokenHash: "abc"}
+	require.NoError(t, repo.Create(context.Background(), feed))
+	require.NotZero(t, feed.ID)
+
+	got, err := repo.GetByTokenHash(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, feed.ID, got.ID)
+	assert.Equal(t, "Phone", got.Name)
+
+	_, err = repo.GetByTokenHash(context.Background(), "unknown")
+	assert.ErrorIs(t, err, ErrCalendarFeedNotFound)
+
+	feeds, err := repo.List(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, feeds, 1)
+
+	require.NoError(t, repo.Delete(context.Background(), feed.ID))
+	assert.ErrorIs(t, repo.Delete(context.Background(), feed.ID), ErrCalendarFeedNotFound)
+}
@@ -0,0 +1,100 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/uptrace/bun"
+)
+
+// ErrNoMigration is returned when no migration was ever applied to the database
+var ErrNoMigration = errors.New("no migration applied")
+
+// HealthRepository defines the interface for checking the database the application depends on
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	// MigrationVersion returns the version of the schema and whether its last migration failed half-way
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+	// PoolStat returns the number of connections in use and the maximum size of the pool
+	PoolStat() (acquired, total int32)
+	// PoolStats returns a snapshot of the connections of the pool and of the time spent acquiring them
+	PoolStats() PoolStats
+}
+
+// PoolStats represents the state of the connection pool, counters accumulating since it was opened
+type PoolStats struct {
+	AcquiredConns           int32
+	IdleConns               int32
+	ConstructingConns       int32
+	TotalConns              int32
+	MaxConns                int32
+	AcquireCount            int64
+	AcquireDuration         time.Duration
+	EmptyAcquireCount       int64
+	EmptyAcquireWaitTime    time.Duration
+	CanceledAcquireCount    int64
+	NewConnsCount           int64
+	MaxLifetimeDestroyCount int64
+	MaxIdleDestroyCount     int64
+}
+
+// healthRepository implements HealthRepository using Bun and the pool under it
+type healthRepository struct {
+	db   bun.IDB
+	pool *pgxpool.Pool
+}
+
+// NewHealthRepository creates a new instance of HealthRepository
+func NewHealthRepository(db bun.IDB, pool *pgxpool.Pool) HealthRepository {
+	return &healthRepository{db: db, pool: pool}
+}
+
+// Ping runs a trivial query, unlike sql.DB.Ping which may reuse a connection without a round trip
+func (r *healthRepository) Ping(ctx context.Context) error {
+	var one int
+	return r.db.NewRaw("SELECT 1").Scan(ctx, &one)
+}
+
+// MigrationVersion reads the table maintained by golang-migrate
+func (r *healthRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var migration struct {
+		Version int64
+		Dirty   bool
+	}
+	err := r.db.NewRaw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(ctx, &migration)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, ErrNoMigration
+	}
+	if err != nil {
+		return 0, false, err
+	}
+
+	return uint(migration.Version), migration.Dirty, nil
+}
+
+func (r *healthRepository) PoolStat() (int32, int32) {
+	stat := r.pool.Stat()
+	return stat.AcquiredConns(), stat.MaxConns()
+}
+
+func (r *healthRepository) PoolStats() PoolStats {
+	stat := r.pool.Stat()
+	return PoolStats{
+		AcquiredConns:           stat.AcquiredConns(),
+		IdleConns:               stat.IdleConns(),
+		ConstructingConns:       stat.ConstructingConns(),
+		TotalConns:              stat.TotalConns(),
+		MaxConns:                stat.MaxConns(),
+		AcquireCount:            stat.AcquireCount(),
+		AcquireDuration:         stat.AcquireDuration(),
+		EmptyAcquireCount:       stat.EmptyAcquireCount(),
+		EmptyAcquireWaitTime:    stat.EmptyAcquireWaitTime(),
+		CanceledAcquireCount:    stat.CanceledAcquireCount(),
+		NewConnsCount:           stat.NewConnsCount(),
+		MaxLifetimeDestroyCount: stat.MaxLifetimeDestroyCount(),
+		MaxIdleDestroyCount:     stat.MaxIdleDestroyCount(),
+	}
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/migrations"
+)
+
+func (s *PGRepositorySuite) TestPGHealth() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	repo := NewHealthRepository(trx, s.pgContainer.Pool)
+	ctx := context.Background()
+
+	require.NoError(t, repo.Ping(ctx))
+
+	// The test database is migrated up to the last migration
+	latest, err := migrations.LatestVersion()
+	require.NoError(t, err)
+	version, dirty, err := repo.MigrationVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, latest, version)
+	assert.False(t, dirty)
+
+	_, err = trx.NewRaw("DELETE FROM schema_migrations").Exec(ctx)
+	require.NoError(t, err)
+	_, _, err = repo.MigrationVersion(ctx)
+	assert.ErrorIs(t, err, ErrNoMigration)
+
+	acquired, total := repo.PoolStat()
+	assert.Positive(t, total)
+	assert.LessOrEqual(t, acquired, total)
+
+	stats := repo.PoolStats()
+	assert.Equal(t, total, stats.MaxConns)
+	assert.LessOrEqual(t, stats.AcquiredConns+stats.IdleConns, stats.TotalConns)
+}
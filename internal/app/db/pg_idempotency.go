@@ -0,0 +1,127 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// IdempotencyRepository defines the interface for idempotency key data access
+// Each call runs in a short transaction of its own, so that no connection is held while the request is handled
+type IdempotencyRepository interface {
+	// Claim marks the record of claim.Key as in progress with claim, unless a live record holds the key: that
+	// record is returned instead, nil meaning the key was claimed. Expired records, in progress or not, are taken over
+	Claim(ctx context.Context, claim *models.IdempotencyKey) (*models.IdempotencyKey, error)
+	// Complete stores the response of the key claimed with record.ClaimToken, or returns ErrIdempotencyClaimLost
+	// when another request took the key over meanwhile
+	Complete(ctx context.Context, record *models.IdempotencyKey) error
+	// Release deletes the claim of key made with claimToken, so that the request can be retried with it
+	Release(ctx context.Context, key string, claimToken string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// idempotencyRepository implements IdempotencyRepository using Bun
+type idempotencyRepository struct {
+	db bun.IDB
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository
+func NewIdempotencyRepository(db bun.IDB) IdempotencyRepository {
+	return &idempotencyRepository{db: db}
+}
+
+// Claim locks the record of the key with SELECT ... FOR UPDATE until it is claimed, concurrent claims of the same
+// key waiting for the transaction to end
+func (r *idempotencyRepository) Claim(ctx context.Context, claim *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	var live *models.IdempotencyKey
+	err := r.conn(ctx).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// A concurrent insert of the same key blocks here until the other transaction ends
+		if _, err := tx.NewInsert().
+			Model(&models.IdempotencyKey{Key: claim.Key, CreatedAt: claim.CreatedAt, ExpiresAt: claim.CreatedAt}).
+			On("CONFLICT (key) DO NOTHING").
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		record := new(models.IdempotencyKey)
+		if err := tx.NewSelect().
+			Model(record).
+			Where("ik.key = ?", claim.Key).
+			For("UPDATE").
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		if record.ExpiresAt.After(claim.CreatedAt) {
+			live = record
+			return nil
+		}
+
+		_, err := tx.NewUpdate().
+			Model(claim).
+			WherePK().
+			Exec(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return live, nil
+}
+
+// Complete replaces the claim with the response, unless another request took the key over meanwhile
+func (r *idempotencyRepository) Complete(ctx context.Context, record *models.IdempotencyKey) error {
+	res, err := r.conn(ctx).NewUpdate().
+		Model(record).
+		WherePK().
+		Where("claim_token = ?", record.ClaimToken).
+		Where("response_status = 0").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrIdempotencyClaimLost
+	}
+
+	return nil
+}
+
+// Release deletes the record of key while it is in progress under claimToken
+func (r *idempotencyRepository) Release(ctx context.Context, key string, claimToken string) error {
+	_, err := r.conn(ctx).NewDelete().
+		Model((*models.IdempotencyKey)(nil)).
+		Where("key = ?", key).
+		Where("claim_token = ?", claimToken).
+		Where("response_status = 0").
+		Exec(ctx)
+
+	return err
+}
+
+// DeleteExpired removes the records that expired before now
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := r.conn(ctx).NewDelete().
+		Model((*models.IdempotencyKey)(nil)).
+		Where("expires_at < ?", now).
+		Exec(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
+
+// conn returns the transaction of ctx, if any, or the repository database
+func (r *idempotencyRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
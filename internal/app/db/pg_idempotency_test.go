@@ -0,0 +1,165 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/uptrace/bun"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func (s *PGRepositorySuite) TestPGIdempotency_Claim() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	repo := NewIdempotencyRepository(trx)
+	ctx := context.Background()
+	now := time.Now()
+	claim := func(key, hash string) *models.IdempotencyKey {
+		return &models.IdempotencyKey{Key: key, RequestHash: hash, ClaimToken: hash, CreatedAt: now, ExpiresAt: now.Add(time.Minute)}
+	}
+
+	// A new key is claimed, and held in progress until completed
+	live, err := repo.Claim(ctx, claim("saved", "hash"))
+	require.NoError(t, err)
+	assert.Nil(t, live)
+
+	live, err = repo.Claim(ctx, claim("saved", "hash"))
+	require.NoError(t, err)
+	require.NotNil(t, live)
+	assert.Equal(t, "hash", live.RequestHash)
+	assert.Zero(t, live.ResponseStatus)
+
+	require.NoError(t, repo.Complete(ctx, &models.IdempotencyKey{
+		Key:                 "saved",
+		RequestHash:         "hash",
+		ClaimToken:          "hash",
+		ResponseStatus:      201,
+		ResponseContentType: "application/json",
+		ResponseBody:        []byte(`{"id":1}`),
+		CreatedAt:           now,
+		ExpiresAt:           now.Add(time.Hour),
+	}))
+
+	live, err = repo.Claim(ctx, claim("saved", "other"))
+	require.NoError(t, err)
+	require.NotNil(t, live)
+	assert.Equal(t, "hash", live.RequestHash)
+	assert.Equal(t, 201, live.ResponseStatus)
+	assert.Equal(t, []byte(`{"id":1}`), live.ResponseBody)
+
+	// A released claim frees the key, a completed record is kept
+	_, err = repo.Claim(ctx, claim("released", "hash"))
+	require.NoError(t, err)
+	require.NoError(t, repo.Release(ctx, "released", "hash"))
+	require.NoError(t, repo.Release(ctx, "saved", "hash"))
+	count, err := trx.NewSelect().Model((*models.IdempotencyKey)(nil)).Where("key IN (?)", bun.In([]string{"released", "saved"})).Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// An expired claim is taken over
+	_, err = repo.Claim(ctx, &models.IdempotencyKey{Key: "abandoned", RequestHash: "first", ClaimToken: "first", CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(-time.Minute)})
+	require.NoError(t, err)
+	live, err = repo.Claim(ctx, claim("abandoned", "second"))
+	require.NoError(t, err)
+	assert.Nil(t, live)
+}
+
+func (s *PGRepositorySuite) TestPGIdempotency_CompleteAfterTakeover() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	repo := NewIdempotencyRepository(trx)
+	ctx := context.Background()
+	now := time.Now()
+	response := func(token string, status int) *models.IdempotencyKey {
+		return &models.IdempotencyKey{Key: "slow", RequestHash: "hash", ClaimToken: token, ResponseStatus: status, CreatedAt: now, ExpiresAt: now.Add(time.Hour)}
+	}
+
+	// The claim of the first request expires while it runs, and a retry takes the key over
+	live, err := repo.Claim(ctx, &models.IdempotencyKey{Key: "slow", RequestHash: "hash", ClaimToken: "first", CreatedAt: now.Add(-time.Hour), ExpiresAt: now.Add(-time.Minute)})
+	require.NoError(t, err)
+	require.Nil(t, live)
+	live, err = repo.Claim(ctx, &models.IdempotencyKey{Key: "slow", RequestHash: "hash", ClaimToken: "second", CreatedAt: now, ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	require.Nil(t, live)
+
+	// The first request can neither store its response nor release the key
+	assert.ErrorIs(t, repo.Complete(ctx, response("first", 201)), ErrIdempotencyClaimLost)
+	require.NoError(t, repo.Release(ctx, "slow", "first"))
+
+	require.NoError(t, repo.Complete(ctx, response("second", 200)))
+	live, err = repo.Claim(ctx, &models.IdempotencyKey{Key: "slow", RequestHash: "hash", ClaimToken: "third", CreatedAt: now, ExpiresAt: now.Add(time.Minute)})
+	require.NoError(t, err)
+	require.NotNil(t, live)
+	assert.Equal(t, 200, live.ResponseStatus)
+	assert.Equal(t, "second", live.ClaimToken)
+}
+
+func (s *PGRepositorySuite) TestPGIdempotency_ClaimOnceConcurrently() {
+	t := s.T()
+
+	// Each request needs its own connection, so this test cannot run in a rolled back transaction
+	repo := NewIdempotencyRepository(s.pgContainer.DB)
+	ctx := context.Background()
+	defer func() {
+		_, err := s.pgContainer.DB.NewDelete().Model((*models.IdempotencyKey)(nil)).Where("key = ?", "concurrent").Exec(ctx)
+		require.NoError(t, err)
+	}()
+
+	const requests = 5
+	now := time.Now()
+	var claimed atomic.Int32
+	var wg sync.WaitGroup
+	for range requests {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			live, err := repo.Claim(ctx, &models.IdempotencyKey{Key: "concurrent", RequestHash: "hash", CreatedAt: now, ExpiresAt: now.Add(time.Minute)})
+			assert.NoError(t, err)
+			if live == nil {
+				claimed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), claimed.Load())
+}
+
+func (s *PGRepositorySuite) TestPGIdempotency_DeleteExpired() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	now := time.Now()
+	s.insert(t, trx, &[]*models.IdempotencyKey{
+		{Key: "expired", ExpiresAt: now.Add(-time.Minute)},
+		{Key: "live", ExpiresAt: now.Add(time.Hour)},
+	})
+
+	repo := NewIdempotencyRepository(trx)
+	purged, err := repo.DeleteExpired(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+}
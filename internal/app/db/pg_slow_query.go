@@ -0,0 +1,81 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// SlowQueryPlanRepository defines the interface for the plans captured for slow statements
+type SlowQueryPlanRepository interface {
+	// Explain runs query under EXPLAIN (ANALYZE, BUFFERS) and returns its plan, executing it once more in a read-only
+	// transaction that is rolled back
+	Explain(ctx context.Context, query string) (string, error)
+	Create(ctx context.Context, plan *models.SlowQueryPlan) error
+	List(ctx context.Context, limit int) ([]*models.SlowQueryPlan, error)
+}
+
+// slowQueryPlanRepository implements SlowQueryPlanRepository using Bun
+type slowQueryPlanRepository struct {
+	db bun.IDB
+}
+
+// NewSlowQueryPlanRepository creates a new instance of SlowQueryPlanRepository
+func NewSlowQueryPlanRepository(db bun.IDB) SlowQueryPlanRepository {
+	return &slowQueryPlanRepository{db: db}
+}
+
+// Explain returns the plan as text, one line per node
+// The query was already formatted by Bun, it is passed as is so that its question marks are not taken for
+// placeholders
+// The transaction keeps the statement from writing, whatever it calls, and is never committed
+func (r *slowQueryPlanRepository) Explain(ctx context.Context, query string) (string, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var lines []string
+	if err = tx.NewRaw("EXPLAIN (ANALYZE, BUFFERS) ?", bun.Safe(query)).Scan(ctx, &lines); err != nil {
+		return "", err
+	}
+
+	return strings.Join(lines, "\n"), nil
+}
+
+// Create inserts a captured plan
+func (r *slowQueryPlanRepository) Create(ctx context.Context, plan *models.SlowQueryPlan) error {
+	plan.CapturedAt = time.Now()
+
+	_, err := r.db.NewInsert().
+		Model(plan).
+		Returning("id").
+		Exec(ctx)
+
+	return err
+}
+
+// List retrieves the last limit plans, newest first
+func (r *slowQueryPlanRepository) List(ctx context.Context, limit int) ([]*models.SlowQueryPlan, error) {
+	plans := make([]*models.SlowQueryPlan, 0)
+
+	err := r.db.NewSelect().
+		Model(&plans).
+		Order("sqp.captured_at DESC", "sqp.id DESC").
+		Limit(limit).
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func (s *PGRepositorySuite) TestPGSlowQueryPlan() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	repo := NewSlowQueryPlanRepository(trx)
+	ctx := context.Background()
+
+	// Question marks of the formatted query are not placeholders
+	plan, err := repo.Explain(ctx, "SELECT id FROM tasks WHERE title = '?'")
+	require.NoError(t, err)
+	assert.Contains(t, plan, "Execution Time")
+
+	first := &models.SlowQueryPlan{Query: "SELECT ?", Operation: "SELECT", DurationMS: 250, Plan: plan}
+	require.NoError(t, repo.Create(ctx, first))
+	second := &models.SlowQueryPlan{Query: "SELECT ? FROM tasks", Operation: "SELECT", DurationMS: 500, Plan: plan}
+	require.NoError(t, repo.Create(ctx, second))
+
+	plans, err := repo.List(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, plans, 1)
+	assert.Equal(t, second.ID, plans[0].ID)
+	assert.Equal(t, "SELECT ? FROM tasks", plans[0].Query)
+}
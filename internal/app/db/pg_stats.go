@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+
+	"github.com/uptrace/bun"
+)
+
+// StatsRepository defines the interface for the counts exposed as business metrics
+type StatsRepository interface {
+	// Count returns the number of tasks and of items not completed
+	Count(ctx context.Context) (tasks int64, openItems int64, err error)
+}
+
+// statsRepository implements StatsRepository using Bun
+type statsRepository struct {
+	db bun.IDB
+}
+
+// NewStatsRepository creates a new instance of StatsRepository
+func NewStatsRepository(db bun.IDB) StatsRepository {
+	return &statsRepository{db: db}
+}
+
+// Count counts both in one round trip, the counts being consistent with each other
+func (r *statsRepository) Count(ctx context.Context) (int64, int64, error) {
+	var stats struct {
+		Tasks     int64
+		OpenItems int64
+	}
+	err := r.db.NewRaw(
+		"SELECT (SELECT count(*) FROM tasks) AS tasks, (SELECT count(*) FROM task_items WHERE NOT completed) AS open_items",
+	).Scan(ctx, &stats)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return stats.Tasks, stats.OpenItems, nil
+}
@@ -0,0 +1,38 @@
+package db
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func (s *PGRepositorySuite) TestPGStats_Count() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	repo := NewStatsRepository(trx)
+	ctx := context.Background()
+
+	tasks, openItems, err := repo.Count(ctx)
+	require.NoError(t, err)
+
+	task := &models.Task{Title: "Shopping"}
+	s.insert(t, trx, task)
+	s.insert(t, trx, &[]models.TaskItem{
+		{TaskID: task.ID, Title: "Buy milk"},
+		{TaskID: task.ID, Title: "Buy bread", Completed: true},
+	})
+
+	gotTasks, gotOpenItems, err := repo.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, tasks+1, gotTasks)
+	assert.Equal(t, openItems+1, gotOpenItems)
+}
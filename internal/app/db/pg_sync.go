@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/uptrace/bun"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// changeLockID identifies the advisory lock ordering change sequence readers after in-flight writers
+const changeLockID int64 = 0x7461736b73
+
+// Changes holds the rows changed in a range of the change sequence
+type Changes struct {
+	Tasks      []*models.Task
+	Items      []*models.TaskItem
+	Tombstones []*models.Tombstone
+}
+
+// SyncRepository defines the interface for reading changes to tasks and items by change sequence
+type SyncRepository interface {
+	// CurrentChangeSeq returns the last change sequence value, once every change up to it is committed
+	CurrentChangeSeq(ctx context.Context) (int64, error)
+	// ListChanges returns the tasks, items and tombstones with a change sequence in (since, until]
+	ListChanges(ctx context.Context, since, until int64) (*Changes, error)
+}
+
+// syncRepository implements SyncRepository using Bun
+type syncRepository struct {
+	db bun.IDB
+}
+
+// NewSyncRepository creates a new instance of SyncRepository
+func NewSyncRepository(db bun.IDB) SyncRepository {
+	return &syncRepository{db: db}
+}
+
+// CurrentChangeSeq takes the change lock exclusively, waiting for the writers holding it to end
+// Sequence values are handed out before commit, so without it a reader could move past a value
+// whose transaction commits afterwards and miss its change
+func (r *syncRepository) CurrentChangeSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	err := r.conn(ctx).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(?)", changeLockID); err != nil {
+			return err
+		}
+
+		return tx.NewRaw("SELECT CASE WHEN is_called THEN last_value ELSE 0 END FROM change_seq").
+			Scan(ctx, &seq)
+	})
+
+	return seq, err
+}
+
+// ListChanges retrieves the changed tasks without their items, the changed items and the tombstones, in change order
+func (r *syncRepository) ListChanges(ctx context.Context, since, until int64) (*Changes, error) {
+	idb := r.conn(ctx)
+	changes := &Changes{
+		Tasks:      make([]*models.Task, 0),
+		Items:      make([]*models.TaskItem, 0),
+		Tombstones: make([]*models.Tombstone, 0),
+	}
+
+	if err := idb.NewSelect().
+		Model(&changes.Tasks).
+		Where("t.change_seq > ?", since).
+		Where("t.change_seq <= ?", until).
+		Order("t.change_seq").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := idb.NewSelect().
+		Model(&changes.Items).
+		Where("ti.change_seq > ?", since).
+		Where("ti.change_seq <= ?", until).
+		Order("ti.change_seq").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := idb.NewSelect().
+		Model(&changes.Tombstones).
+		Where("tb.change_seq > ?", since).
+		Where("tb.change_seq <= ?", until).
+		Order("tb.change_seq").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// conn returns the transaction of ctx, if any, or the repository database
+func (r *syncRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// trackChanges runs fn in a transaction, or a savepoint within the transaction of ctx, holding the change lock
+// in shared mode until commit so that CurrentChangeSeq waits for the change sequence values fn draws
+func trackChanges(ctx context.Context, db bun.IDB, fn func(ctx context.Context, tx bun.Tx) error) error {
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock_shared(?)", changeLockID); err != nil {
+			return err
+		}
+
+		return fn(ctx, tx)
+	})
+}
+
+// insertTombstone records the deletion of a task or an item
+func insertTombstone(ctx context.Context, tx bun.Tx, entityType string, entityID, taskID int64, icalUID string) error {
+	_, err := tx.NewInsert().
+		Model(&models.Tombstone{EntityType: entityType, EntityID: entityID, TaskID: taskID, ICalUID: icalUID}).
+		Exec(ctx)
+	return err
+}
+
+// fieldVersionsJSON encodes field versions for a JSONB merge, nil ones merging nothing
+func fieldVersionsJSON(versions models.FieldVersions) string {
+	if len(versions) == 0 {
+		return "{}"
+	}
+
+	// A map of times cannot fail to encode
+	data, _ := json.Marshal(versions)
+	return string(data)
+}
@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func (s *PGRepositorySuite) TestPGSync_ListChanges() {
+	t := s.T()
+	ctx := context.Background()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	syncRepo := NewSyncRepository(trx)
+	taskRepo := NewTaskRepository(trx)
+
+	start, err := syncRepo.CurrentChangeSeq(ctx)
+	require.NoError(t, err)
+
+	kept := &models.Task{Title: "Shopping", Items: []*models.TaskItem{{Title: "Buy milk"}}}
+	require.NoError(t, taskRepo.Create(ctx, kept))
+	deleted := &models.Task{Title: "Work"}
+	require.NoError(t, taskRepo.Create(ctx, deleted))
+	require.NoError(t, taskRepo.Delete(ctx, deleted.ID))
+
+	afterCreate, err := syncRepo.CurrentChangeSeq(ctx)
+	require.NoError(t, err)
+	assert.Greater(t, afterCreate, start)
+
+	changes, err := syncRepo.ListChanges(ctx, start, afterCreate)
+	require.NoError(t, err)
+	require.Len(t, changes.Tasks, 1)
+	assert.Equal(t, kept.ID, changes.Tasks[0].ID)
+	assert.Empty(t, changes.Tasks[0].Items)
+	require.Len(t, changes.Items, 1)
+	assert.Equal(t, "Buy milk", changes.Items[0].Title)
+	require.Len(t, changes.Tombstones, 1)
+	assert.Equal(t, models.TombstoneTask, changes.Tombstones[0].EntityType)
+	assert.Equal(t, deleted.ID, changes.Tombstones[0].EntityID)
+
+	// Only the item changes after the new token
+	item := changes.Items[0]
+	item.Completed = true
+	item.FieldVersions = models.FieldVersions{models.FieldCompleted: time.Now()}
+	require.NoError(t, taskRepo.UpdateItem(ctx, item))
+	assert.Contains(t, item.FieldVersions, models.FieldTitle, "stored field versions are merged, not replaced")
+
+	afterUpdate, err := syncRepo.CurrentChangeSeq(ctx)
+	require.NoError(t, err)
+
+	changes, err = syncRepo.ListChanges(ctx, afterCreate, afterUpdate)
+	require.NoError(t, err)
+	assert.Empty(t, changes.Tasks)
+	require.Len(t, changes.Items, 1)
+	assert.True(t, changes.Items[0].Completed)
+	assert.Empty(t, changes.Tombstones)
+
+	require.NoError(t, taskRepo.DeleteItem(ctx, kept.ID, item.ID))
+	afterDelete, err := syncRepo.CurrentChangeSeq(ctx)
+	require.NoError(t, err)
+
+	changes, err = syncRepo.ListChanges(ctx, afterUpdate, afterDelete)
+	require.NoError(t, err)
+	require.Len(t, changes.Tombstones, 1)
+	assert.Equal(t, models.TombstoneTaskItem, changes.Tombstones[0].EntityType)
+	assert.Equal(t, kept.ID, changes.Tombstones[0].TaskID)
+}
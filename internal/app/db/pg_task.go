@@ -2,6 +2,9 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/uptrace/bun"
@@ -9,12 +12,29 @@ import (
 	"github.com/clevertechware/todo-bun-app/internal/app/models"
 )
 
+// taskCursorBatchSize is how many tasks ForEach fetches from its cursor at once
+const taskCursorBatchSize = 500
+
 // TaskRepository defines the interface for task data access
 type TaskRepository interface {
 	Create(ctx context.Context, task *models.Task) error
+	Update(ctx context.Context, task *models.Task) error
 	Delete(ctx context.Context, taskID int64) error
 	GetByID(ctx context.Context, taskID int64) (*models.Task, error)
+	// GetForUpdate retrieves a task with its items, the task being locked until the end of the transaction of ctx
+	GetForUpdate(ctx context.Context, taskID int64) (*models.Task, error)
+	GetByICalUID(ctx context.Context, uid string) (*models.Task, error)
 	List(ctx context.Context) ([]*models.Task, error)
+	ForEach(ctx context.Context, fn func(task *models.Task) error) error
+	Search(ctx context.Context, filter TaskFilter) ([]*models.Task, int, error)
+	ListItemsByTaskIDs(ctx context.Context, taskIDs []int64) ([]*models.TaskItem, error)
+	// GetItemForUpdate retrieves an item of a task, locked until the end of the transaction of ctx
+	GetItemForUpdate(ctx context.Context, taskID int64, itemID int64) (*models.TaskItem, error)
+	ListTitles(ctx context.Context, titles []string) ([]string, error)
+	Stats(ctx context.Context) (*TaskStats, error)
+	CreateItem(ctx context.Context, item *models.TaskItem) error
+	UpdateItem(ctx context.Context, item *models.TaskItem) error
+	DeleteItem(ctx context.Context, taskID int64, itemID int64) error
 }
 
 // taskRepository implements TaskRepository using Bun
@@ -27,13 +47,14 @@ func NewTaskRepository(db bun.IDB) TaskRepository {
 	return &taskRepository{db: db}
 }
 
-// Create inserts a new task with its items in a transaction
+// Create inserts a new task with its items in a transaction, or a savepoint within the transaction of ctx
 func (r *taskRepository) Create(ctx context.Context, task *models.Task) error {
-	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+	return trackChanges(ctx, r.conn(ctx), func(ctx context.Context, tx bun.Tx) error {
 		// Set timestamps
 		now := time.Now()
 		task.CreatedAt = now
 		task.UpdatedAt = now
+		task.FieldVersions.Init(now, models.FieldTitle, models.FieldDescription, models.FieldDueAt)
 
 		// Insert the task
 		if _, err := tx.NewInsert().
@@ -48,6 +69,7 @@ func (r *taskRepository) Create(ctx context.Context, task *models.Task) error {
 				item.TaskID = task.ID
 				item.CreatedAt = now
 				item.UpdatedAt = now
+				item.FieldVersions.Init(now, models.FieldTitle, models.FieldCompleted)
 			}
 
 			if _, err := tx.NewInsert().
@@ -61,35 +83,78 @@ func (r *taskRepository) Create(ctx context.Context, task *models.Task) error {
 	})
 }
 
-// Delete removes a task by ID (cascade deletes items via FK constraint)
-func (r *taskRepository) Delete(ctx context.Context, taskID int64) error {
-	result, err := r.db.NewDelete().
-		Model((*models.Task)(nil)).
-		Where("id = ?", taskID).
-		Exec(ctx)
+// Update updates the title and description of a task, merging its field versions into the stored ones
+func (r *taskRepository) Update(ctx context.Context, task *models.Task) error {
+	task.UpdatedAt = time.Now()
 
-	if err != nil {
-		return err
-	}
-
-	// Check if the task was actually deleted
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
+	err := trackChanges(ctx, r.conn(ctx), func(ctx context.Context, tx bun.Tx) error {
+		return tx.NewUpdate().
+			Model(task).
+			Column("title", "description", "due_at", "updated_at").
+			Set("change_seq = nextval('change_seq')").
+			Set("field_versions = field_versions || ?", fieldVersionsJSON(task.FieldVersions)).
+			Where("t.id = ?", task.ID).
+			Returning("*").
+			Scan(ctx)
+	})
 
-	if rowsAffected == 0 {
+	if errors.Is(err, sql.ErrNoRows) {
 		return ErrTaskNotFound
 	}
 
-	return nil
+	return err
+}
+
+// Delete removes a task by ID (cascade deletes items via FK constraint) and records the tombstones of the task
+// and its items
+func (r *taskRepository) Delete(ctx context.Context, taskID int64) error {
+	return trackChanges(ctx, r.conn(ctx), func(ctx context.Context, tx bun.Tx) error {
+		// Lock the task so that no item is added while the items are listed
+		task := new(models.Task)
+		err := tx.NewSelect().
+			Model(task).
+			Column("t.id", "t.ical_uid").
+			Where("t.id = ?", taskID).
+			For("UPDATE").
+			Scan(ctx)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTaskNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = tx.NewSelect().
+			Model(&task.Items).
+			Column("ti.id", "ti.ical_uid").
+			Where("ti.task_id = ?", taskID).
+			Scan(ctx); err != nil {
+			return err
+		}
+
+		if _, err = tx.NewDelete().
+			Model((*models.Task)(nil)).
+			Where("id = ?", taskID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		for _, item := range task.Items {
+			if err = insertTombstone(ctx, tx, models.TombstoneTaskItem, item.ID, taskID, item.ICalUID); err != nil {
+				return err
+			}
+		}
+
+		return insertTombstone(ctx, tx, models.TombstoneTask, taskID, taskID, task.ICalUID)
+	})
 }
 
 // GetByID retrieves a task by ID with its items
 func (r *taskRepository) GetByID(ctx context.Context, taskID int64) (*models.Task, error) {
 	task := new(models.Task)
 
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(task).
 		Where("t.id = ?", taskID).
 		Relation("Items").
@@ -102,11 +167,57 @@ func (r *taskRepository) GetByID(ctx context.Context, taskID int64) (*models.Tas
 	return task, nil
 }
 
+// GetForUpdate locks the task with SELECT ... FOR UPDATE, so that concurrent updates are applied one after the
+// other instead of overwriting each other's fields
+func (r *taskRepository) GetForUpdate(ctx context.Context, taskID int64) (*models.Task, error) {
+	task := new(models.Task)
+
+	err := r.conn(ctx).NewSelect().
+		Model(task).
+		Where("t.id = ?", taskID).
+		Relation("Items").
+		For("UPDATE").
+		Scan(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// GetByICalUID retrieves, with its items, the task whose VTODO or the VTODO of one of whose items has the given UID
+func (r *taskRepository) GetByICalUID(ctx context.Context, uid string) (*models.Task, error) {
+	task := new(models.Task)
+	idb := r.conn(ctx)
+
+	itemTaskID := idb.NewSelect().
+		Model((*models.TaskItem)(nil)).
+		Column("ti.task_id").
+		Where("ti.ical_uid = ?", uid)
+
+	err := idb.NewSelect().
+		Model(task).
+		Where("t.ical_uid = ?", uid).
+		WhereOr("t.id = (?)", itemTaskID).
+		Relation("Items").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
 // List retrieves all tasks with their items
 func (r *taskRepository) List(ctx context.Context) ([]*models.Task, error) {
 	var tasks []*models.Task
 
-	err := r.db.NewSelect().
+	err := r.conn(ctx).NewSelect().
 		Model(&tasks).
 		Relation("Items").
 		Order("t.created_at DESC").
@@ -118,3 +229,266 @@ func (r *taskRepository) List(ctx context.Context) ([]*models.Task, error) {
 
 	return tasks, nil
 }
+
+// ForEach calls fn with every task and its items, in the order of List, reading them in batches through a
+// server-side cursor so that memory does not grow with the number of tasks
+// The cursor lives in a read-only transaction, closed when ctx is done or fn returns an error
+func (r *taskRepository) ForEach(ctx context.Context, fn func(task *models.Task) error) error {
+	return r.conn(ctx).RunInTx(ctx, &sql.TxOptions{ReadOnly: true}, func(ctx context.Context, tx bun.Tx) error {
+		ctx = context.WithValue(ctx, txContextKey{}, tx)
+
+		query := tx.NewSelect().
+			Model((*models.Task)(nil)).
+			Order("t.created_at DESC")
+		if _, err := tx.ExecContext(ctx, "DECLARE task_cursor NO SCROLL CURSOR FOR ?", query); err != nil {
+			return err
+		}
+
+		for {
+			var tasks []*models.Task
+			if err := tx.NewRaw("FETCH ? FROM task_cursor", taskCursorBatchSize).Scan(ctx, &tasks); err != nil {
+				return err
+			}
+			if len(tasks) == 0 {
+				break
+			}
+
+			if err := r.attachItems(ctx, tasks); err != nil {
+				return err
+			}
+			for _, task := range tasks {
+				if err := fn(task); err != nil {
+					return err
+				}
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, "CLOSE task_cursor")
+		return err
+	})
+}
+
+// attachItems loads the items of a batch of tasks
+func (r *taskRepository) attachItems(ctx context.Context, tasks []*models.Task) error {
+	taskIDs := make([]int64, 0, len(tasks))
+	byID := make(map[int64]*models.Task, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+		byID[task.ID] = task
+		task.Items = make([]*models.TaskItem, 0)
+	}
+
+	items, err := r.ListItemsByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		byID[item.TaskID].Items = append(byID[item.TaskID].Items, item)
+	}
+
+	return nil
+}
+
+// Search retrieves tasks matching the filter, without their items, along with the total number of matches
+func (r *taskRepository) Search(ctx context.Context, filter TaskFilter) ([]*models.Task, int, error) {
+	var tasks []*models.Task
+
+	idb := r.conn(ctx)
+	query := idb.NewSelect().
+		Model(&tasks)
+
+	if filter.TitleContains != "" {
+		query = query.Where("t.title ILIKE ?", "%"+escapeLike(filter.TitleContains)+"%")
+	}
+	if filter.HasOpenItems != nil {
+		openItems := idb.NewSelect().
+			TableExpr("task_items AS oi").
+			ColumnExpr("1").
+			Where("oi.task_id = t.id").
+			Where("oi.completed = FALSE")
+		if *filter.HasOpenItems {
+			query = query.Where("EXISTS (?)", openItems)
+		} else {
+			query = query.Where("NOT EXISTS (?)", openItems)
+		}
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("t.created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("t.created_at < ?", *filter.CreatedBefore)
+	}
+
+	count, err := query.
+		Order("t.created_at DESC", "t.id DESC").
+		Limit(filter.Limit).
+		Offset(filter.Offset).
+		ScanAndCount(ctx)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return tasks, count, nil
+}
+
+// ListItemsByTaskIDs retrieves the items of several tasks in a single query
+func (r *taskRepository) ListItemsByTaskIDs(ctx context.Context, taskIDs []int64) ([]*models.TaskItem, error) {
+	items := make([]*models.TaskItem, 0)
+	if len(taskIDs) == 0 {
+		return items, nil
+	}
+
+	err := r.conn(ctx).NewSelect().
+		Model(&items).
+		Where("ti.task_id IN (?)", bun.In(taskIDs)).
+		Order("ti.task_id", "ti.id").
+		Scan(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ListTitles retrieves the titles of the tasks matching any of titles, ignoring case and surrounding spaces
+func (r *taskRepository) ListTitles(ctx context.Context, titles []string) ([]string, error) {
+	existing := make([]string, 0)
+	if len(titles) == 0 {
+		return existing, nil
+	}
+
+	keys := make([]string, 0, len(titles))
+	for _, title := range titles {
+		keys = append(keys, strings.ToLower(strings.TrimSpace(title)))
+	}
+
+	err := r.conn(ctx).NewSelect().
+		Model((*models.Task)(nil)).
+		Column("t.title").
+		Where("lower(trim(t.title)) IN (?)", bun.In(keys)).
+		Scan(ctx, &existing)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// Stats computes aggregated counters over all tasks and items
+func (r *taskRepository) Stats(ctx context.Context) (*TaskStats, error) {
+	stats := new(TaskStats)
+
+	err := r.conn(ctx).NewSelect().
+		TableExpr("task_items").
+		ColumnExpr("(SELECT count(*) FROM tasks) AS task_count").
+		ColumnExpr("count(*) AS item_count").
+		ColumnExpr("count(*) FILTER (WHERE completed) AS completed_item_count").
+		Scan(ctx, stats)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// CreateItem inserts a new item into an existing task
+func (r *taskRepository) CreateItem(ctx context.Context, item *models.TaskItem) error {
+	now := time.Now()
+	item.CreatedAt = now
+	item.UpdatedAt = now
+	item.FieldVersions.Init(now, models.FieldTitle, models.FieldCompleted)
+
+	err := trackChanges(ctx, r.conn(ctx), func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewInsert().
+			Model(item).
+			Exec(ctx)
+		return err
+	})
+
+	if err != nil && isForeignKeyViolation(err) {
+		return ErrTaskNotFound
+	}
+
+	return err
+}
+
+// UpdateItem updates the title and completion status of a task item, merging its field versions into the stored ones
+func (r *taskRepository) UpdateItem(ctx context.Context, item *models.TaskItem) error {
+	item.UpdatedAt = time.Now()
+
+	err := trackChanges(ctx, r.conn(ctx), func(ctx context.Context, tx bun.Tx) error {
+		return tx.NewUpdate().
+			Model(item).
+			Column("title", "completed", "updated_at").
+			Set("change_seq = nextval('change_seq')").
+			Set("field_versions = field_versions || ?", fieldVersionsJSON(item.FieldVersions)).
+			Where("ti.id = ?", item.ID).
+			Where("ti.task_id = ?", item.TaskID).
+			Returning("*").
+			Scan(ctx)
+	})
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTaskItemNotFound
+	}
+
+	return err
+}
+
+// GetItemForUpdate locks the item with SELECT ... FOR UPDATE, so that concurrent updates are applied one after the
+// other instead of overwriting each other's fields
+func (r *taskRepository) GetItemForUpdate(ctx context.Context, taskID int64, itemID int64) (*models.TaskItem, error) {
+	item := new(models.TaskItem)
+
+	err := r.conn(ctx).NewSelect().
+		Model(item).
+		Where("ti.id = ?", itemID).
+		Where("ti.task_id = ?", taskID).
+		For("UPDATE").
+		Scan(ctx)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTaskItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// DeleteItem removes an item from a task and records its tombstone
+func (r *taskRepository) DeleteItem(ctx context.Context, taskID int64, itemID int64) error {
+	return trackChanges(ctx, r.conn(ctx), func(ctx context.Context, tx bun.Tx) error {
+		var icalUID string
+		err := tx.NewDelete().
+			Model((*models.TaskItem)(nil)).
+			Where("id = ?", itemID).
+			Where("task_id = ?", taskID).
+			Returning("ical_uid").
+			Scan(ctx, &icalUID)
+
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrTaskItemNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		return insertTombstone(ctx, tx, models.TombstoneTaskItem, itemID, taskID, icalUID)
+	})
+}
+
+// conn returns the transaction of ctx, if any, or the repository database
+func (r *taskRepository) conn(ctx context.Context) bun.IDB {
+	return conn(ctx, r.db)
+}
+
+// escapeLike escapes the LIKE wildcards of a user-provided pattern
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
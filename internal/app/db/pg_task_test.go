@@ -2,7 +2,10 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -221,6 +224,39 @@ func (s *PGRepositorySuite) TestPGTask_GetByID() {
 	}
 }
 
+func (s *PGRepositorySuite) TestPGTask_GetByICalUID() {
+	t := s.T()
+	ctx := context.Background()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	repo := NewTaskRepository(trx)
+	task := &models.Task{
+		Title:   "Shopping",
+		ICalUID: "0C8E5A2B-7F3D-4B6A-9E21-5D4C3B2A1F00",
+		Items:   []*models.TaskItem{{Title: "Buy milk"}},
+	}
+	require.NoError(t, repo.Create(ctx, task))
+	require.Len(t, task.Items, 1)
+	assert.Regexp(t, `^item-\d+@todo-bun-app$`, task.Items[0].ICalUID, "the database derives missing UIDs")
+
+	got, err := repo.GetByICalUID(ctx, task.ICalUID)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, got.ID)
+	assert.Len(t, got.Items, 1)
+
+	got, err = repo.GetByICalUID(ctx, task.Items[0].ICalUID)
+	require.NoError(t, err)
+	assert.Equal(t, task.ID, got.ID, "an item UID finds the task of the item")
+
+	_, err = repo.GetByICalUID(ctx, "unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
 func (s *PGRepositorySuite) TestPGTask_List() {
 	type args struct {
 		ctx context.Context
@@ -370,6 +406,19 @@ func (s *PGRepositorySuite) TestPGTask_Delete() {
 					Count(context.Background())
 				require.NoError(t, err)
 				assert.Equal(t, 0, count)
+
+				// Verify the task and its items left tombstones carrying their UIDs
+				var tombstones []*models.Tombstone
+				err = client.NewSelect().
+					Model(&tombstones).
+					Order("tb.entity_type").
+					Scan(context.Background())
+				require.NoError(t, err)
+				require.Len(t, tombstones, 2)
+				assert.Equal(t, models.TombstoneTask, tombstones[0].EntityType)
+				assert.Regexp(t, `^task-\d+@todo-bun-app$`, tombstones[0].ICalUID)
+				assert.Equal(t, models.TombstoneTaskItem, tombstones[1].EntityType)
+				assert.Regexp(t, `^item-\d+@todo-bun-app$`, tombstones[1].ICalUID)
 			},
 			wantErr: assert.NoError,
 		},
@@ -418,3 +467,382 @@ func (s *PGRepositorySuite) TestPGTask_Delete() {
 		})
 	}
 }
+
+func (s *PGRepositorySuite) TestPGTask_Search() {
+	hasOpenItems := true
+
+	type args struct {
+		ctx    context.Context
+		filter TaskFilter
+	}
+
+	tests := []struct {
+		name       string
+		args       args
+		seed       func(t *testing.T, client bun.IDB)
+		wantTitles []string
+		wantCount  int
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should filter tasks by title",
+			args: args{
+				ctx:    context.Background(),
+				filter: TaskFilter{TitleContains: "shop"},
+			},
+			seed: func(t *testing.T, client bun.IDB) {
+				s.insert(t, client, &models.Task{Title: "Shopping"})
+				s.insert(t, client, &models.Task{Title: "Work"})
+			},
+			wantTitles: []string{"Shopping"},
+			wantCount:  1,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "should filter tasks with open items",
+			args: args{
+				ctx:    context.Background(),
+				filter: TaskFilter{HasOpenItems: &hasOpenItems},
+			},
+			seed: func(t *testing.T, client bun.IDB) {
+				open := &models.Task{Title: "Open"}
+				s.insert(t, client, open)
+				s.insert(t, client, &models.TaskItem{TaskID: open.ID, Title: "Todo", Completed: false})
+
+				done := &models.Task{Title: "Done"}
+				s.insert(t, client, done)
+				s.insert(t, client, &models.TaskItem{TaskID: done.ID, Title: "Done", Completed: true})
+			},
+			wantTitles: []string{"Open"},
+			wantCount:  1,
+			wantErr:    assert.NoError,
+		},
+		{
+			name: "should paginate and return total count",
+			args: args{
+				ctx:    context.Background(),
+				filter: TaskFilter{Limit: 1, Offset: 1},
+			},
+			seed: func(t *testing.T, client bun.IDB) {
+				s.insert(t, client, &models.Task{Title: "First"})
+				s.insert(t, client, &models.Task{Title: "Second"})
+			},
+			wantTitles: []string{"First"},
+			wantCount:  2,
+			wantErr:    assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			t := s.T()
+
+			trx, err := s.pgContainer.TxBegin()
+			require.NoError(t, err)
+			defer func() {
+				require.NoError(t, trx.Rollback())
+			}()
+
+			repo := NewTaskRepository(trx)
+			if tt.seed != nil {
+				tt.seed(t, trx)
+			}
+
+			tasks, count, err := repo.Search(tt.args.ctx, tt.args.filter)
+
+			tt.wantErr(t, err)
+			titles := make([]string, 0, len(tasks))
+			for _, task := range tasks {
+				titles = append(titles, task.Title)
+				assert.Empty(t, task.Items)
+			}
+			assert.Equal(t, tt.wantTitles, titles)
+			assert.Equal(t, tt.wantCount, count)
+		})
+	}
+}
+
+func (s *PGRepositorySuite) TestPGTask_ListItemsByTaskIDs() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	first := &models.Task{Title: "First"}
+	s.insert(t, trx, first)
+	second := &models.Task{Title: "Second"}
+	s.insert(t, trx, second)
+	other := &models.Task{Title: "Other"}
+	s.insert(t, trx, other)
+	s.insert(t, trx, &[]*models.TaskItem{
+		{TaskID: first.ID, Title: "A"},
+		{TaskID: second.ID, Title: "B"},
+		{TaskID: second.ID, Title: "C"},
+		{TaskID: other.ID, Title: "D"},
+	})
+
+	repo := NewTaskRepository(trx)
+	items, err := repo.ListItemsByTaskIDs(context.Background(), []int64{first.ID, second.ID})
+
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, "A", items[0].Title)
+	assert.Equal(t, "B", items[1].Title)
+	assert.Equal(t, "C", items[2].Title)
+}
+
+func (s *PGRepositorySuite) TestPGTask_GetForUpdate() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	task := &models.Task{Title: "Shopping"}
+	s.insert(t, trx, task)
+	s.insert(t, trx, &models.TaskItem{TaskID: task.ID, Title: "Buy milk"})
+
+	repo := NewTaskRepository(trx)
+	got, err := repo.GetForUpdate(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Shopping", got.Title)
+	require.Len(t, got.Items, 1)
+	assert.Equal(t, "Buy milk", got.Items[0].Title)
+
+	_, err = repo.GetForUpdate(context.Background(), task.ID+1)
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
+func (s *PGRepositorySuite) TestPGTask_GetItemForUpdate() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	task := &models.Task{Title: "Shopping"}
+	s.insert(t, trx, task)
+	item := &models.TaskItem{TaskID: task.ID, Title: "Buy milk"}
+	s.insert(t, trx, item)
+
+	repo := NewTaskRepository(trx)
+	got, err := repo.GetItemForUpdate(context.Background(), task.ID, item.ID)
+	require.NoError(t, err)
+	assert.Equal(t, item.ID, got.ID)
+	assert.Equal(t, "Buy milk", got.Title)
+
+	_, err = repo.GetItemForUpdate(context.Background(), task.ID+1, item.ID)
+	assert.ErrorIs(t, err, ErrTaskItemNotFound)
+}
+
+func (s *PGRepositorySuite) TestPGTask_ForEach() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	// More tasks than a cursor batch, the newest first
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	tasks := make([]*models.Task, 0, taskCursorBatchSize+2)
+	for i := range taskCursorBatchSize + 2 {
+		tasks = append(tasks, &models.Task{Title: "Task", CreatedAt: createdAt.Add(-time.Duration(i) * time.Minute)})
+	}
+	s.insert(t, trx, &tasks)
+	last := tasks[len(tasks)-1]
+	s.insert(t, trx, &[]*models.TaskItem{
+		{TaskID: tasks[0].ID, Title: "A"},
+		{TaskID: last.ID, Title: "B"},
+		{TaskID: last.ID, Title: "C"},
+	})
+
+	repo := NewTaskRepository(trx)
+
+	var ids []int64
+	var lastItems []*models.TaskItem
+	err = repo.ForEach(context.Background(), func(task *models.Task) error {
+		ids = append(ids, task.ID)
+		lastItems = task.Items
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, ids, len(tasks))
+	assert.Equal(t, tasks[0].ID, ids[0])
+	assert.Equal(t, last.ID, ids[len(ids)-1])
+	require.Len(t, lastItems, 2)
+	assert.Equal(t, "B", lastItems[0].Title)
+
+	// An error of fn stops the iteration
+	errStop := errors.New("stop")
+	calls := 0
+	err = repo.ForEach(context.Background(), func(*models.Task) error {
+		calls++
+		return errStop
+	})
+
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, calls)
+}
+
+func (s *PGRepositorySuite) TestPGTask_Stats() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	task := &models.Task{Title: "Shopping"}
+	s.insert(t, trx, task)
+	s.insert(t, trx, &[]*models.TaskItem{
+		{TaskID: task.ID, Title: "Buy milk", Completed: true},
+		{TaskID: task.ID, Title: "Buy bread", Completed: false},
+	})
+
+	repo := NewTaskRepository(trx)
+	stats, err := repo.Stats(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &TaskStats{TaskCount: 1, ItemCount: 2, CompletedItemCount: 1}, stats)
+}
+
+func (s *PGRepositorySuite) TestPGTask_ListTitles() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	s.insert(t, trx, &[]*models.Task{
+		{Title: "Shopping"},
+		{Title: " Work "},
+		{Title: "Holidays"},
+	})
+
+	repo := NewTaskRepository(trx)
+	titles, err := repo.ListTitles(context.Background(), []string{"SHOPPING", "work", "Garden"})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Shopping", " Work "}, titles)
+
+	titles, err = repo.ListTitles(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, titles)
+}
+
+func (s *PGRepositorySuite) TestPGTask_Update() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	task := &models.Task{Title: "Shopping", Description: "Weekly"}
+	s.insert(t, trx, task)
+
+	repo := NewTaskRepository(trx)
+	dueAt := time.Date(2025, 4, 15, 18, 0, 0, 0, time.UTC)
+	err = repo.Update(context.Background(), &models.Task{ID: task.ID, Title: "Groceries", Description: "Weekly", DueAt: &dueAt})
+	require.NoError(t, err)
+
+	got, err := repo.GetByID(context.Background(), task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Groceries", got.Title)
+	assert.Equal(t, "Weekly", got.Description)
+	require.NotNil(t, got.DueAt)
+	assert.True(t, dueAt.Equal(got.DueAt.UTC()))
+
+	err = repo.Update(context.Background(), &models.Task{ID: 999999, Title: "Missing"})
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
+func (s *PGRepositorySuite) TestPGTask_CreateItem() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	task := &models.Task{Title: "Shopping"}
+	s.insert(t, trx, task)
+
+	repo := NewTaskRepository(trx)
+	item := &models.TaskItem{TaskID: task.ID, Title: "Buy milk"}
+	err = repo.CreateItem(context.Background(), item)
+
+	require.NoError(t, err)
+	assert.NotZero(t, item.ID)
+	assert.NotZero(t, item.CreatedAt)
+
+	err = repo.CreateItem(context.Background(), &models.TaskItem{TaskID: 999999, Title: "Orphan"})
+	assert.ErrorIs(t, err, ErrTaskNotFound)
+}
+
+func (s *PGRepositorySuite) TestPGTask_UpdateItem() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	task := &models.Task{Title: "Shopping"}
+	s.insert(t, trx, task)
+	item := &models.TaskItem{TaskID: task.ID, Title: "Buy milk"}
+	s.insert(t, trx, item)
+
+	repo := NewTaskRepository(trx)
+	item.Title = "Buy oat milk"
+	item.Completed = true
+	err = repo.UpdateItem(context.Background(), item)
+	require.NoError(t, err)
+
+	items, err := repo.ListItemsByTaskIDs(context.Background(), []int64{task.ID})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "Buy oat milk", items[0].Title)
+	assert.True(t, items[0].Completed)
+
+	err = repo.UpdateItem(context.Background(), &models.TaskItem{ID: item.ID, TaskID: task.ID + 1, Title: "Wrong task"})
+	assert.ErrorIs(t, err, ErrTaskItemNotFound)
+}
+
+func (s *PGRepositorySuite) TestPGTask_DeleteItem() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	task := &models.Task{Title: "Shopping"}
+	s.insert(t, trx, task)
+	item := &models.TaskItem{TaskID: task.ID, Title: "Buy milk"}
+	s.insert(t, trx, item)
+
+	repo := NewTaskRepository(trx)
+	err = repo.DeleteItem(context.Background(), task.ID, item.ID)
+	require.NoError(t, err)
+
+	err = repo.DeleteItem(context.Background(), task.ID, item.ID)
+	assert.ErrorIs(t, err, ErrTaskItemNotFound)
+}
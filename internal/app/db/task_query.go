@@ -0,0 +1,21 @@
+package db
+
+import "time"
+
+// TaskFilter holds the criteria used to search tasks
+// Zero values disable the corresponding criterion
+type TaskFilter struct {
+	TitleContains string     // Case-insensitive substring match on the title
+	HasOpenItems  *bool      // Tasks with (true) or without (false) at least one uncompleted item
+	CreatedAfter  *time.Time // Tasks created at or after this time
+	CreatedBefore *time.Time // Tasks created strictly before this time
+	Limit         int        // Maximum number of tasks to return (0 means no limit)
+	Offset        int        // Number of tasks to skip
+}
+
+// TaskStats holds aggregated counters over tasks and their items
+type TaskStats struct {
+	TaskCount          int `bun:"task_count"`
+	ItemCount          int `bun:"item_count"`
+	CompletedItemCount int `bun:"completed_item_count"`
+}
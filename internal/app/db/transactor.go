@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+const (
+	// maxTxRetries is how many times a transaction aborted by a serialization failure or a deadlock is retried
+	maxTxRetries = 3
+	// txRetryBackoff is the base delay before retrying an aborted transaction, doubled on each attempt
+	txRetryBackoff = 20 * time.Millisecond
+)
+
+// Transactor runs several repository calls atomically
+type Transactor interface {
+	// WithinTx runs fn with a transaction stored in ctx, committed when fn returns nil and rolled back otherwise
+	// Repositories called with the ctx given to fn take part in the transaction
+	// Nested calls open a savepoint; the outermost call is retried on serialization failures and deadlocks,
+	// so fn may run more than once and must not have side effects outside the database
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txContextKey struct{}
+
+// bunTransactor implements Transactor using Bun transactions
+type bunTransactor struct {
+	db bun.IDB
+}
+
+// NewTransactor creates a new instance of Transactor
+func NewTransactor(db bun.IDB) Transactor {
+	return &bunTransactor{db: db}
+}
+
+// WithinTx opens a transaction, or a savepoint when ctx already carries one
+func (t *bunTransactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(txContextKey{}).(bun.Tx); ok {
+		// Retrying is left to the outermost call: a failed statement aborts the whole transaction
+		return runInTx(ctx, tx, fn)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := runInTx(ctx, t.db, fn)
+		if err == nil || !isRetryable(err) || attempt >= maxTxRetries {
+			return err
+		}
+
+		delay := txRetryBackoff << attempt
+		timer := time.NewTimer(delay/2 + rand.N(delay/2))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// runInTx runs fn in a transaction, or a savepoint when db is a transaction, stored in ctx
+func runInTx(ctx context.Context, db bun.IDB, fn func(ctx context.Context) error) error {
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(context.WithValue(ctx, txContextKey{}, tx))
+	})
+}
+
+// conn returns the transaction stored in ctx by a Transactor, or db outside of one
+func conn(ctx context.Context, db bun.IDB) bun.IDB {
+	if tx, ok := ctx.Value(txContextKey{}).(bun.Tx); ok {
+		return tx
+	}
+
+	return db
+}
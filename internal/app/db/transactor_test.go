@@ -0,0 +1,122 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func (s *PGRepositorySuite) TestTransactor_WithinTx() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	transactor := NewTransactor(trx)
+	// Built on the shared database: only the transaction of ctx ties its calls to trx
+	repo := NewTaskRepository(s.pgContainer.DB)
+
+	errAbort := errors.New("abort")
+	outer := &models.Task{Title: "Outer"}
+	inner := &models.Task{Title: "Inner"}
+	err = transactor.WithinTx(context.Background(), func(ctx context.Context) error {
+		if err := repo.Create(ctx, outer); err != nil {
+			return err
+		}
+
+		// A failing nested call only rolls back its savepoint
+		err := transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := repo.Create(ctx, inner); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	txRepo := NewTaskRepository(trx)
+	_, err = txRepo.GetByID(context.Background(), outer.ID)
+	assert.NoError(t, err)
+	_, err = txRepo.GetByID(context.Background(), inner.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Nothing is visible outside of trx until it commits
+	_, err = repo.GetByID(context.Background(), outer.ID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func (s *PGRepositorySuite) TestTransactor_WithinTxRetries() {
+	t := s.T()
+
+	trx, err := s.pgContainer.TxBegin()
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, trx.Rollback())
+	}()
+
+	transactor := NewTransactor(trx)
+
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{
+			name:         "should retry serialization failures",
+			err:          &pgconn.PgError{Code: "40001"},
+			wantAttempts: maxTxRetries + 1,
+		},
+		{
+			name:         "should retry deadlocks",
+			err:          &pgconn.PgError{Code: "40P01"},
+			wantAttempts: maxTxRetries + 1,
+		},
+		{
+			name:         "should not retry other errors",
+			err:          &pgconn.PgError{Code: "23505"},
+			wantAttempts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			attempts := 0
+			err := transactor.WithinTx(context.Background(), func(ctx context.Context) error {
+				attempts++
+				return tt.err
+			})
+
+			assert.ErrorIs(s.T(), err, tt.err)
+			assert.Equal(s.T(), tt.wantAttempts, attempts)
+		})
+	}
+
+	s.Run("should not retry nested calls", func() {
+		attempts := 0
+		err := transactor.WithinTx(context.Background(), func(ctx context.Context) error {
+			return transactor.WithinTx(ctx, func(ctx context.Context) error {
+				attempts++
+				if attempts == 1 {
+					return &pgconn.PgError{Code: "40001"}
+				}
+				return nil
+			})
+		})
+
+		assert.NoError(s.T(), err)
+		// The outer call retried the whole transaction once
+		assert.Equal(s.T(), 2, attempts)
+	})
+}
@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+)
+
+//go:embed graphql_schema.graphql
+var graphQLSchema string
+
+type graphQLHTTPRequest struct {
+	Query         string         `json:"query" form:"query" binding:"required"`
+	OperationName string         `json:"operationName" form:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// GraphQLHandler serves the GraphQL API over HTTP
+// Subscriptions are delivered as server-sent events when the client accepts text/event-stream
+type GraphQLHandler struct {
+	schema      *graphql.Schema
+	taskUsecase usecases.TaskUsecase
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler instance
+func NewGraphQLHandler(taskUsecase usecases.TaskUsecase) *GraphQLHandler {
+	schema := graphql.MustParseSchema(graphQLSchema, &graphQLResolver{taskUsecase: taskUsecase},
+		graphql.UseStringDescriptions(),
+		graphql.MaxDepth(10),
+	)
+
+	return &GraphQLHandler{
+		schema:      schema,
+		taskUsecase: taskUsecase,
+	}
+}
+
+// Serve handles GET and POST /graphql
+func (h *GraphQLHandler) Serve(c *gin.Context) {
+	req, err := h.bindRequest(c)
+	if err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// GET must stay safe: a link or an <img> could otherwise change tasks on behalf of the user
+	if c.Request.Method == http.MethodGet && graphQLOperationType(req.Query, req.OperationName) == "mutation" {
+		c.Header("Allow", http.MethodPost)
+		respondWithError(c, http.StatusMethodNotAllowed, "mutations must be sent with POST")
+		return
+	}
+
+	if strings.Contains(c.GetHeader("Accept"), "text/event-stream") {
+		h.serveEventStream(c, req)
+		return
+	}
+
+	ctx := withGraphQLLoaders(c.Request.Context(), newGraphQLLoaders(h.taskUsecase, true))
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	c.JSON(http.StatusOK, response)
+}
+
+// serveEventStream executes the operation and streams every response as a "next" event
+func (h *GraphQLHandler) serveEventStream(c *gin.Context, req *graphQLHTTPRequest) {
+	ctx := withGraphQLLoaders(c.Request.Context(), newGraphQLLoaders(h.taskUsecase, false))
+
+	responses, err := h.schema.Subscribe(ctx, req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	disableWriteDeadline(c)
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	for response := range responses {
+		data, marshalErr := json.Marshal(response)
+		if marshalErr != nil {
+			return
+		}
+		c.SSEvent("next", string(data))
+		c.Writer.Flush()
+	}
+
+	// The responses channel is also closed when the client disconnects
+	if ctx.Err() == nil {
+		c.SSEvent("complete", "")
+		c.Writer.Flush()
+	}
+}
+
+// bindRequest reads the operation from the query string (GET) or the JSON body (POST)
+func (h *GraphQLHandler) bindRequest(c *gin.Context) (*graphQLHTTPRequest, error) {
+	var req graphQLHTTPRequest
+
+	if c.Request.Method == http.MethodGet {
+		if err := c.ShouldBindQuery(&req); err != nil {
+			return nil, err
+		}
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return nil, err
+			}
+		}
+		return &req, nil
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		return nil, err
+	}
+
+	return &req, nil
+}
+
+// graphQLOperationType returns the type (query, mutation or subscription) of the operation
+// that will be executed, or an empty string when the document does not select one
+// Execution reports malformed documents, so only the top-level definitions are scanned
+func graphQLOperationType(document, operationName string) string {
+	type operation struct{ typ, name string }
+	var (
+		operations []operation
+		definition = true
+		named      = false
+		braces     = 0
+		parens     = 0
+	)
+
+	for i := 0; i < len(document); {
+		ch := document[i]
+		switch {
+		case ch == '#':
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+		case ch == '"':
+			if strings.HasPrefix(document[i:], `"""`) {
+				end := strings.Index(document[i+3:], `"""`)
+				if end < 0 {
+					return ""
+				}
+				i += end + 6
+				continue
+			}
+			for i++; i < len(document) && document[i] != '"'; i++ {
+				if document[i] == '\\' {
+					i++
+				}
+			}
+			i++
+		case ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z':
+			start := i
+			for i < len(document) && (document[i] == '_' || document[i] >= '0' && document[i] <= '9' ||
+				document[i] >= 'a' && document[i] <= 'z' || document[i] >= 'A' && document[i] <= 'Z') {
+				i++
+			}
+			name := document[start:i]
+			switch {
+			case definition:
+				definition = false
+				if name == "query" || name == "mutation" || name == "subscription" {
+					operations = append(operations, operation{typ: name})
+					named = true
+				}
+			case named:
+				operations[len(operations)-1].name = name
+				named = false
+			}
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == ',':
+			i++
+		default:
+			switch ch {
+			case '{':
+				if definition {
+					// A bare selection set is an anonymous query
+					operations = append(operations, operation{typ: "query"})
+					definition = false
+				}
+				braces++
+			case '}':
+				braces--
+				if braces == 0 && parens == 0 {
+					definition = true
+				}
+			case '(':
+				parens++
+			case ')':
+				parens--
+			}
+			named = false
+			i++
+		}
+	}
+
+	for _, op := range operations {
+		if op.name == operationName || operationName == "" && len(operations) == 1 {
+			return op.typ
+		}
+	}
+	return ""
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/graph-gophers/dataloader/v7"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+)
+
+type graphQLLoadersKey struct{}
+
+// graphQLLoaders batches the lookups performed while resolving a single GraphQL operation
+type graphQLLoaders struct {
+	taskItems *dataloader.Loader[int64, []usecases.TaskItemResult]
+}
+
+// newGraphQLLoaders creates loaders scoped to a single operation
+// Caching is disabled for subscriptions so that every event sees fresh data
+func newGraphQLLoaders(taskUsecase usecases.TaskUsecase, cache bool) *graphQLLoaders {
+	options := []dataloader.Option[int64, []usecases.TaskItemResult]{}
+	if !cache {
+		options = append(options, dataloader.WithCache[int64, []usecases.TaskItemResult](&dataloader.NoCache[int64, []usecases.TaskItemResult]{}))
+	}
+
+	return &graphQLLoaders{
+		taskItems: dataloader.NewBatchedLoader(batchTaskItems(taskUsecase), options...),
+	}
+}
+
+// batchTaskItems loads the items of all requested tasks with a single usecase call
+func batchTaskItems(taskUsecase usecases.TaskUsecase) dataloader.BatchFunc[int64, []usecases.TaskItemResult] {
+	return func(ctx context.Context, taskIDs []int64) []*dataloader.Result[[]usecases.TaskItemResult] {
+		results := make([]*dataloader.Result[[]usecases.TaskItemResult], len(taskIDs))
+
+		itemsByTask, err := taskUsecase.ListTaskItems(ctx, taskIDs)
+		for i, taskID := range taskIDs {
+			if err != nil {
+				results[i] = &dataloader.Result[[]usecases.TaskItemResult]{Error: err}
+				continue
+			}
+			results[i] = &dataloader.Result[[]usecases.TaskItemResult]{Data: itemsByTask[taskID]}
+		}
+
+		return results
+	}
+}
+
+func withGraphQLLoaders(ctx context.Context, loaders *graphQLLoaders) context.Context {
+	return context.WithValue(ctx, graphQLLoadersKey{}, loaders)
+}
+
+func graphQLLoadersFromContext(ctx context.Context) *graphQLLoaders {
+	loaders, _ := ctx.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+	return loaders
+}
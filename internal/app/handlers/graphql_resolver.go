@@ -0,0 +1,413 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+)
+
+// graphQLResolver is the root resolver for queries, mutations and subscriptions
+type graphQLResolver struct {
+	taskUsecase usecases.TaskUsecase
+}
+
+// Task resolves Query.task, null when the task does not exist
+func (r *graphQLResolver) Task(ctx context.Context, args struct{ ID graphql.ID }) (*taskResolver, error) {
+	id, err := parseGraphQLID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.taskUsecase.GetTask(ctx, id)
+	if errors.Is(err, db.ErrTaskNotFound) || errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newTaskResolver(result, true), nil
+}
+
+type taskFilterInput struct {
+	TitleContains *string
+	HasOpenItems  *bool
+	CreatedAfter  *graphql.Time
+	CreatedBefore *graphql.Time
+}
+
+type tasksArgs struct {
+	Filter *taskFilterInput
+	First  int32
+	After  *string
+}
+
+// Tasks resolves Query.tasks
+func (r *graphQLResolver) Tasks(ctx context.Context, args tasksArgs) (*taskConnectionResolver, error) {
+	params := usecases.SearchTasksParams{
+		Limit: int(args.First),
+	}
+	if args.After != nil {
+		offset, err := decodeCursor(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		params.Offset = offset
+	}
+	if f := args.Filter; f != nil {
+		if f.TitleContains != nil {
+			params.TitleContains = *f.TitleContains
+		}
+		params.HasOpenItems = f.HasOpenItems
+		if f.CreatedAfter != nil {
+			params.CreatedAfter = &f.CreatedAfter.Time
+		}
+		if f.CreatedBefore != nil {
+			params.CreatedBefore = &f.CreatedBefore.Time
+		}
+	}
+
+	page, err := r.taskUsecase.SearchTasks(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make([]*taskResolver, 0, len(page.Tasks))
+	for i := range page.Tasks {
+		nodes = append(nodes, r.newTaskResolver(&page.Tasks[i], false))
+	}
+
+	return &taskConnectionResolver{
+		nodes:      nodes,
+		totalCount: page.TotalCount,
+		offset:     params.Offset,
+	}, nil
+}
+
+// Stats resolves Query.stats
+func (r *graphQLResolver) Stats(ctx context.Context) (*taskStatsResolver, error) {
+	stats, err := r.taskUsecase.GetTaskStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &taskStatsResolver{stats: stats}, nil
+}
+
+type createTaskItemInput struct {
+	Title     string
+	Completed *bool
+}
+
+type createTaskInput struct {
+	Title       string
+	Description *string
+	Items       *[]createTaskItemInput
+}
+
+// CreateTask resolves Mutation.createTask
+func (r *graphQLResolver) CreateTask(ctx context.Context, args struct{ Input createTaskInput }) (*taskResolver, error) {
+	params := usecases.CreateTaskParams{
+		Title: args.Input.Title,
+	}
+	if args.Input.Description != nil {
+		params.Description = *args.Input.Description
+	}
+	if args.Input.Items != nil {
+		for _, item := range *args.Input.Items {
+			params.Items = append(params.Items, usecases.CreateTaskItemParams{
+				Title:     item.Title,
+				Completed: item.Completed != nil && *item.Completed,
+			})
+		}
+	}
+
+	result, err := r.taskUsecase.CreateTask(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newTaskResolver(result, true), nil
+}
+
+// DeleteTask resolves Mutation.deleteTask
+func (r *graphQLResolver) DeleteTask(ctx context.Context, args struct{ ID graphql.ID }) (bool, error) {
+	id, err := parseGraphQLID(args.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if err = r.taskUsecase.DeleteTask(ctx, id); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// TaskChanged resolves Subscription.taskChanged
+func (r *graphQLResolver) TaskChanged(ctx context.Context) (<-chan *taskEventResolver, error) {
+	events, err := r.taskUsecase.WatchTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make(chan *taskEventResolver)
+	go func() {
+		defer close(resolvers)
+		for event := range events {
+			select {
+			case resolvers <- &taskEventResolver{root: r, event: event}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return resolvers, nil
+}
+
+// newTaskResolver wraps a task result, itemsLoaded tells whether result.Items can be used as is
+func (r *graphQLResolver) newTaskResolver(result *usecases.TaskResult, itemsLoaded bool) *taskResolver {
+	return &taskResolver{
+		root:        r,
+		task:        result,
+		itemsLoaded: itemsLoaded,
+	}
+}
+
+type taskResolver struct {
+	root        *graphQLResolver
+	task        *usecases.TaskResult
+	itemsLoaded bool
+}
+
+func (t *taskResolver) ID() graphql.ID {
+	return formatGraphQLID(t.task.ID)
+}
+
+func (t *taskResolver) Title() string {
+	return t.task.Title
+}
+
+func (t *taskResolver) Description() string {
+	return t.task.Description
+}
+
+func (t *taskResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: t.task.CreatedAt}
+}
+
+func (t *taskResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: t.task.UpdatedAt}
+}
+
+func (t *taskResolver) Items(ctx context.Context, args struct{ Completed *bool }) ([]*taskItemResolver, error) {
+	items, err := t.loadItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*taskItemResolver, 0, len(items))
+	for i := range items {
+		if args.Completed != nil && items[i].Completed != *args.Completed {
+			continue
+		}
+		resolvers = append(resolvers, &taskItemResolver{item: &items[i]})
+	}
+
+	return resolvers, nil
+}
+
+func (t *taskResolver) ItemCount(ctx context.Context) (int32, error) {
+	items, err := t.loadItems(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(len(items)), nil
+}
+
+func (t *taskResolver) CompletedItemCount(ctx context.Context) (int32, error) {
+	items, err := t.loadItems(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int32
+	for _, item := range items {
+		if item.Completed {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// loadItems returns the task items, batching the lookups of sibling tasks through the dataloader
+func (t *taskResolver) loadItems(ctx context.Context) ([]usecases.TaskItemResult, error) {
+	if t.itemsLoaded {
+		return t.task.Items, nil
+	}
+
+	loaders := graphQLLoadersFromContext(ctx)
+	if loaders == nil {
+		itemsByTask, err := t.root.taskUsecase.ListTaskItems(ctx, []int64{t.task.ID})
+		if err != nil {
+			return nil, err
+		}
+		return itemsByTask[t.task.ID], nil
+	}
+
+	return loaders.taskItems.Load(ctx, t.task.ID)()
+}
+
+type taskItemResolver struct {
+	item *usecases.TaskItemResult
+}
+
+func (i *taskItemResolver) ID() graphql.ID {
+	return formatGraphQLID(i.item.ID)
+}
+
+func (i *taskItemResolver) TaskID() graphql.ID {
+	return formatGraphQLID(i.item.TaskID)
+}
+
+func (i *taskItemResolver) Title() string {
+	return i.item.Title
+}
+
+func (i *taskItemResolver) Completed() bool {
+	return i.item.Completed
+}
+
+func (i *taskItemResolver) CreatedAt() graphql.Time {
+	return graphql.Time{Time: i.item.CreatedAt}
+}
+
+func (i *taskItemResolver) UpdatedAt() graphql.Time {
+	return graphql.Time{Time: i.item.UpdatedAt}
+}
+
+type taskConnectionResolver struct {
+	nodes      []*taskResolver
+	totalCount int
+	offset     int
+}
+
+func (c *taskConnectionResolver) Nodes() []*taskResolver {
+	return c.nodes
+}
+
+func (c *taskConnectionResolver) TotalCount() int32 {
+	return int32(c.totalCount)
+}
+
+func (c *taskConnectionResolver) PageInfo() *pageInfoResolver {
+	end := c.offset + len(c.nodes)
+	info := &pageInfoResolver{hasNextPage: end < c.totalCount}
+	if len(c.nodes) > 0 {
+		cursor := encodeCursor(end)
+		info.endCursor = &cursor
+	}
+
+	return info
+}
+
+type pageInfoResolver struct {
+	hasNextPage bool
+	endCursor   *string
+}
+
+func (p *pageInfoResolver) HasNextPage() bool {
+	return p.hasNextPage
+}
+
+func (p *pageInfoResolver) EndCursor() *string {
+	return p.endCursor
+}
+
+type taskStatsResolver struct {
+	stats *usecases.TaskStatsResult
+}
+
+func (s *taskStatsResolver) TaskCount() int32 {
+	return int32(s.stats.TaskCount)
+}
+
+func (s *taskStatsResolver) ItemCount() int32 {
+	return int32(s.stats.ItemCount)
+}
+
+func (s *taskStatsResolver) CompletedItemCount() int32 {
+	return int32(s.stats.CompletedItemCount)
+}
+
+func (s *taskStatsResolver) OpenItemCount() int32 {
+	return int32(s.stats.OpenItemCount)
+}
+
+type taskEventResolver struct {
+	root  *graphQLResolver
+	event usecases.TaskEvent
+}
+
+func (e *taskEventResolver) Type() string {
+	return strings.ToUpper(string(e.event.Type))
+}
+
+func (e *taskEventResolver) TaskID() graphql.ID {
+	return formatGraphQLID(e.event.TaskID)
+}
+
+func (e *taskEventResolver) Task() *taskResolver {
+	if e.event.Task == nil {
+		return nil
+	}
+
+	return e.root.newTaskResolver(e.event.Task, true)
+}
+
+func formatGraphQLID(id int64) graphql.ID {
+	return graphql.ID(strconv.FormatInt(id, 10))
+}
+
+func parseGraphQLID(id graphql.ID) (int64, error) {
+	parsed, err := strconv.ParseInt(string(id), 10, 64)
+	if err != nil {
+		return 0, usecases.ErrInvalidTaskID
+	}
+
+	return parsed, nil
+}
+
+const cursorPrefix = "offset:"
+
+// encodeCursor builds an opaque pagination cursor pointing after the given offset
+func encodeCursor(offset int) string {
+	return base64.URLEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(offset)))
+}
+
+// decodeCursor extracts the offset from an opaque pagination cursor
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	offset, err := strconv.Atoi(strings.TrimPrefix(string(raw), cursorPrefix))
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+
+	return offset, nil
+}
@@ -0,0 +1,335 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+)
+
+func TestGraphQLHandler_Serve(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		query     string
+		variables map[string]any
+	}
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name       string
+		args       args
+		setup      setup
+		wantStatus int
+		wantData   string
+		wantErrors bool
+	}{
+		{
+			name: "should batch item lookups when listing tasks",
+			args: args{
+				query: `{ tasks(first: 2, filter: {titleContains: "o"}) {
+					totalCount
+					pageInfo { hasNextPage endCursor }
+					nodes { id title itemCount items(completed: false) { title } }
+				} }`,
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("SearchTasks", mock.Anything, mock.MatchedBy(func(params usecases.SearchTasksParams) bool {
+					return params.Limit == 2 && params.Offset == 0 && params.TitleContains == "o"
+				})).Return(&usecases.TaskPageResult{
+					Tasks: []usecases.TaskResult{
+						{ID: 1, Title: "Shopping"},
+						{ID: 2, Title: "Work"},
+					},
+					TotalCount: 3,
+				}, nil).Once()
+				mockUsecase.On("ListTaskItems", mock.Anything, mock.MatchedBy(func(taskIDs []int64) bool {
+					return assert.ElementsMatch(t, []int64{1, 2}, taskIDs)
+				})).Return(map[int64][]usecases.TaskItemResult{
+					1: {
+						{ID: 1, TaskID: 1, Title: "Buy milk", Completed: false},
+						{ID: 2, TaskID: 1, Title: "Buy bread", Completed: true},
+					},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantData: `{"tasks":{"totalCount":3,"pageInfo":{"hasNextPage":true,"endCursor":"b2Zmc2V0OjI="},"nodes":[
+				{"id":"1","title":"Shopping","itemCount":2,"items":[{"title":"Buy milk"}]},
+				{"id":"2","title":"Work","itemCount":0,"items":[]}
+			]}}`,
+		},
+		{
+			name: "should return aggregated stats",
+			args: args{
+				query: `{ stats { taskCount openItemCount } }`,
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetTaskStats", mock.Anything).
+					Return(&usecases.TaskStatsResult{TaskCount: 2, ItemCount: 3, CompletedItemCount: 1, OpenItemCount: 2}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantData:   `{"stats":{"taskCount":2,"openItemCount":2}}`,
+		},
+		{
+			name: "should create task through mutation",
+			args: args{
+				query: `mutation($input: CreateTaskInput!) { createTask(input: $input) { id items { title completed } } }`,
+				variables: map[string]any{
+					"input": map[string]any{
+						"title": "Shopping",
+						"items": []map[string]any{{"title": "Buy milk"}},
+					},
+				},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("CreateTask", mock.Anything, mock.MatchedBy(func(params usecases.CreateTaskParams) bool {
+					return params.Title == "Shopping" && len(params.Items) == 1
+				})).Return(&usecases.TaskResult{
+					ID:    1,
+					Title: "Shopping",
+					Items: []usecases.TaskItemResult{{ID: 1, TaskID: 1, Title: "Buy milk"}},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantData:   `{"createTask":{"id":"1","items":[{"title":"Buy milk","completed":false}]}}`,
+		},
+		{
+			name: "should return null for an unknown task",
+			args: args{
+				query: `{ task(id: "999") { id title } }`,
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetTask", mock.Anything, int64(999)).
+					Return(nil, sql.ErrNoRows).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantData:   `{"task":null}`,
+		},
+		{
+			name: "should return errors when usecase fails",
+			args: args{
+				query: `mutation { deleteTask(id: "999") }`,
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("DeleteTask", mock.Anything, int64(999)).
+					Return(usecases.ErrInvalidTaskID).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantErrors: true,
+		},
+		{
+			name: "should return 400 when query is missing",
+			args: args{
+				query: "",
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				graphQLHandler: NewGraphQLHandler(mockUsecase),
+			}
+			router := gin.Default()
+			handler.registerGraphQLRoutes(router)
+
+			// Create request
+			var body bytes.Buffer
+			err := json.NewEncoder(&body).Encode(map[string]any{
+				"query":     tt.args.query,
+				"variables": tt.args.variables,
+			})
+			require.NoError(t, err)
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/graphql", &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response struct {
+				Data   json.RawMessage  `json:"data"`
+				Errors []map[string]any `json:"errors"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			if tt.wantErrors {
+				assert.NotEmpty(t, response.Errors)
+				return
+			}
+			assert.Empty(t, response.Errors)
+			assert.JSONEq(t, tt.wantData, string(response.Data))
+		})
+	}
+}
+
+func TestGraphQLHandler_ServeGet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		query      string
+		setup      func(mockUsecase *mocks.TaskUsecase)
+		wantStatus int
+	}{
+		{
+			name:  "should execute a query",
+			query: `{ stats { taskCount } }`,
+			setup: func(mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetTaskStats", mock.Anything).
+					Return(&usecases.TaskStatsResult{TaskCount: 2}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "should refuse a mutation",
+			query:      `mutation { deleteTask(id: "1") }`,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(mockUsecase)
+			}
+
+			handler := HTTPHandler{
+				graphQLHandler: NewGraphQLHandler(mockUsecase),
+			}
+			router := gin.Default()
+			handler.registerGraphQLRoutes(router)
+
+			target := "/graphql?" + url.Values{"query": {tt.query}}.Encode()
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, target, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusMethodNotAllowed {
+				assert.Equal(t, http.MethodPost, w.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestGraphQLOperationType(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		document      string
+		operationName string
+		want          string
+	}{
+		{name: "should read a shorthand query", document: `{ stats { taskCount } }`, want: "query"},
+		{name: "should read a mutation", document: `mutation { deleteTask(id: "1") }`, want: "mutation"},
+		{
+			name:     "should skip comments, strings and variable defaults",
+			document: "# query\n" + `mutation Create($input: CreateTaskInput! = {title: "{ query }"}) { createTask(input: $input) { id } }`,
+			want:     "mutation",
+		},
+		{
+			name:          "should select the named operation",
+			document:      `query List { tasks { totalCount } } mutation Remove { deleteTask(id: "1") }`,
+			operationName: "Remove",
+			want:          "mutation",
+		},
+		{
+			name:     "should not guess between several operations",
+			document: `query List { tasks { totalCount } } mutation Remove { deleteTask(id: "1") }`,
+			want:     "",
+		},
+		{
+			name:     "should ignore fragments",
+			document: `fragment F on Task { id } mutation { createTask(input: {title: "a"}) { ...F } }`,
+			want:     "mutation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, graphQLOperationType(tt.document, tt.operationName))
+		})
+	}
+}
+
+func TestGraphQLHandler_Subscription(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := mocks.NewTaskUsecase(t)
+	events := make(chan usecases.TaskEvent, 2)
+	events <- usecases.TaskEvent{Type: usecases.TaskEventCreated, TaskID: 1, Task: &usecases.TaskResult{ID: 1, Title: "Shopping"}}
+	events <- usecases.TaskEvent{Type: usecases.TaskEventDeleted, TaskID: 1}
+	close(events)
+	mockUsecase.On("WatchTasks", mock.Anything).Return((<-chan usecases.TaskEvent)(events), nil).Once()
+
+	handler := HTTPHandler{
+		graphQLHandler: NewGraphQLHandler(mockUsecase),
+	}
+	router := gin.Default()
+	handler.registerGraphQLRoutes(router)
+
+	body := strings.NewReader(`{"query": "subscription { taskChanged { type taskId task { title } } }"}`)
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/graphql", body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	raw := w.Body.String()
+	var data []string
+	scanner := bufio.NewScanner(strings.NewReader(raw))
+	for scanner.Scan() {
+		if line, ok := strings.CutPrefix(scanner.Text(), "data:"); ok && line != "" {
+			data = append(data, line)
+		}
+	}
+	require.Len(t, data, 2)
+	assert.JSONEq(t, `{"data":{"taskChanged":{"type":"CREATED","taskId":"1","task":{"title":"Shopping"}}}}`, data[0])
+	assert.JSONEq(t, `{"data":{"taskChanged":{"type":"DELETED","taskId":"1","task":null}}}`, data[1])
+	assert.Contains(t, raw, "event:complete")
+}
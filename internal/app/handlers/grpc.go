@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	todov1 "github.com/clevertechware/todo-bun-app/pkg/api/todo/v1"
+)
+
+type GRPCHandler struct {
+	grpcTaskHandler *GRPCTaskHandler
+	health          *health.Server
+}
+
+func NewGRPCHandler(grpcTaskHandler *GRPCTaskHandler) *GRPCHandler {
+	return &GRPCHandler{
+		grpcTaskHandler: grpcTaskHandler,
+		health:          health.NewServer(),
+	}
+}
+
+func (h *GRPCHandler) RegisterServices(server *grpc.Server) {
+	// Standard health service
+	healthpb.RegisterHealthServer(server, h.health)
+	h.health.SetServingStatus(todov1.TaskService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	// API services
+	todov1.RegisterTaskServiceServer(server, h.grpcTaskHandler)
+
+	// Server reflection for tools like grpcurl
+	reflection.Register(server)
+}
+
+// Shutdown marks all services as not serving so that health checks fail during shutdown
+func (h *GRPCHandler) Shutdown() {
+	h.health.Shutdown()
+}
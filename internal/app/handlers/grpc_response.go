@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/requestid"
+	todov1 "github.com/clevertechware/todo-bun-app/pkg/api/todo/v1"
+)
+
+// grpcInternalErrorMessage replaces the message of unexpected errors, which may expose SQL or driver details
+const grpcInternalErrorMessage = "internal error"
+
+// grpcStatusFromError maps domain errors to gRPC status errors
+// Unexpected errors are logged with the request ID of ctx, which the client receives in the x-request-id header
+func grpcStatusFromError(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, db.ErrTaskNotFound), errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, "task not found")
+	case errors.Is(err, usecases.ErrInvalidTaskID),
+		errors.Is(err, usecases.ErrTaskTitleRequired),
+		errors.Is(err, usecases.ErrTaskItemTitleRequired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, usecases.ErrChangeFeedUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	default:
+		logger.FromContext(ctx).Error().
+			Err(err).
+			Str("requestId", requestid.FromContext(ctx)).
+			Msg("gRPC request failed")
+		return status.Error(codes.Internal, grpcInternalErrorMessage)
+	}
+}
+
+// resultToProto maps usecase result to protobuf message
+func resultToProto(result *usecases.TaskResult) *todov1.Task {
+	items := make([]*todov1.TaskItem, 0, len(result.Items))
+	for _, item := range result.Items {
+		items = append(items, &todov1.TaskItem{
+			Id:        item.ID,
+			TaskId:    item.TaskID,
+			Title:     item.Title,
+			Completed: item.Completed,
+			CreatedAt: timestamppb.New(item.CreatedAt),
+			UpdatedAt: timestamppb.New(item.UpdatedAt),
+		})
+	}
+
+	task := &todov1.Task{
+		Id:          result.ID,
+		Title:       result.Title,
+		Description: result.Description,
+		CreatedAt:   timestamppb.New(result.CreatedAt),
+		UpdatedAt:   timestamppb.New(result.UpdatedAt),
+		Items:       items,
+	}
+	if result.DueAt != nil {
+		task.DueAt = timestamppb.New(*result.DueAt)
+	}
+
+	return task
+}
+
+// eventToProto maps a task change event to protobuf message
+func eventToProto(event usecases.TaskEvent) *todov1.WatchTasksResponse {
+	response := &todov1.WatchTasksResponse{
+		TaskId: event.TaskID,
+	}
+
+	switch event.Type {
+	case usecases.TaskEventCreated:
+		response.Type = todov1.TaskEventType_TASK_EVENT_TYPE_CREATED
+	case usecases.TaskEventUpdated:
+		response.Type = todov1.TaskEventType_TASK_EVENT_TYPE_UPDATED
+	case usecases.TaskEventDeleted:
+		response.Type = todov1.TaskEventType_TASK_EVENT_TYPE_DELETED
+	}
+
+	if event.Task != nil {
+		response.Task = resultToProto(event.Task)
+	}
+
+	return response
+}
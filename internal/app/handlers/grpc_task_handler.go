@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	todov1 "github.com/clevertechware/todo-bun-app/pkg/api/todo/v1"
+)
+
+// GRPCTaskHandler handles gRPC requests for tasks
+type GRPCTaskHandler struct {
+	todov1.UnimplementedTaskServiceServer
+
+	taskUsecase usecases.TaskUsecase
+}
+
+// NewGRPCTaskHandler creates a new GRPCTaskHandler instance
+func NewGRPCTaskHandler(taskUsecase usecases.TaskUsecase) *GRPCTaskHandler {
+	return &GRPCTaskHandler{
+		taskUsecase: taskUsecase,
+	}
+}
+
+// CreateTask handles TaskService.CreateTask
+func (h *GRPCTaskHandler) CreateTask(ctx context.Context, req *todov1.CreateTaskRequest) (*todov1.CreateTaskResponse, error) {
+	// Map gRPC request to usecase params
+	items := make([]usecases.CreateTaskItemParams, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		items = append(items, usecases.CreateTaskItemParams{
+			Title:     item.GetTitle(),
+			Completed: item.GetCompleted(),
+		})
+	}
+
+	// Call usecase
+	result, err := h.taskUsecase.CreateTask(ctx, usecases.CreateTaskParams{
+		Title:       req.GetTitle(),
+		Description: req.GetDescription(),
+		Items:       items,
+	})
+	if err != nil {
+		return nil, grpcStatusFromError(ctx, err)
+	}
+
+	return &todov1.CreateTaskResponse{Task: resultToProto(result)}, nil
+}
+
+// GetTask handles TaskService.GetTask
+func (h *GRPCTaskHandler) GetTask(ctx context.Context, req *todov1.GetTaskRequest) (*todov1.GetTaskResponse, error) {
+	result, err := h.taskUsecase.GetTask(ctx, req.GetId())
+	if err != nil {
+		return nil, grpcStatusFromError(ctx, err)
+	}
+
+	return &todov1.GetTaskResponse{Task: resultToProto(result)}, nil
+}
+
+// ListTasks handles TaskService.ListTasks, sending one message per task as soon as it is read from the cursor
+func (h *GRPCTaskHandler) ListTasks(_ *todov1.ListTasksRequest, stream grpc.ServerStreamingServer[todov1.ListTasksResponse]) error {
+	var sendErr error
+	err := h.taskUsecase.StreamTasks(stream.Context(), func(task *usecases.TaskResult) error {
+		sendErr = stream.Send(&todov1.ListTasksResponse{Task: resultToProto(task)})
+		return sendErr
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return grpcStatusFromError(stream.Context(), err)
+	}
+
+	return nil
+}
+
+// DeleteTask handles TaskService.DeleteTask
+func (h *GRPCTaskHandler) DeleteTask(ctx context.Context, req *todov1.DeleteTaskRequest) (*todov1.DeleteTaskResponse, error) {
+	if err := h.taskUsecase.DeleteTask(ctx, req.GetId()); err != nil {
+		return nil, grpcStatusFromError(ctx, err)
+	}
+
+	return &todov1.DeleteTaskResponse{}, nil
+}
+
+// WatchTasks handles TaskService.WatchTasks, streaming task changes until the client cancels
+func (h *GRPCTaskHandler) WatchTasks(_ *todov1.WatchTasksRequest, stream grpc.ServerStreamingServer[todov1.WatchTasksResponse]) error {
+	ctx := stream.Context()
+
+	events, err := h.taskUsecase.WatchTasks(ctx)
+	if err != nil {
+		return grpcStatusFromError(ctx, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err = stream.Send(eventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/requestid"
+	todov1 "github.com/clevertechware/todo-bun-app/pkg/api/todo/v1"
+)
+
+// newGRPCTestClient starts an in-memory gRPC server backed by the given usecase
+func newGRPCTestClient(t *testing.T, mockUsecase *mocks.TaskUsecase) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(requestid.GRPCUnaryInterceptor()),
+		grpc.ChainStreamInterceptor(requestid.GRPCStreamInterceptor()),
+	)
+	handler := NewGRPCHandler(NewGRPCTaskHandler(mockUsecase))
+	handler.RegisterServices(server)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	return conn
+}
+
+func TestGRPCTaskHandler_CreateTask(t *testing.T) {
+	t.Parallel()
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name     string
+		req      *todov1.CreateTaskRequest
+		setup    setup
+		wantCode codes.Code
+	}{
+		{
+			name: "should return OK when task is created successfully",
+			req: &todov1.CreateTaskRequest{
+				Title:       "Shopping",
+				Description: "Weekly shopping",
+				Items: []*todov1.CreateTaskItem{
+					{Title: "Buy milk"},
+					{Title: "Buy bread"},
+				},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("CreateTask", mock.Anything, mock.MatchedBy(func(params usecases.CreateTaskParams) bool {
+					return params.Title == "Shopping" && len(params.Items) == 2
+				})).Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "should return InvalidArgument when title is missing",
+			req:  &todov1.CreateTaskRequest{},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("CreateTask", mock.Anything, mock.Anything).
+					Return(nil, usecases.ErrTaskTitleRequired).Once()
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "should return Internal when usecase returns error",
+			req:  &todov1.CreateTaskRequest{Title: "Shopping"},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("CreateTask", mock.Anything, mock.Anything).
+					Return(nil, errors.New("internal error")).Once()
+			},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+			client := todov1.NewTaskServiceClient(newGRPCTestClient(t, mockUsecase))
+
+			resp, err := client.CreateTask(context.Background(), tt.req)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, int64(1), resp.GetTask().GetId())
+			}
+		})
+	}
+}
+
+func TestResultToProto(t *testing.T) {
+	t.Parallel()
+
+	dueAt := time.Date(2025, 3, 1, 18, 0, 0, 0, time.UTC)
+
+	assert.True(t, resultToProto(&usecases.TaskResult{ID: 1, DueAt: &dueAt}).GetDueAt().AsTime().Equal(dueAt))
+	assert.Nil(t, resultToProto(&usecases.TaskResult{ID: 1}).GetDueAt())
+}
+
+func TestGRPCTaskHandler_InternalError(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := mocks.NewTaskUsecase(t)
+	mockUsecase.On("GetTask", mock.Anything, int64(1)).
+		Return(nil, errors.New(`pq: relation "tasks" does not exist`)).Once()
+	client := todov1.NewTaskServiceClient(newGRPCTestClient(t, mockUsecase))
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), requestid.MetadataKey, "req-42")
+	_, err := client.GetTask(ctx, &todov1.GetTaskRequest{Id: 1}, grpc.Header(&header))
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, grpcInternalErrorMessage, status.Convert(err).Message())
+	assert.Equal(t, []string{"req-42"}, header.Get(requestid.MetadataKey))
+}
+
+func TestGRPCTaskHandler_GetTask(t *testing.T) {
+	t.Parallel()
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name     string
+		id       int64
+		setup    setup
+		wantCode codes.Code
+	}{
+		{
+			name: "should return OK when task is found",
+			id:   1,
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetTask", mock.Anything, int64(1)).
+					Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "should return InvalidArgument when task ID is invalid",
+			id:   0,
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetTask", mock.Anything, int64(0)).
+					Return(nil, usecases.ErrInvalidTaskID).Once()
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "should return NotFound when task is not found",
+			id:   999,
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetTask", mock.Anything, int64(999)).
+					Return(nil, sql.ErrNoRows).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+			client := todov1.NewTaskServiceClient(newGRPCTestClient(t, mockUsecase))
+
+			_, err := client.GetTask(context.Background(), &todov1.GetTaskRequest{Id: tt.id})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCTaskHandler_ListTasks(t *testing.T) {
+	t.Parallel()
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name     string
+		setup    setup
+		wantIDs  []int64
+		wantCode codes.Code
+	}{
+		{
+			name: "should stream one message per task",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("StreamTasks", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, fn func(*usecases.TaskResult) error) error {
+						if err := fn(&usecases.TaskResult{ID: 1, Title: "Shopping"}); err != nil {
+							return err
+						}
+						return fn(&usecases.TaskResult{ID: 2, Title: "Work"})
+					}).Once()
+			},
+			wantIDs:  []int64{1, 2},
+			wantCode: codes.OK,
+		},
+		{
+			name: "should return Internal when usecase returns error",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("StreamTasks", mock.Anything, mock.Anything).
+					Return(errors.New("internal error")).Once()
+			},
+			wantCode: codes.Internal,
+		},
+		{
+			name: "should end the stream with the error once tasks were sent",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("StreamTasks", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, fn func(*usecases.TaskResult) error) error {
+						if err := fn(&usecases.TaskResult{ID: 1, Title: "Shopping"}); err != nil {
+							return err
+						}
+						return errors.New("connection lost")
+					}).Once()
+			},
+			wantIDs:  []int64{1},
+			wantCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+			client := todov1.NewTaskServiceClient(newGRPCTestClient(t, mockUsecase))
+
+			stream, err := client.ListTasks(context.Background(), &todov1.ListTasksRequest{})
+			require.NoError(t, err)
+
+			var gotIDs []int64
+			for {
+				resp, recvErr := stream.Recv()
+				if recvErr != nil {
+					if !errors.Is(recvErr, io.EOF) {
+						err = recvErr
+					}
+					break
+				}
+				gotIDs = append(gotIDs, resp.GetTask().GetId())
+			}
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantIDs, gotIDs)
+		})
+	}
+}
+
+func TestGRPCTaskHandler_DeleteTask(t *testing.T) {
+	t.Parallel()
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name     string
+		id       int64
+		setup    setup
+		wantCode codes.Code
+	}{
+		{
+			name: "should return OK when task is deleted successfully",
+			id:   1,
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("DeleteTask", mock.Anything, int64(1)).Return(nil).Once()
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name: "should return NotFound when task is not found",
+			id:   999,
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("DeleteTask", mock.Anything, int64(999)).Return(db.ErrTaskNotFound).Once()
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+			client := todov1.NewTaskServiceClient(newGRPCTestClient(t, mockUsecase))
+
+			_, err := client.DeleteTask(context.Background(), &todov1.DeleteTaskRequest{Id: tt.id})
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
+
+func TestGRPCTaskHandler_WatchTasks(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := mocks.NewTaskUsecase(t)
+	events := make(chan usecases.TaskEvent, 2)
+	events <- usecases.TaskEvent{Type: usecases.TaskEventCreated, TaskID: 1, Task: &usecases.TaskResult{ID: 1}}
+	events <- usecases.TaskEvent{Type: usecases.TaskEventDeleted, TaskID: 1}
+	close(events)
+	mockUsecase.On("WatchTasks", mock.Anything).Return((<-chan usecases.TaskEvent)(events), nil).Once()
+
+	client := todov1.NewTaskServiceClient(newGRPCTestClient(t, mockUsecase))
+
+	stream, err := client.WatchTasks(context.Background(), &todov1.WatchTasksRequest{})
+	require.NoError(t, err)
+
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, todov1.TaskEventType_TASK_EVENT_TYPE_CREATED, first.GetType())
+	assert.Equal(t, int64(1), first.GetTask().GetId())
+
+	second, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, todov1.TaskEventType_TASK_EVENT_TYPE_DELETED, second.GetType())
+	assert.Nil(t, second.GetTask())
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestGRPCHandler_Health(t *testing.T) {
+	t.Parallel()
+
+	conn := newGRPCTestClient(t, mocks.NewTaskUsecase(t))
+	client := healthpb.NewHealthClient(conn)
+
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{
+		Service: todov1.TaskService_ServiceDesc.ServiceName,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+}
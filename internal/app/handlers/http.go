@@ -1,24 +1,80 @@
 package handlers
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
 
 type HTTPHandler struct {
-	httpTaskHandler *HTTPTaskHandler
+	httpTaskHandler        *HTTPTaskHandler
+	httpIdempotencyHandler *HTTPIdempotencyHandler
+	httpCalendarHandler    *HTTPCalendarHandler
+	httpCalDAVHandler      *HTTPCalDAVHandler
+	graphQLHandler         *GraphQLHandler
+	openAPIHandler         *OpenAPIHandler
+	httpHealthHandler      *HTTPHealthHandler
 }
 
-func NewHTTPHandler(httpTaskHandler *HTTPTaskHandler) *HTTPHandler {
-	return &HTTPHandler{httpTaskHandler: httpTaskHandler}
+func NewHTTPHandler(
+	httpTaskHandler *HTTPTaskHandler,
+	httpIdempotencyHandler *HTTPIdempotencyHandler,
+	httpCalendarHandler *HTTPCalendarHandler,
+	httpCalDAVHandler *HTTPCalDAVHandler,
+	graphQLHandler *GraphQLHandler,
+	openAPIHandler *OpenAPIHandler,
+	httpHealthHandler *HTTPHealthHandler,
+) *HTTPHandler {
+	return &HTTPHandler{
+		httpTaskHandler:        httpTaskHandler,
+		httpIdempotencyHandler: httpIdempotencyHandler,
+		httpCalendarHandler:    httpCalendarHandler,
+		httpCalDAVHandler:      httpCalDAVHandler,
+		graphQLHandler:         graphQLHandler,
+		openAPIHandler:         openAPIHandler,
+		httpHealthHandler:      httpHealthHandler,
+	}
 }
 
 func (h *HTTPHandler) RegisterRoutes(router gin.IRouter) {
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ok"})
-	})
+	// Liveness, readiness and health endpoints
+	h.registerHealthRoutes(router)
+
+	// API documentation
+	h.registerDocsRoutes(router)
 
-	// API routes
+	// API routes, POST requests being replayable with an Idempotency-Key header
 	api := router.Group("/api")
+	if h.httpIdempotencyHandler != nil {
+		api.Use(h.httpIdempotencyHandler.Middleware())
+	}
 	h.registerTaskRoutes(api)
+	h.registerBatchRoutes(api)
+	h.registerSyncRoutes(api)
+	h.registerTransferRoutes(api)
+	h.registerCalendarRoutes(router, api)
+
+	// CalDAV server for calendar and reminder apps
+	h.registerCalDAVRoutes(router)
+
+	// GraphQL endpoint
+	h.registerGraphQLRoutes(router)
+}
+
+// Drain makes readiness fail ahead of a shutdown, requests still being served
+func (h *HTTPHandler) Drain() {
+	if h.httpHealthHandler != nil {
+		h.httpHealthHandler.Drain()
+	}
+}
+
+// registerHealthRoutes registers the probes, /health being kept as an alias of /readyz for existing checks
+func (h *HTTPHandler) registerHealthRoutes(router gin.IRouter) {
+	router.GET("/livez", h.httpHealthHandler.Live)
+	router.GET("/readyz", h.httpHealthHandler.Ready)
+	router.GET("/healthz", h.httpHealthHandler.Health)
+	router.GET("/health", h.httpHealthHandler.Ready)
 }
 
 func (h *HTTPHandler) registerTaskRoutes(api gin.IRouter) {
@@ -27,6 +83,77 @@ func (h *HTTPHandler) registerTaskRoutes(api gin.IRouter) {
 		tasks.POST("", h.httpTaskHandler.CreateTask)
 		tasks.GET("", h.httpTaskHandler.ListTasks)
 		tasks.GET("/:id", h.httpTaskHandler.GetTask)
+		tasks.PATCH("/:id", h.httpTaskHandler.UpdateTask)
 		tasks.DELETE("/:id", h.httpTaskHandler.DeleteTask)
+		tasks.POST("/:id/items", h.httpTaskHandler.AddTaskItem)
+		tasks.PATCH("/:id/items/:itemId", h.httpTaskHandler.UpdateTaskItem)
+		tasks.DELETE("/:id/items/:itemId", h.httpTaskHandler.DeleteTaskItem)
+	}
+}
+
+func (h *HTTPHandler) registerBatchRoutes(api gin.IRouter) {
+	api.POST("/batch", h.httpTaskHandler.ExecuteBatch)
+}
+
+func (h *HTTPHandler) registerSyncRoutes(api gin.IRouter) {
+	api.GET("/sync", h.httpTaskHandler.GetChanges)
+	api.POST("/sync", h.httpTaskHandler.ApplyChanges)
+}
+
+func (h *HTTPHandler) registerTransferRoutes(api gin.IRouter) {
+	api.GET("/export", h.httpTaskHandler.ExportTasks)
+	api.POST("/import", h.httpTaskHandler.ImportTasks)
+}
+
+// registerCalendarRoutes registers the feed management routes under api, and the feeds themselves
+// outside of it as calendar apps only know their URL
+func (h *HTTPHandler) registerCalendarRoutes(router gin.IRouter, api gin.IRouter) {
+	feeds := api.Group("/calendar/feeds")
+	{
+		feeds.POST("", h.httpCalendarHandler.CreateFeed)
+		feeds.GET("", h.httpCalendarHandler.ListFeeds)
+		feeds.DELETE("/:id", h.httpCalendarHandler.DeleteFeed)
 	}
+
+	router.GET("/ical/:token.ics", h.httpCalendarHandler.Feed)
+}
+
+// registerCalDAVRoutes registers the CalDAV server under /dav/, and the well-known URL clients discover it with
+func (h *HTTPHandler) registerCalDAVRoutes(router gin.IRouter) {
+	for _, method := range caldavMethods {
+		router.Handle(method, caldavPrefix+"/*path", h.httpCalDAVHandler.Serve)
+	}
+	router.Handle(http.MethodGet, "/.well-known/caldav", h.httpCalDAVHandler.Serve)
+	router.Handle("PROPFIND", "/.well-known/caldav", h.httpCalDAVHandler.Serve)
+}
+
+func (h *HTTPHandler) registerGraphQLRoutes(router gin.IRouter) {
+	router.GET("/graphql", h.graphQLHandler.Serve)
+	router.POST("/graphql", h.graphQLHandler.Serve)
+}
+
+func (h *HTTPHandler) registerDocsRoutes(router gin.IRouter) {
+	router.GET("/openapi.json", h.openAPIHandler.Spec)
+	router.GET("/swagger/*filepath", h.openAPIHandler.SwaggerUI)
+}
+
+// OpenAPIValidationMiddleware validates requests and responses against the OpenAPI spec
+func (h *HTTPHandler) OpenAPIValidationMiddleware() gin.HandlerFunc {
+	return h.openAPIHandler.ValidationMiddleware()
+}
+
+// streamWriteTimeout bounds each write of a streamed response backed by a database cursor,
+// so that a client which stops reading releases the cursor and its connection
+const streamWriteTimeout = 30 * time.Second
+
+// extendWriteDeadline gives the next write of a streamed response streamWriteTimeout to complete
+func extendWriteDeadline(c *gin.Context) {
+	// Writers without deadlines, such as test recorders, have no timeout to extend
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+}
+
+// disableWriteDeadline lifts the write timeout of the server for a response streamed as long as the client listens
+func disableWriteDeadline(c *gin.Context) {
+	// Writers without deadlines, such as test recorders, have no timeout to lift
+	_ = http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
 }
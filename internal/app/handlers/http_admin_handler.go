@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/config"
+)
+
+// HTTPAdminHandler handles the HTTP requests of operators, served on the admin listener only
+type HTTPAdminHandler struct {
+	adminUsecase     usecases.AdminUsecase
+	slowQueryUsecase usecases.SlowQueryUsecase
+	currentConfig    func() *config.Config
+}
+
+// NewHTTPAdminHandler creates a new HTTPAdminHandler instance dumping the configuration returned by currentConfig,
+// its secrets redacted
+func NewHTTPAdminHandler(
+	adminUsecase usecases.AdminUsecase, slowQueryUsecase usecases.SlowQueryUsecase, currentConfig func() *config.Config,
+) *HTTPAdminHandler {
+	return &HTTPAdminHandler{
+		adminUsecase:     adminUsecase,
+		slowQueryUsecase: slowQueryUsecase,
+		currentConfig:    currentConfig,
+	}
+}
+
+// RegisterRoutes registers the admin routes under /admin, and the profiles under /debug/pprof where the pprof
+// tool expects them
+func (h *HTTPAdminHandler) RegisterRoutes(router gin.IRouter) {
+	admin := router.Group("/admin")
+	{
+		admin.GET("/loglevel", h.GetLogLevel)
+		admin.PUT("/loglevel", h.SetLogLevel)
+		admin.GET("/config", h.GetConfig)
+		admin.GET("/pool", h.GetPoolStats)
+		admin.GET("/slow-queries", h.ListSlowQueries)
+	}
+
+	debug := router.Group("/debug/pprof")
+	{
+		debug.GET("/", gin.WrapF(pprof.Index))
+		debug.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+		debug.GET("/profile", gin.WrapF(pprof.Profile))
+		debug.GET("/symbol", gin.WrapF(pprof.Symbol))
+		debug.POST("/symbol", gin.WrapF(pprof.Symbol))
+		debug.GET("/trace", gin.WrapF(pprof.Trace))
+		// heap, goroutine, allocs and the other runtime profiles
+		debug.GET("/:profile", gin.WrapF(pprof.Index))
+	}
+}
+
+// GetLogLevel handles GET /admin/loglevel
+func (h *HTTPAdminHandler) GetLogLevel(c *gin.Context) {
+	c.JSON(http.StatusOK, logLevelHTTPResponse{Level: h.adminUsecase.GetLogLevel()})
+}
+
+// SetLogLevel handles PUT /admin/loglevel
+// The level applies at once to every logger, until the next restart
+func (h *HTTPAdminHandler) SetLogLevel(c *gin.Context) {
+	var req setLogLevelHTTPRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// Call usecase
+	if err := h.adminUsecase.SetLogLevel(req.Level); err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, logLevelHTTPResponse{Level: h.adminUsecase.GetLogLevel()})
+}
+
+// GetConfig handles GET /admin/config
+// The effective configuration is dumped as YAML, the format of the configuration file, its secrets redacted
+func (h *HTTPAdminHandler) GetConfig(c *gin.Context) {
+	c.YAML(http.StatusOK, h.currentConfig().Redacted())
+}
+
+// GetPoolStats handles GET /admin/pool
+func (h *HTTPAdminHandler) GetPoolStats(c *gin.Context) {
+	stats := h.adminUsecase.GetPoolStats()
+
+	c.JSON(http.StatusOK, poolStatsHTTPResponse{
+		AcquiredConns:           stats.AcquiredConns,
+		IdleConns:               stats.IdleConns,
+		ConstructingConns:       stats.ConstructingConns,
+		TotalConns:              stats.TotalConns,
+		MaxConns:                stats.MaxConns,
+		AcquireCount:            stats.AcquireCount,
+		AcquireDurationMS:       float64(stats.AcquireDuration.Microseconds()) / 1000,
+		EmptyAcquireCount:       stats.EmptyAcquireCount,
+		EmptyAcquireWaitTimeMS:  float64(stats.EmptyAcquireWaitTime.Microseconds()) / 1000,
+		CanceledAcquireCount:    stats.CanceledAcquireCount,
+		NewConnsCount:           stats.NewConnsCount,
+		MaxLifetimeDestroyCount: stats.MaxLifetimeDestroyCount,
+		MaxIdleDestroyCount:     stats.MaxIdleDestroyCount,
+	})
+}
+
+// ListSlowQueries handles GET /admin/slow-queries
+// The plans captured for slow statements are listed newest first
+func (h *HTTPAdminHandler) ListSlowQueries(c *gin.Context) {
+	var query listSlowQueriesHTTPQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// Call usecase
+	result, err := h.slowQueryUsecase.ListSlowQueryPlans(c.Request.Context(), query.Limit)
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	// Map usecase result to HTTP response
+	response := slowQueryPlanListHTTPResponse{
+		Plans: make([]slowQueryPlanHTTPResponse, 0, len(result.Plans)),
+	}
+	for _, plan := range result.Plans {
+		response.Plans = append(response.Plans, slowQueryPlanHTTPResponse{
+			ID:         plan.ID,
+			Query:      plan.Query,
+			Operation:  plan.Operation,
+			DurationMS: float64(plan.Duration.Microseconds()) / 1000,
+			Plan:       plan.Plan,
+			CapturedAt: plan.CapturedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/config"
+)
+
+func TestHTTPAdminHandler(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.NewDefaultConfig()
+
+	type setup func(t *testing.T, mockUsecase *mocks.AdminUsecase)
+
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		setup        setup
+		wantStatus   int
+		wantResponse func(t *testing.T, body string)
+	}{
+		{
+			name:   "should return the log level",
+			method: http.MethodGet,
+			url:    "/admin/loglevel",
+			setup: func(t *testing.T, mockUsecase *mocks.AdminUsecase) {
+				mockUsecase.On("GetLogLevel").Return("info").Once()
+			},
+			wantStatus: http.StatusOK,
+			wantResponse: func(t *testing.T, body string) {
+				assert.JSONEq(t, `{"level":"info"}`, body)
+			},
+		},
+		{
+			name:   "should change the log level",
+			method: http.MethodPut,
+			url:    "/admin/loglevel",
+			body:   `{"level":"debug"}`,
+			setup: func(t *testing.T, mockUsecase *mocks.AdminUsecase) {
+				mockUsecase.On("SetLogLevel", "debug").Return(nil).Once()
+				mockUsecase.On("GetLogLevel").Return("debug").Once()
+			},
+			wantStatus: http.StatusOK,
+			wantResponse: func(t *testing.T, body string) {
+				assert.JSONEq(t, `{"level":"debug"}`, body)
+			},
+		},
+		{
+			name:   "should return 400 when the log level is unknown",
+			method: http.MethodPut,
+			url:    "/admin/loglevel",
+			body:   `{"level":"verbose"}`,
+			setup: func(t *testing.T, mockUsecase *mocks.AdminUsecase) {
+				mockUsecase.On("SetLogLevel", "verbose").Return(usecases.ErrInvalidLogLevel).Once()
+			},
+			wantStatus: http.StatusBadRequest,
+			wantResponse: func(t *testing.T, body string) {
+				assert.JSONEq(t, `{"error":"invalid log level"}`, body)
+			},
+		},
+		{
+			name:       "should dump the configuration without its secrets",
+			method:     http.MethodGet,
+			url:        "/admin/config",
+			wantStatus: http.StatusOK,
+			wantResponse: func(t *testing.T, body string) {
+				assert.Contains(t, body, "host: localhost")
+				assert.Contains(t, body, "password: <redacted>")
+				assert.NotContains(t, body, "password: postgres")
+			},
+		},
+		{
+			name:   "should return the pool stats",
+			method: http.MethodGet,
+			url:    "/admin/pool",
+			setup: func(t *testing.T, mockUsecase *mocks.AdminUsecase) {
+				mockUsecase.On("GetPoolStats").Return(&usecases.PoolStatsResult{
+					AcquiredConns:   2,
+					IdleConns:       1,
+					TotalConns:      3,
+					MaxConns:        5,
+					AcquireCount:    10,
+					AcquireDuration: 2500 * time.Microsecond,
+				}).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantResponse: func(t *testing.T, body string) {
+				assert.JSONEq(t, `{
+					"acquired_conns":2,"idle_conns":1,"constructing_conns":0,"total_conns":3,"max_conns":5,
+					"acquire_count":10,"acquire_duration_ms":2.5,"empty_acquire_count":0,"empty_acquire_wait_time_ms":0,
+					"canceled_acquire_count":0,"new_conns_count":0,"max_lifetime_destroy_count":0,"max_idle_destroy_count":0
+				}`, body)
+			},
+		},
+		{
+			name:       "should serve the pprof profiles",
+			method:     http.MethodGet,
+			url:        "/debug/pprof/goroutine?debug=1",
+			wantStatus: http.StatusOK,
+			wantResponse: func(t *testing.T, body string) {
+				assert.Contains(t, body, "goroutine profile")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewAdminUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			router := gin.New()
+			NewHTTPAdminHandler(mockUsecase, nil, func() *config.Config { return cfg }).RegisterRoutes(router)
+
+			// Execute request
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, tt.url, strings.NewReader(tt.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			tt.wantResponse(t, w.Body.String())
+		})
+	}
+}
+
+func TestHTTPAdminHandler_ListSlowQueries(t *testing.T) {
+	t.Parallel()
+
+	capturedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	type setup func(t *testing.T, mockUsecase *mocks.SlowQueryUsecase)
+
+	tests := []struct {
+		name         string
+		url          string
+		setup        setup
+		wantStatus   int
+		wantResponse string
+	}{
+		{
+			name: "should return 200 with the captured plans",
+			url:  "/admin/slow-queries?limit=10",
+			setup: func(t *testing.T, mockUsecase *mocks.SlowQueryUsecase) {
+				mockUsecase.On("ListSlowQueryPlans", mock.Anything, 10).
+					Return(&usecases.SlowQueryPlanListResult{Plans: []usecases.SlowQueryPlanResult{{
+						ID:         1,
+						Query:      `SELECT * FROM "task_items" WHERE task_id = ?`,
+						Operation:  "SELECT",
+						Duration:   1500 * time.Microsecond,
+						Plan:       "Seq Scan on task_items",
+						CapturedAt: capturedAt,
+					}}}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantResponse: `{"plans":[{
+				"id":1,
+				"query":"SELECT * FROM \"task_items\" WHERE task_id = ?",
+				"operation":"SELECT",
+				"duration_ms":1.5,
+				"plan":"Seq Scan on task_items",
+				"captured_at":"2025-01-02T03:04:05Z"
+			}]}`,
+		},
+		{
+			name: "should return 200 with the default limit when none is provided",
+			url:  "/admin/slow-queries",
+			setup: func(t *testing.T, mockUsecase *mocks.SlowQueryUsecase) {
+				mockUsecase.On("ListSlowQueryPlans", mock.Anything, 0).
+					Return(&usecases.SlowQueryPlanListResult{}, nil).Once()
+			},
+			wantStatus:   http.StatusOK,
+			wantResponse: `{"plans":[]}`,
+		},
+		{
+			name:         "should return 400 when the limit is too large",
+			url:          "/admin/slow-queries?limit=1000",
+			wantStatus:   http.StatusBadRequest,
+			wantResponse: `{"limit":"too long"}`,
+		},
+		{
+			name: "should return 500 when usecase returns error",
+			url:  "/admin/slow-queries",
+			setup: func(t *testing.T, mockUsecase *mocks.SlowQueryUsecase) {
+				mockUsecase.On("ListSlowQueryPlans", mock.Anything, 0).
+					Return(nil, errors.New("connection refused")).Once()
+			},
+			wantStatus:   http.StatusInternalServerError,
+			wantResponse: `{"error":"internal server error"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewSlowQueryUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			router := gin.New()
+			NewHTTPAdminHandler(nil, mockUsecase, nil).RegisterRoutes(router)
+
+			// Execute request
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.JSONEq(t, tt.wantResponse, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+)
+
+// ExecuteBatch handles POST /api/batch
+func (h *HTTPTaskHandler) ExecuteBatch(c *gin.Context) {
+	var req batchHTTPRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// Call usecase
+	result, err := h.taskUsecase.ExecuteBatch(c.Request.Context(), h.batchRequestToParams(req))
+	if err != nil {
+		respondWithBatchError(c, err)
+		return
+	}
+
+	// Map usecase result to HTTP response
+	response := batchHTTPResponse{
+		Results: make([]batchOperationHTTPResponse, 0, len(result.Results)),
+	}
+	for _, opResult := range result.Results {
+		opResponse := batchOperationHTTPResponse{
+			Index:  opResult.Index,
+			Op:     string(opResult.Type),
+			Ref:    opResult.Ref,
+			TaskID: opResult.TaskID,
+		}
+		if opResult.Task != nil {
+			opResponse.Task = h.resultToResponse(opResult.Task)
+		}
+		if opResult.Item != nil {
+			opResponse.Item = h.itemResultToResponse(opResult.Item)
+		}
+		response.Results = append(response.Results, opResponse)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// batchRequestToParams maps HTTP batch request to usecase params
+func (h *HTTPTaskHandler) batchRequestToParams(req batchHTTPRequest) usecases.BatchParams {
+	operations := make([]usecases.BatchOperationParams, 0, len(req.Operations))
+	for _, op := range req.Operations {
+		params := usecases.BatchOperationParams{
+			Type:    usecases.BatchOperationType(op.Op),
+			Ref:     op.Ref,
+			TaskID:  op.TaskID,
+			TaskRef: op.TaskRef,
+			ItemID:  op.ItemID,
+			Update: usecases.UpdateTaskParams{
+				Title:       op.Title,
+				Description: op.Description,
+			},
+			Completed: op.Completed,
+		}
+		if op.Task != nil {
+			params.Task = h.requestToParams(*op.Task)
+		}
+		operations = append(operations, params)
+	}
+
+	return usecases.BatchParams{Operations: operations}
+}
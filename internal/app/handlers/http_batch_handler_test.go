@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+)
+
+func TestHTTPTaskHandler_ExecuteBatch(t *testing.T) {
+	t.Parallel()
+
+	completed := true
+	title := "Groceries"
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		setup            setup
+		wantStatus       int
+		wantResponseBody interface{}
+	}{
+		{
+			name: "should return 200 with one result per operation",
+			requestBody: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "create_task", "ref": "new", "task": map[string]interface{}{"title": "Shopping"}},
+					{"op": "update_task", "task_ref": "new", "title": title},
+					{"op": "toggle_item", "task_id": 7, "item_id": 3, "completed": true},
+				},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ExecuteBatch", mock.Anything, usecases.BatchParams{
+					Operations: []usecases.BatchOperationParams{
+						{Type: usecases.BatchCreateTask, Ref: "new", Task: usecases.CreateTaskParams{Title: "Shopping", Items: []usecases.CreateTaskItemParams{}}},
+						{Type: usecases.BatchUpdateTask, TaskRef: "new", Update: usecases.UpdateTaskParams{Title: &title}},
+						{Type: usecases.BatchToggleItem, TaskID: 7, ItemID: 3, Completed: &completed},
+					},
+				}).Return(&usecases.BatchResult{
+					Results: []usecases.BatchOperationResult{
+						{Index: 0, Type: usecases.BatchCreateTask, Ref: "new", TaskID: 42, Task: &usecases.TaskResult{ID: 42, Title: "Shopping"}},
+						{Index: 1, Type: usecases.BatchUpdateTask, TaskID: 42, Task: &usecases.TaskResult{ID: 42, Title: title}},
+						{Index: 2, Type: usecases.BatchToggleItem, TaskID: 7, Item: &usecases.TaskItemResult{ID: 3, TaskID: 7, Title: "Milk", Completed: true}},
+					},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantResponseBody: batchHTTPResponse{
+				Results: []batchOperationHTTPResponse{
+					{Index: 0, Op: "create_task", Ref: "new", TaskID: 42, Task: &taskHTTPResponse{ID: 42, Title: "Shopping", Items: []taskItemHTTPResponse{}}},
+					{Index: 1, Op: "update_task", TaskID: 42, Task: &taskHTTPResponse{ID: 42, Title: title, Items: []taskItemHTTPResponse{}}},
+					{Index: 2, Op: "toggle_item", TaskID: 7, Item: &taskItemHTTPResponse{ID: 3, TaskID: 7, Title: "Milk", Completed: true}},
+				},
+			},
+		},
+		{
+			name: "should return the index of the failed operation",
+			requestBody: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "delete_task", "task_id": 1},
+					{"op": "delete_task", "task_id": 999},
+				},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ExecuteBatch", mock.Anything, mock.Anything).
+					Return(nil, &usecases.BatchOperationError{Index: 1, Err: db.ErrTaskNotFound}).Once()
+			},
+			wantStatus: http.StatusNotFound,
+			wantResponseBody: batchErrorHTTPResponse{
+				Error: db.ErrTaskNotFound.Error(),
+				Index: 1,
+			},
+		},
+		{
+			name: "should return 400 when a reference is unknown",
+			requestBody: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "delete_task", "task_ref": "missing"},
+				},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ExecuteBatch", mock.Anything, mock.Anything).
+					Return(nil, &usecases.BatchOperationError{Index: 0, Err: usecases.ErrUnknownBatchRef}).Once()
+			},
+			wantStatus: http.StatusBadRequest,
+			wantResponseBody: batchErrorHTTPResponse{
+				Error: usecases.ErrUnknownBatchRef.Error(),
+				Index: 0,
+			},
+		},
+		{
+			name: "should return 400 when operation is unknown",
+			requestBody: map[string]interface{}{
+				"operations": []map[string]interface{}{
+					{"op": "rename_item", "task_id": 1},
+				},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "should return 400 when there are no operations",
+			requestBody: map[string]interface{}{"operations": []map[string]interface{}{}},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerBatchRoutes(api)
+
+			// Create request
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/batch", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantResponseBody != nil {
+				want, err := json.Marshal(tt.wantResponseBody)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(want), w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav"
+	"github.com/emersion/go-webdav/caldav"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+)
+
+// caldavBackend serves the tasks as the single calendar of a single principal, there being no user accounts
+type caldavBackend struct {
+	caldavUsecase usecases.CalDAVUsecase
+}
+
+type caldavIfMatchContextKey struct{}
+
+// tasksCalendar is the calendar collection holding every task and item
+var tasksCalendar = caldav.Calendar{
+	Path:                  caldavCalendarPath,
+	Name:                  caldavCalendarName,
+	Description:           caldavCalendarDescription,
+	SupportedComponentSet: []string{ical.CompToDo},
+}
+
+// CurrentUserPrincipal implements webdav.UserPrincipalBackend
+func (b *caldavBackend) CurrentUserPrincipal(context.Context) (string, error) {
+	return caldavPrincipalPath, nil
+}
+
+// CalendarHomeSetPath implements caldav.Backend
+func (b *caldavBackend) CalendarHomeSetPath(context.Context) (string, error) {
+	return caldavHomeSetPath, nil
+}
+
+// CreateCalendar implements caldav.Backend, the tasks calendar being the only one
+func (b *caldavBackend) CreateCalendar(context.Context, *caldav.Calendar) error {
+	return webdav.NewHTTPError(http.StatusForbidden, errors.New("calendars cannot be created"))
+}
+
+// ListCalendars implements caldav.Backend
+func (b *caldavBackend) ListCalendars(context.Context) ([]caldav.Calendar, error) {
+	return []caldav.Calendar{tasksCalendar}, nil
+}
+
+// GetCalendar implements caldav.Backend
+func (b *caldavBackend) GetCalendar(_ context.Context, urlPath string) (*caldav.Calendar, error) {
+	if !isCalDAVCalendarPath(urlPath) {
+		return nil, webdav.NewHTTPError(http.StatusNotFound, errors.New("calendar not found"))
+	}
+
+	calendar := tasksCalendar
+	return &calendar, nil
+}
+
+// GetCalendarObject implements caldav.Backend, the whole VTODO being returned whatever the requested properties
+func (b *caldavBackend) GetCalendarObject(ctx context.Context, urlPath string, _ *caldav.CalendarCompRequest) (*caldav.CalendarObject, error) {
+	uid, ok := caldavObjectUID(urlPath)
+	if !ok {
+		return nil, webdav.NewHTTPError(http.StatusNotFound, usecases.ErrCalendarObjectNotFound)
+	}
+
+	result, err := b.caldavUsecase.GetObject(ctx, uid)
+	if err != nil {
+		return nil, caldavError(ctx, err)
+	}
+
+	return resultToCalendarObject(result), nil
+}
+
+// ListCalendarObjects implements caldav.Backend
+func (b *caldavBackend) ListCalendarObjects(ctx context.Context, urlPath string, _ *caldav.CalendarCompRequest) ([]caldav.CalendarObject, error) {
+	if !isCalDAVCalendarPath(urlPath) {
+		return nil, webdav.NewHTTPError(http.StatusNotFound, errors.New("calendar not found"))
+	}
+
+	results, err := b.caldavUsecase.ListObjects(ctx)
+	if err != nil {
+		return nil, caldavError(ctx, err)
+	}
+
+	objects := make([]caldav.CalendarObject, 0, len(results))
+	for i := range results {
+		objects = append(objects, *resultToCalendarObject(&results[i]))
+	}
+
+	return objects, nil
+}
+
+// QueryCalendarObjects implements caldav.Backend by filtering every object
+func (b *caldavBackend) QueryCalendarObjects(ctx context.Context, urlPath string, query *caldav.CalendarQuery) ([]caldav.CalendarObject, error) {
+	objects, err := b.ListCalendarObjects(ctx, urlPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return caldav.Filter(query, objects)
+}
+
+// PutCalendarObject implements caldav.Backend
+// Objects are stored by the UID of their VTODO: uploading to another name moves them to the name of their UID,
+// returned in the Location header
+func (b *caldavBackend) PutCalendarObject(ctx context.Context, urlPath string, calendar *ical.Calendar, opts *caldav.PutCalendarObjectOptions) (*caldav.CalendarObject, error) {
+	if _, ok := caldavObjectUID(urlPath); !ok {
+		return nil, webdav.NewHTTPError(http.StatusMethodNotAllowed, errors.New("calendar objects are stored in "+caldavCalendarPath))
+	}
+
+	result, err := b.caldavUsecase.PutObject(ctx, usecases.PutCalendarObjectParams{
+		Calendar:    calendar,
+		IfMatch:     conditionalETag(string(opts.IfMatch)),
+		IfNoneMatch: conditionalETag(string(opts.IfNoneMatch)),
+	})
+	if err != nil {
+		return nil, caldavError(ctx, err)
+	}
+
+	return resultToCalendarObject(result), nil
+}
+
+// DeleteCalendarObject implements caldav.Backend, honouring the If-Match header stored in ctx by HTTPCalDAVHandler
+func (b *caldavBackend) DeleteCalendarObject(ctx context.Context, urlPath string) error {
+	uid, ok := caldavObjectUID(urlPath)
+	if !ok {
+		return webdav.NewHTTPError(http.StatusForbidden, errors.New("only calendar objects can be deleted"))
+	}
+
+	ifMatch, _ := ctx.Value(caldavIfMatchContextKey{}).(string)
+	err := b.caldavUsecase.DeleteObject(ctx, usecases.DeleteCalendarObjectParams{
+		UID:     uid,
+		IfMatch: conditionalETag(ifMatch),
+	})
+	if err != nil {
+		return caldavError(ctx, err)
+	}
+
+	return nil
+}
+
+// resultToCalendarObject maps a usecase result to a CalDAV calendar object
+func resultToCalendarObject(result *usecases.CalendarObjectResult) *caldav.CalendarObject {
+	return &caldav.CalendarObject{
+		Path:    caldavObjectPath(result.UID),
+		ModTime: result.ModTime,
+		ETag:    result.ETag,
+		Data:    result.Data,
+	}
+}
+
+// caldavError attaches the HTTP status and message of a domain error for the CalDAV library to respond with,
+// unexpected errors being logged
+func caldavError(ctx context.Context, err error) error {
+	status := domainErrorStatus(err)
+	if status >= http.StatusInternalServerError {
+		logger.FromContext(ctx).Error().Err(err).Msg("CalDAV request failed")
+	}
+
+	return webdav.NewHTTPError(status, errors.New(domainErrorMessage(err)))
+}
+
+// caldavObjectPath returns the path of the calendar object of a UID
+func caldavObjectPath(uid string) string {
+	return caldavCalendarPath + url.PathEscape(uid) + caldavObjectSuffix
+}
+
+// caldavObjectUID returns the UID of the calendar object at urlPath, if it is one
+func caldavObjectUID(urlPath string) (string, bool) {
+	name, ok := strings.CutPrefix(urlPath, caldavCalendarPath)
+	if !ok || strings.Contains(name, "/") {
+		return "", false
+	}
+
+	name, ok = strings.CutSuffix(name, caldavObjectSuffix)
+	if !ok || name == "" {
+		return "", false
+	}
+
+	return name, true
+}
+
+func isCalDAVCalendarPath(urlPath string) bool {
+	return strings.TrimSuffix(urlPath, "/") == strings.TrimSuffix(caldavCalendarPath, "/")
+}
+
+// conditionalETag returns the unquoted entity tag of an If-Match or If-None-Match header, "*" for any,
+// or "" when the header is not set
+func conditionalETag(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "*" {
+		return value
+	}
+
+	return strings.Trim(strings.TrimPrefix(value, "W/"), `"`)
+}
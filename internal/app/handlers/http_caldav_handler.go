@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/gin-gonic/gin"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+)
+
+// Paths of the CalDAV resources: a principal with a single calendar holding every task and item
+const (
+	caldavPrefix        = "/dav"
+	caldavPrincipalPath = caldavPrefix + "/principal/"
+	caldavHomeSetPath   = caldavPrincipalPath + "calendars/"
+	caldavCalendarPath  = caldavHomeSetPath + "tasks/"
+	caldavObjectSuffix  = ".ics"
+
+	caldavCalendarName        = "Tasks"
+	caldavCalendarDescription = "Tasks of the TODO API, items being sub-tasks"
+
+	// caldavSyncTokenPrefix turns sync tokens into the URIs RFC 6578 requires
+	caldavSyncTokenPrefix = "urn:todo-bun-app:sync:"
+	// maxCalDAVRequestSize bounds the XML bodies read by HTTPCalDAVHandler itself
+	maxCalDAVRequestSize = 1 << 20
+)
+
+// caldavMethods are the HTTP methods of the CalDAV routes
+var caldavMethods = []string{
+	http.MethodOptions, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete,
+	"PROPFIND", "PROPPATCH", "REPORT", "MKCOL", "MKCALENDAR", "COPY", "MOVE",
+}
+
+// HTTPCalDAVHandler handles CalDAV (RFC 4791) requests
+type HTTPCalDAVHandler struct {
+	caldavUsecase usecases.CalDAVUsecase
+	dav           *caldav.Handler
+}
+
+// NewHTTPCalDAVHandler creates a new HTTPCalDAVHandler instance
+func NewHTTPCalDAVHandler(caldavUsecase usecases.CalDAVUsecase) *HTTPCalDAVHandler {
+	return &HTTPCalDAVHandler{
+		caldavUsecase: caldavUsecase,
+		dav: &caldav.Handler{
+			Backend: &caldavBackend{caldavUsecase: caldavUsecase},
+			Prefix:  caldavPrefix,
+		},
+	}
+}
+
+// Serve handles the requests under /dav/ and /.well-known/caldav
+// The CalDAV library supports neither sync tokens nor the sync-collection report (RFC 6578), so these
+// reports and the PROPFIND requests for the properties of the calendar itself are answered here,
+// everything else being delegated to the library
+func (h *HTTPCalDAVHandler) Serve(c *gin.Context) {
+	switch c.Request.Method {
+	case "REPORT":
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxCalDAVRequestSize))
+		if err != nil {
+			c.String(http.StatusRequestEntityTooLarge, "request body is too large")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		if davRootElement(body) == (xml.Name{Space: davNamespace, Local: "sync-collection"}) {
+			h.syncCollection(c, body)
+			return
+		}
+	case "PROPFIND":
+		if isCalDAVCalendarPath(c.Request.URL.Path) && c.GetHeader("Depth") == "0" {
+			h.propFindCalendar(c)
+			return
+		}
+	case http.MethodDelete:
+		ctx := context.WithValue(c.Request.Context(), caldavIfMatchContextKey{}, c.GetHeader("If-Match"))
+		c.Request = c.Request.WithContext(ctx)
+	}
+
+	h.dav.ServeHTTP(c.Writer, c.Request)
+}
+
+// propFindCalendar answers a PROPFIND request for the properties of the calendar, including its sync token
+func (h *HTTPCalDAVHandler) propFindCalendar(c *gin.Context) {
+	var req davPropFind
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxCalDAVRequestSize))
+	if err != nil {
+		c.String(http.StatusRequestEntityTooLarge, "request body is too large")
+		return
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err = xml.Unmarshal(body, &req); err != nil {
+			c.String(http.StatusBadRequest, "invalid PROPFIND request: %v", err)
+			return
+		}
+	}
+
+	token, err := h.caldavUsecase.SyncToken(c.Request.Context())
+	if err != nil {
+		respondWithCalDAVError(c, err)
+		return
+	}
+
+	writeMultiStatus(c, davMultiStatus{Responses: []davResponse{{
+		Href:      caldavCalendarPath,
+		PropStats: davPropStats(calendarProperties(token), requestedProperties(req.Prop)),
+	}}})
+}
+
+// syncCollection answers a sync-collection report with the objects changed and deleted since its sync token
+// The report is always of level 1, the calendar having no sub-collections, and its limit is ignored
+func (h *HTTPCalDAVHandler) syncCollection(c *gin.Context, body []byte) {
+	if !isCalDAVCalendarPath(c.Request.URL.Path) {
+		writeDAVError(c, http.StatusForbidden, xml.Name{Space: davNamespace, Local: "supported-report"})
+		return
+	}
+
+	var req davSyncCollection
+	if err := xml.Unmarshal(body, &req); err != nil {
+		c.String(http.StatusBadRequest, "invalid sync-collection request: %v", err)
+		return
+	}
+
+	token := strings.TrimSpace(req.SyncToken)
+	if token != "" {
+		var ok bool
+		if token, ok = strings.CutPrefix(token, caldavSyncTokenPrefix); !ok {
+			writeDAVError(c, http.StatusForbidden, xml.Name{Space: davNamespace, Local: "valid-sync-token"})
+			return
+		}
+	}
+
+	result, err := h.caldavUsecase.SyncObjects(c.Request.Context(), token)
+	if errors.Is(err, usecases.ErrInvalidSyncToken) {
+		writeDAVError(c, http.StatusForbidden, xml.Name{Space: davNamespace, Local: "valid-sync-token"})
+		return
+	}
+	if err != nil {
+		respondWithCalDAVError(c, err)
+		return
+	}
+
+	names := requestedProperties(req.Prop)
+	multiStatus := davMultiStatus{
+		Responses: make([]davResponse, 0, len(result.Objects)+len(result.Deleted)),
+		SyncToken: caldavSyncTokenPrefix + result.Token,
+	}
+	for i := range result.Objects {
+		properties, err := calendarObjectProperties(&result.Objects[i])
+		if err != nil {
+			respondWithCalDAVError(c, err)
+			return
+		}
+		multiStatus.Responses = append(multiStatus.Responses, davResponse{
+			Href:      caldavObjectPath(result.Objects[i].UID),
+			PropStats: davPropStats(properties, names),
+		})
+	}
+	for _, uid := range result.Deleted {
+		multiStatus.Responses = append(multiStatus.Responses, davResponse{
+			Href:   caldavObjectPath(uid),
+			Status: davStatus(http.StatusNotFound),
+		})
+	}
+
+	writeMultiStatus(c, multiStatus)
+}
+
+// calendarProperties returns the properties of the tasks calendar
+func calendarProperties(token string) []davProperty {
+	syncToken := caldavSyncTokenPrefix + token
+
+	return []davProperty{
+		{
+			XMLName: xml.Name{Space: davNamespace, Local: "resourcetype"},
+			Value:   `<collection xmlns="DAV:"/><calendar xmlns="urn:ietf:params:xml:ns:caldav"/>`,
+		},
+		davText(xml.Name{Space: davNamespace, Local: "displayname"}, caldavCalendarName),
+		davHref(xml.Name{Space: davNamespace, Local: "current-user-principal"}, caldavPrincipalPath),
+		{
+			XMLName: xml.Name{Space: davNamespace, Local: "current-user-privilege-set"},
+			Value:   `<privilege xmlns="DAV:"><read/></privilege><privilege xmlns="DAV:"><write/></privilege>`,
+		},
+		{
+			XMLName: xml.Name{Space: davNamespace, Local: "supported-report-set"},
+			Value: `<supported-report xmlns="DAV:"><report><calendar-query xmlns="urn:ietf:params:xml:ns:caldav"/></report></supported-report>` +
+				`<supported-report xmlns="DAV:"><report><calendar-multiget xmlns="urn:ietf:params:xml:ns:caldav"/></report></supported-report>` +
+				`<supported-report xmlns="DAV:"><report><sync-collection/></report></supported-report>`,
+		},
+		davText(xml.Name{Space: davNamespace, Local: "sync-token"}, syncToken),
+		// Clients without sync-collection support poll the collection tag instead
+		davText(xml.Name{Space: calendarServerNamespace, Local: "getctag"}, syncToken),
+		davText(xml.Name{Space: caldavNamespace, Local: "calendar-description"}, caldavCalendarDescription),
+		{
+			XMLName: xml.Name{Space: caldavNamespace, Local: "supported-calendar-component-set"},
+			Value:   `<comp xmlns="urn:ietf:params:xml:ns:caldav" name="VTODO"/>`,
+		},
+		{
+			XMLName: xml.Name{Space: caldavNamespace, Local: "supported-calendar-data"},
+			Value:   `<calendar-data xmlns="urn:ietf:params:xml:ns:caldav" content-type="text/calendar" version="2.0"/>`,
+		},
+	}
+}
+
+// calendarObjectProperties returns the properties of a calendar object
+func calendarObjectProperties(object *usecases.CalendarObjectResult) ([]davProperty, error) {
+	var data bytes.Buffer
+	if err := ical.NewEncoder(&data).Encode(object.Data); err != nil {
+		return nil, err
+	}
+
+	return []davProperty{
+		davText(xml.Name{Space: davNamespace, Local: "getetag"}, `"`+object.ETag+`"`),
+		davText(xml.Name{Space: davNamespace, Local: "getcontenttype"}, ical.MIMEType+"; charset=utf-8; component=VTODO"),
+		davText(xml.Name{Space: davNamespace, Local: "getlastmodified"}, object.ModTime.UTC().Format(http.TimeFormat)),
+		{XMLName: xml.Name{Space: davNamespace, Local: "resourcetype"}},
+		davText(xml.Name{Space: caldavNamespace, Local: "calendar-data"}, data.String()),
+	}, nil
+}
+
+// requestedProperties returns the names of the requested properties, nil when every property is requested
+func requestedProperties(prop *davPropNames) []davPropName {
+	if prop == nil {
+		return nil
+	}
+	if prop.Names == nil {
+		return []davPropName{}
+	}
+
+	return prop.Names
+}
+
+// respondWithCalDAVError responds with the status code and message of a domain error as plain text, as CalDAV
+// clients expect, unexpected errors being logged
+func respondWithCalDAVError(c *gin.Context, err error) {
+	status := domainErrorStatus(err)
+	if status >= http.StatusInternalServerError {
+		logger.FromContext(c.Request.Context()).Error().
+			Err(err).
+			Str("method", c.Request.Method).
+			Str("path", c.Request.URL.Path).
+			Msg("Request failed")
+	}
+
+	c.String(status, domainErrorMessage(err))
+}
+
+func writeMultiStatus(c *gin.Context, multiStatus davMultiStatus) {
+	body, err := encodeDAVXML(multiStatus)
+	if err != nil {
+		respondWithCalDAVError(c, err)
+		return
+	}
+
+	c.Data(http.StatusMultiStatus, "application/xml; charset=utf-8", body)
+}
+
+func writeDAVError(c *gin.Context, status int, precondition xml.Name) {
+	body, err := encodeDAVXML(davError{Precondition: davProperty{XMLName: precondition}})
+	if err != nil {
+		respondWithCalDAVError(c, err)
+		return
+	}
+
+	c.Data(status, "application/xml; charset=utf-8", body)
+}
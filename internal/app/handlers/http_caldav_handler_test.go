@@ -0,0 +1,334 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/emersion/go-webdav/caldav"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+)
+
+func newCalDAVTestServer(t *testing.T, mockUsecase *mocks.CalDAVUsecase) *httptest.Server {
+	t.Helper()
+
+	handler := HTTPHandler{
+		httpCalDAVHandler: NewHTTPCalDAVHandler(mockUsecase),
+	}
+	router := gin.New()
+	handler.registerCalDAVRoutes(router)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func newTestCalendarObject(uid, summary string) usecases.CalendarObjectResult {
+	modTime := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	todo := ical.NewComponent(ical.CompToDo)
+	todo.Props.SetText(ical.PropUID, uid)
+	todo.Props.SetDateTime(ical.PropDateTimeStamp, modTime)
+	todo.Props.SetText(ical.PropSummary, summary)
+
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, "-//test//EN")
+	cal.Children = append(cal.Children, todo)
+
+	return usecases.CalendarObjectResult{UID: uid, ETag: "etag-" + uid, ModTime: modTime, Data: cal}
+}
+
+func TestHTTPCalDAVHandler_Client(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	shopping := newTestCalendarObject("task-1@todo-bun-app", "Shopping")
+	milk := newTestCalendarObject("item-2@todo-bun-app", "Buy milk")
+
+	mockUsecase := mocks.NewCalDAVUsecase(t)
+	server := newCalDAVTestServer(t, mockUsecase)
+	client, err := caldav.NewClient(server.Client(), server.URL+caldavPrefix+"/")
+	require.NoError(t, err)
+
+	// Discovery, as done by calendar apps
+	principal, err := client.FindCurrentUserPrincipal(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, caldavPrincipalPath, principal)
+
+	homeSet, err := client.FindCalendarHomeSet(ctx, principal)
+	require.NoError(t, err)
+	assert.Equal(t, caldavHomeSetPath, homeSet)
+
+	calendars, err := client.FindCalendars(ctx, homeSet)
+	require.NoError(t, err)
+	require.Len(t, calendars, 1)
+	assert.Equal(t, caldavCalendarPath, calendars[0].Path)
+	assert.Equal(t, []string{ical.CompToDo}, calendars[0].SupportedComponentSet)
+
+	// Query of the open to-dos
+	mockUsecase.On("ListObjects", mock.Anything).
+		Return([]usecases.CalendarObjectResult{shopping, milk}, nil).Once()
+	objects, err := client.QueryCalendar(ctx, caldavCalendarPath, &caldav.CalendarQuery{
+		CompFilter: caldav.CompFilter{
+			Name:  ical.CompCalendar,
+			Comps: []caldav.CompFilter{{Name: ical.CompToDo}},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, objects, 2)
+	assert.Equal(t, caldavObjectPath(shopping.UID), objects[0].Path)
+	assert.Equal(t, shopping.ETag, objects[0].ETag)
+
+	// Download of a single to-do
+	mockUsecase.On("GetObject", mock.Anything, milk.UID).Return(&milk, nil).Once()
+	object, err := client.GetCalendarObject(ctx, caldavObjectPath(milk.UID))
+	require.NoError(t, err)
+	assert.Equal(t, milk.ETag, object.ETag)
+	summary, err := object.Data.Children[0].Props.Text(ical.PropSummary)
+	require.NoError(t, err)
+	assert.Equal(t, "Buy milk", summary)
+
+	// Upload of a new to-do, named after its UID
+	created := newTestCalendarObject("A1B2C3", "Call the bank")
+	mockUsecase.On("PutObject", mock.Anything, mock.MatchedBy(func(params usecases.PutCalendarObjectParams) bool {
+		uid, _ := params.Calendar.Children[0].Props.Text(ical.PropUID)
+		return uid == created.UID
+	})).Return(&created, nil).Once()
+	object, err = client.PutCalendarObject(ctx, caldavObjectPath(created.UID), created.Data)
+	require.NoError(t, err)
+	assert.Equal(t, created.ETag, object.ETag)
+
+	// Deletion
+	mockUsecase.On("DeleteObject", mock.Anything, usecases.DeleteCalendarObjectParams{UID: created.UID}).
+		Return(nil).Once()
+	require.NoError(t, client.RemoveAll(ctx, caldavObjectPath(created.UID)))
+}
+
+func TestHTTPCalDAVHandler(t *testing.T) {
+	t.Parallel()
+
+	shopping := newTestCalendarObject("task-1@todo-bun-app", "Shopping & co")
+
+	type setup func(t *testing.T, mockUsecase *mocks.CalDAVUsecase)
+
+	tests := []struct {
+		name        string
+		method      string
+		url         string
+		header      map[string]string
+		requestBody string
+		setup       setup
+		wantStatus  int
+		wantBody    []string
+		hiddenBody  []string
+	}{
+		{
+			name:   "should return the properties of the calendar with its sync token",
+			method: "PROPFIND",
+			url:    caldavCalendarPath,
+			header: map[string]string{"Depth": "0"},
+			requestBody: `<?xml version="1.0"?>
+				<propfind xmlns="DAV:" xmlns:CS="http://calendarserver.org/ns/">
+					<prop><resourcetype/><sync-token/><CS:getctag/><owner/></prop>
+				</propfind>`,
+			setup: func(t *testing.T, mockUsecase *mocks.CalDAVUsecase) {
+				mockUsecase.On("SyncToken", mock.Anything).Return("djE6NDI", nil).Once()
+			},
+			wantStatus: http.StatusMultiStatus,
+			wantBody: []string{
+				`<calendar xmlns="urn:ietf:params:xml:ns:caldav"/>`,
+				`<sync-token xmlns="DAV:">urn:todo-bun-app:sync:djE6NDI</sync-token>`,
+				`<getctag xmlns="http://calendarserver.org/ns/">urn:todo-bun-app:sync:djE6NDI</getctag>`,
+				`<owner xmlns="DAV:"></owner>`,
+				`HTTP/1.1 404 Not Found`,
+			},
+		},
+		{
+			name:   "should return the changed and deleted objects since the sync token",
+			method: "REPORT",
+			url:    caldavCalendarPath,
+			requestBody: `<?xml version="1.0"?>
+				<sync-collection xmlns="DAV:">
+					<sync-token>urn:todo-bun-app:sync:djE6NDI</sync-token>
+					<sync-level>1</sync-level>
+					<prop><getetag/></prop>
+				</sync-collection>`,
+			setup: func(t *testing.T, mockUsecase *mocks.CalDAVUsecase) {
+				mockUsecase.On("SyncObjects", mock.Anything, "djE6NDI").Return(&usecases.CalendarSyncResult{
+					Token:   "djE6NDU",
+					Objects: []usecases.CalendarObjectResult{shopping},
+					Deleted: []string{"item-3@todo-bun-app"},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusMultiStatus,
+			wantBody: []string{
+				`<href>/dav/principal/calendars/tasks/task-1@todo-bun-app.ics</href>`,
+				`<getetag xmlns="DAV:">&#34;etag-task-1@todo-bun-app&#34;</getetag>`,
+				`<href>/dav/principal/calendars/tasks/item-3@todo-bun-app.ics</href><status>HTTP/1.1 404 Not Found</status>`,
+				`<sync-token>urn:todo-bun-app:sync:djE6NDU</sync-token>`,
+			},
+		},
+		{
+			name:   "should return every object with their data on an initial sync",
+			method: "REPORT",
+			url:    caldavCalendarPath,
+			requestBody: `<?xml version="1.0"?>
+				<sync-collection xmlns="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+					<sync-token/>
+					<prop><getetag/><C:calendar-data/></prop>
+				</sync-collection>`,
+			setup: func(t *testing.T, mockUsecase *mocks.CalDAVUsecase) {
+				mockUsecase.On("SyncObjects", mock.Anything, "").Return(&usecases.CalendarSyncResult{
+					Token:   "djE6NDU",
+					Objects: []usecases.CalendarObjectResult{shopping},
+					Deleted: []string{},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusMultiStatus,
+			wantBody: []string{
+				`<calendar-data xmlns="urn:ietf:params:xml:ns:caldav">BEGIN:VCALENDAR`,
+				`SUMMARY:Shopping &amp; co`,
+			},
+		},
+		{
+			name:   "should return 403 with the valid-sync-token precondition for an unknown token",
+			method: "REPORT",
+			url:    caldavCalendarPath,
+			requestBody: `<?xml version="1.0"?>
+				<sync-collection xmlns="DAV:"><sync-token>urn:todo-bun-app:sync:djE6OTk5</sync-token><prop><getetag/></prop></sync-collection>`,
+			setup: func(t *testing.T, mockUsecase *mocks.CalDAVUsecase) {
+				mockUsecase.On("SyncObjects", mock.Anything, "djE6OTk5").Return(nil, usecases.ErrInvalidSyncToken).Once()
+			},
+			wantStatus: http.StatusForbidden,
+			wantBody:   []string{`<error xmlns="DAV:"><valid-sync-token xmlns="DAV:"></valid-sync-token></error>`},
+		},
+		{
+			name:   "should return 403 for a sync token issued by another server",
+			method: "REPORT",
+			url:    caldavCalendarPath,
+			requestBody: `<?xml version="1.0"?>
+				<sync-collection xmlns="DAV:"><sync-token>http://example.com/sync/1</sync-token><prop><getetag/></prop></sync-collection>`,
+			wantStatus: http.StatusForbidden,
+			wantBody:   []string{`<valid-sync-token xmlns="DAV:">`},
+		},
+		{
+			name:   "should return 412 when deleting an object that changed",
+			method: http.MethodDelete,
+			url:    caldavObjectPath(shopping.UID),
+			header: map[string]string{"If-Match": `"stale"`},
+			setup: func(t *testing.T, mockUsecase *mocks.CalDAVUsecase) {
+				mockUsecase.On("DeleteObject", mock.Anything, usecases.DeleteCalendarObjectParams{UID: shopping.UID, IfMatch: "stale"}).
+					Return(usecases.ErrCalendarObjectPreconditionFailed).Once()
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "should return 412 when creating an object that already exists",
+			method: http.MethodPut,
+			url:    caldavObjectPath(shopping.UID),
+			header: map[string]string{"If-None-Match": "*", "Content-Type": ical.MIMEType},
+			requestBody: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//test//EN\r\nBEGIN:VTODO\r\n" +
+				"UID:task-1@todo-bun-app\r\nDTSTAMP:20250301T100000Z\r\nSUMMARY:Shopping\r\nEND:VTODO\r\nEND:VCALENDAR\r\n",
+			setup: func(t *testing.T, mockUsecase *mocks.CalDAVUsecase) {
+				mockUsecase.On("PutObject", mock.Anything, mock.MatchedBy(func(params usecases.PutCalendarObjectParams) bool {
+					return params.IfNoneMatch == "*" && params.IfMatch == ""
+				})).Return(nil, usecases.ErrCalendarObjectPreconditionFailed).Once()
+			},
+			wantStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:   "should return 404 for an unknown object",
+			method: http.MethodGet,
+			url:    caldavObjectPath("unknown"),
+			setup: func(t *testing.T, mockUsecase *mocks.CalDAVUsecase) {
+				mockUsecase.On("GetObject", mock.Anything, "unknown").Return(nil, usecases.ErrCalendarObjectNotFound).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:   "should hide database errors behind a generic message",
+			method: "PROPFIND",
+			url:    caldavCalendarPath,
+			header: map[string]string{"Depth": "0"},
+			setup: func(t *testing.T, mockUsecase *mocks.CalDAVUsecase) {
+				mockUsecase.On("SyncToken", mock.Anything).Return("", errors.New(`relation "tasks" does not exist`)).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   []string{"internal server error"},
+			hiddenBody: []string{"relation"},
+		},
+		{
+			name:   "should hide database errors of the CalDAV library behind a generic message",
+			method: http.MethodGet,
+			url:    caldavObjectPath("task-1@todo-bun-app"),
+			setup: func(t *testing.T, mockUsecase *mocks.CalDAVUsecase) {
+				mockUsecase.On("GetObject", mock.Anything, "task-1@todo-bun-app").
+					Return(nil, errors.New(`relation "tasks" does not exist`)).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   []string{"internal server error"},
+			hiddenBody: []string{"relation"},
+		},
+		{
+			name:       "should redirect the well-known URL to the principal",
+			method:     http.MethodGet,
+			url:        "/.well-known/caldav",
+			wantStatus: http.StatusPermanentRedirect,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewCalDAVUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpCalDAVHandler: NewHTTPCalDAVHandler(mockUsecase),
+			}
+			router := gin.New()
+			handler.registerCalDAVRoutes(router)
+
+			// Create request
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.requestBody))
+			req.Header.Set("Content-Type", "application/xml")
+			for key, value := range tt.header {
+				req.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+
+			// Execute request
+			router.ServeHTTP(w, req)
+
+			// Assert response
+			assert.Equal(t, tt.wantStatus, w.Code)
+			body, err := io.ReadAll(w.Body)
+			require.NoError(t, err)
+			for _, want := range tt.wantBody {
+				assert.Contains(t, string(body), want)
+			}
+			for _, hidden := range tt.hiddenBody {
+				assert.NotContains(t, string(body), hidden)
+			}
+		})
+	}
+}
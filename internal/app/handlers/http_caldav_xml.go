@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// XML namespaces of the WebDAV and CalDAV elements
+const (
+	davNamespace            = "DAV:"
+	caldavNamespace         = "urn:ietf:params:xml:ns:caldav"
+	calendarServerNamespace = "http://calendarserver.org/ns/"
+)
+
+// davPropFind is the body of a PROPFIND request, every property being requested when Prop is nil
+type davPropFind struct {
+	XMLName xml.Name      `xml:"DAV: propfind"`
+	Prop    *davPropNames `xml:"DAV: prop"`
+}
+
+// davSyncCollection is the body of a sync-collection REPORT request (RFC 6578)
+type davSyncCollection struct {
+	XMLName   xml.Name      `xml:"DAV: sync-collection"`
+	SyncToken string        `xml:"DAV: sync-token"`
+	Prop      *davPropNames `xml:"DAV: prop"`
+}
+
+// davPropNames lists the requested properties
+type davPropNames struct {
+	Names []davPropName `xml:",any"`
+}
+
+type davPropName struct {
+	XMLName xml.Name
+}
+
+// davMultiStatus is the body of a 207 Multi-Status response
+type davMultiStatus struct {
+	XMLName   xml.Name      `xml:"DAV: multistatus"`
+	Responses []davResponse `xml:"response"`
+	SyncToken string        `xml:"sync-token,omitempty"`
+}
+
+// davResponse holds the properties of a resource, or the status of a deleted one
+type davResponse struct {
+	Href      string        `xml:"href"`
+	PropStats []davPropStat `xml:"propstat,omitempty"`
+	Status    string        `xml:"status,omitempty"`
+}
+
+type davPropStat struct {
+	Prop   davProp `xml:"prop"`
+	Status string  `xml:"status"`
+}
+
+type davProp struct {
+	Properties []davProperty `xml:",any"`
+}
+
+// davProperty is a property along with its value, as raw XML
+type davProperty struct {
+	XMLName xml.Name
+	Value   string `xml:",innerxml"`
+}
+
+// davError is the body of an error response carrying a failed precondition
+type davError struct {
+	XMLName      xml.Name `xml:"DAV: error"`
+	Precondition davProperty
+}
+
+// davText returns a property whose value is a text
+func davText(name xml.Name, text string) davProperty {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(text))
+
+	return davProperty{XMLName: name, Value: b.String()}
+}
+
+// davHref returns a property whose value is a URL
+func davHref(name xml.Name, href string) davProperty {
+	property := davText(name, href)
+	property.Value = `<href xmlns="DAV:">` + property.Value + `</href>`
+
+	return property
+}
+
+// davPropStats returns the requested properties found in available, and the requested ones that are not
+// with a 404 status; nil names requests every available property
+func davPropStats(available []davProperty, names []davPropName) []davPropStat {
+	if names == nil {
+		return []davPropStat{{Prop: davProp{Properties: available}, Status: davStatus(http.StatusOK)}}
+	}
+
+	var found, missing []davProperty
+	for _, name := range names {
+		index := slices.IndexFunc(available, func(p davProperty) bool { return p.XMLName == name.XMLName })
+		if index < 0 {
+			missing = append(missing, davProperty{XMLName: name.XMLName})
+			continue
+		}
+		found = append(found, available[index])
+	}
+
+	propStats := make([]davPropStat, 0, 2)
+	if len(found) > 0 {
+		propStats = append(propStats, davPropStat{Prop: davProp{Properties: found}, Status: davStatus(http.StatusOK)})
+	}
+	if len(missing) > 0 {
+		propStats = append(propStats, davPropStat{Prop: davProp{Properties: missing}, Status: davStatus(http.StatusNotFound)})
+	}
+
+	return propStats
+}
+
+// davStatus returns the status line of a multi-status response
+func davStatus(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
+
+// encodeDAVXML encodes v as an XML document
+func encodeDAVXML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	if err := xml.NewEncoder(&buf).Encode(v); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// davRootElement returns the name of the root element of an XML request body
+func davRootElement(body []byte) xml.Name {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return xml.Name{}
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+)
+
+// icalFeedSuffix ends the feed URLs, calendar apps telling subscriptions apart by their extension
+const icalFeedSuffix = ".ics"
+
+// HTTPCalendarHandler handles HTTP requests for calendar feeds
+type HTTPCalendarHandler struct {
+	calendarUsecase usecases.CalendarUsecase
+}
+
+// NewHTTPCalendarHandler creates a new HTTPCalendarHandler instance
+func NewHTTPCalendarHandler(calendarUsecase usecases.CalendarUsecase) *HTTPCalendarHandler {
+	return &HTTPCalendarHandler{
+		calendarUsecase: calendarUsecase,
+	}
+}
+
+// CreateFeed handles POST /api/calendar/feeds
+// The token is only returned here, it cannot be retrieved afterwards
+func (h *HTTPCalendarHandler) CreateFeed(c *gin.Context) {
+	var req createCalendarFeedHTTPRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// Call usecase
+	result, err := h.calendarUsecase.CreateFeed(c.Request.Context(), usecases.CreateCalendarFeedParams{Name: req.Name})
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	// Map usecase result to HTTP response
+	response := h.resultToResponse(result)
+	response.Token = result.Token
+	response.URL = feedURL(c.Request, result.Token)
+
+	c.JSON(http.StatusCreated, response)
+}
+
+// ListFeeds handles GET /api/calendar/feeds
+func (h *HTTPCalendarHandler) ListFeeds(c *gin.Context) {
+	// Call usecase
+	result, err := h.calendarUsecase.ListFeeds(c.Request.Context())
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	// Map usecase result to HTTP response
+	response := calendarFeedListHTTPResponse{
+		Feeds: make([]calendarFeedHTTPResponse, 0, len(result.Feeds)),
+	}
+	for _, feed := range result.Feeds {
+		response.Feeds = append(response.Feeds, h.resultToResponse(&feed))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteFeed handles DELETE /api/calendar/feeds/:id
+func (h *HTTPCalendarHandler) DeleteFeed(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "invalid calendar feed ID")
+		return
+	}
+
+	// Call usecase
+	if err = h.calendarUsecase.DeleteFeed(c.Request.Context(), id); err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// Feed handles GET /ical/:token.ics, the URL calendar apps subscribe to
+func (h *HTTPCalendarHandler) Feed(c *gin.Context) {
+	token, ok := strings.CutSuffix(c.Param("token.ics"), icalFeedSuffix)
+	if !ok {
+		respondWithDomainError(c, db.ErrCalendarFeedNotFound)
+		return
+	}
+
+	// Call usecase, buffering the calendar so that a failure can still be reported as JSON
+	var buf bytes.Buffer
+	if err := h.calendarUsecase.WriteFeed(c.Request.Context(), token, &buf); err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Data(http.StatusOK, usecases.TransferICal.ContentType(), buf.Bytes())
+}
+
+// resultToResponse maps usecase result to HTTP response, without the token
+func (h *HTTPCalendarHandler) resultToResponse(result *usecases.CalendarFeedResult) calendarFeedHTTPResponse {
+	return calendarFeedHTTPResponse{
+		ID:        result.ID,
+		Name:      result.Name,
+		CreatedAt: result.CreatedAt,
+	}
+}
+
+// feedURL returns the absolute URL of the feed of token, as seen by the client of req
+func feedURL(req *http.Request, token string) string {
+	scheme := "http"
+	if req.TLS != nil || req.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+
+	return fmt.Sprintf("%s://%s/ical/%s%s", scheme, req.Host, token, icalFeedSuffix)
+}
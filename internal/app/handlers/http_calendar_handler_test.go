@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+)
+
+func TestHTTPCalendarHandler(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	type setup func(t *testing.T, mockUsecase *mocks.CalendarUsecase)
+
+	tests := []struct {
+		name             string
+		method           string
+		url              string
+		requestBody      interface{}
+		setup            setup
+		wantStatus       int
+		wantResponseBody interface{}
+	}{
+		{
+			name:        "should return 201 with the token and the URL of the new feed",
+			method:      http.MethodPost,
+			url:         "/api/calendar/feeds",
+			requestBody: map[string]interface{}{"name": "Phone"},
+			setup: func(t *testing.T, mockUsecase *mocks.CalendarUsecase) {
+				mockUsecase.On("CreateFeed", mock.Anything, usecases.CreateCalendarFeedParams{Name: "Phone"}).
+					Return(&usecases.CalendarFeedResult{ID: 1, Name: "Phone", Token: "secret", CreatedAt: createdAt}, nil).Once()
+			},
+			wantStatus: http.StatusCreated,
+			wantResponseBody: calendarFeedHTTPResponse{
+				ID:        1,
+				Name:      "Phone",
+				Token:     "secret",
+				URL:       "http://example.com/ical/secret.ics",
+				CreatedAt: createdAt,
+			},
+		},
+		{
+			name:   "should return 200 with the feeds without their tokens",
+			method: http.MethodGet,
+			url:    "/api/calendar/feeds",
+			setup: func(t *testing.T, mockUsecase *mocks.CalendarUsecase) {
+				mockUsecase.On("ListFeeds", mock.Anything).
+					Return(&usecases.CalendarFeedListResult{Feeds: []usecases.CalendarFeedResult{{ID: 1, Name: "Phone", CreatedAt: createdAt}}}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantResponseBody: calendarFeedListHTTPResponse{
+				Feeds: []calendarFeedHTTPResponse{{ID: 1, Name: "Phone", CreatedAt: createdAt}},
+			},
+		},
+		{
+			name:   "should return 404 when the feed to delete does not exist",
+			method: http.MethodDelete,
+			url:    "/api/calendar/feeds/7",
+			setup: func(t *testing.T, mockUsecase *mocks.CalendarUsecase) {
+				mockUsecase.On("DeleteFeed", mock.Anything, int64(7)).Return(db.ErrCalendarFeedNotFound).Once()
+			},
+			wantStatus:       http.StatusNotFound,
+			wantResponseBody: errorResponse{Error: db.ErrCalendarFeedNotFound.Error()},
+		},
+		{
+			name:   "should return 404 when the feed token is unknown",
+			method: http.MethodGet,
+			url:    "/ical/guess.ics",
+			setup: func(t *testing.T, mockUsecase *mocks.CalendarUsecase) {
+				mockUsecase.On("WriteFeed", mock.Anything, "guess", mock.Anything).Return(db.ErrCalendarFeedNotFound).Once()
+			},
+			wantStatus:       http.StatusNotFound,
+			wantResponseBody: errorResponse{Error: db.ErrCalendarFeedNotFound.Error()},
+		},
+		{
+			name:             "should return 404 without the .ics extension",
+			method:           http.MethodGet,
+			url:              "/ical/secret",
+			wantStatus:       http.StatusNotFound,
+			wantResponseBody: errorResponse{Error: db.ErrCalendarFeedNotFound.Error()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewCalendarUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpCalendarHandler: NewHTTPCalendarHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerCalendarRoutes(router, api)
+
+			// Create request
+			var body io.Reader
+			if tt.requestBody != nil {
+				payload, err := json.Marshal(tt.requestBody)
+				require.NoError(t, err)
+				body = bytes.NewReader(payload)
+			}
+			req, err := http.NewRequestWithContext(context.Background(), tt.method, "http://example.com"+tt.url, body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			want, err := json.Marshal(tt.wantResponseBody)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), w.Body.String())
+		})
+	}
+}
+
+func TestHTTPCalendarHandler_Feed(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := mocks.NewCalendarUsecase(t)
+	mockUsecase.On("WriteFeed", mock.Anything, "secret", mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = io.WriteString(args.Get(2).(io.Writer), "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+		}).Return(nil).Once()
+
+	handler := HTTPHandler{
+		httpCalendarHandler: NewHTTPCalendarHandler(mockUsecase),
+	}
+	router := gin.Default()
+	handler.registerCalendarRoutes(router, router.Group("/api"))
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/ical/secret.ics", nil)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n", w.Body.String())
+}
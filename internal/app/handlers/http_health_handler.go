@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+)
+
+// Statuses reported by the health endpoints
+const (
+	healthStatusOK          = "ok"
+	healthStatusUnavailable = "unavailable"
+	healthStatusDraining    = "draining"
+	healthCheckStatusFail   = "fail"
+)
+
+// HTTPHealthHandler handles the liveness, readiness and health endpoints
+type HTTPHealthHandler struct {
+	healthUsecase usecases.HealthUsecase
+	draining      atomic.Bool
+}
+
+// NewHTTPHealthHandler creates a new HTTPHealthHandler instance
+func NewHTTPHealthHandler(healthUsecase usecases.HealthUsecase) *HTTPHealthHandler {
+	return &HTTPHealthHandler{
+		healthUsecase: healthUsecase,
+	}
+}
+
+// Live handles GET /livez
+// The process answering is enough, restarting it would not bring a lost database back
+func (h *HTTPHealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, healthHTTPResponse{Status: healthStatusOK})
+}
+
+// Ready handles GET /readyz and GET /health
+// The server is not ready once draining or when a health check fails, load balancers then routing requests elsewhere
+func (h *HTTPHealthHandler) Ready(c *gin.Context) {
+	if h.draining.Load() {
+		c.JSON(http.StatusServiceUnavailable, healthHTTPResponse{Status: healthStatusDraining})
+		return
+	}
+
+	h.Health(c)
+}
+
+// Health handles GET /healthz
+// With ?verbose, the response has the status and latency of every check
+func (h *HTTPHealthHandler) Health(c *gin.Context) {
+	// Call usecase
+	result := h.healthUsecase.Check(c.Request.Context())
+
+	// Map usecase result to HTTP response
+	status := http.StatusOK
+	response := healthHTTPResponse{Status: healthStatusOK}
+	if !result.Healthy {
+		status = http.StatusServiceUnavailable
+		response.Status = healthStatusUnavailable
+	}
+
+	if isVerbose(c) {
+		response.Checks = make([]healthCheckHTTPResponse, 0, len(result.Checks))
+		for _, check := range result.Checks {
+			checkResponse := healthCheckHTTPResponse{
+				Name:       check.Name,
+				Status:     healthStatusOK,
+				Error:      check.Error,
+				DurationMS: float64(check.Duration.Microseconds()) / 1000,
+			}
+			if !check.Healthy {
+				checkResponse.Status = healthCheckStatusFail
+			}
+			response.Checks = append(response.Checks, checkResponse)
+		}
+	}
+
+	c.JSON(status, response)
+}
+
+// Drain makes readiness fail ahead of a shutdown, requests still being served
+func (h *HTTPHealthHandler) Drain() {
+	h.draining.Store(true)
+}
+
+// isVerbose reports whether the verbose query parameter is set, with no value or a true one
+func isVerbose(c *gin.Context) bool {
+	value, ok := c.GetQuery("verbose")
+	if !ok {
+		return false
+	}
+	verbose, err := strconv.ParseBool(value)
+	return value == "" || (err == nil && verbose)
+}
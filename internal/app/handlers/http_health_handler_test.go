@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+)
+
+func TestHTTPHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	healthy := &usecases.HealthResult{
+		Healthy: true,
+		Checks:  []usecases.HealthCheckResult{{Name: "database", Healthy: true, Duration: 1500 * time.Microsecond}},
+	}
+	unhealthy := &usecases.HealthResult{
+		Checks: []usecases.HealthCheckResult{
+			{Name: "database", Error: "connection refused", Duration: 2 * time.Second},
+			{Name: "pool", Healthy: true},
+		},
+	}
+
+	type setup func(t *testing.T, mockUsecase *mocks.HealthUsecase)
+
+	tests := []struct {
+		name         string
+		url          string
+		draining     bool
+		setup        setup
+		wantStatus   int
+		wantResponse string
+	}{
+		{
+			name:         "should return 200 on liveness without checking anything",
+			url:          "/livez",
+			wantStatus:   http.StatusOK,
+			wantResponse: `{"status":"ok"}`,
+		},
+		{
+			name: "should return 200 on readiness when the checks pass",
+			url:  "/readyz",
+			setup: func(t *testing.T, mockUsecase *mocks.HealthUsecase) {
+				mockUsecase.On("Check", mock.Anything).Return(healthy).Once()
+			},
+			wantStatus:   http.StatusOK,
+			wantResponse: `{"status":"ok"}`,
+		},
+		{
+			name: "should return 503 on readiness when a check fails",
+			url:  "/health",
+			setup: func(t *testing.T, mockUsecase *mocks.HealthUsecase) {
+				mockUsecase.On("Check", mock.Anything).Return(unhealthy).Once()
+			},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantResponse: `{"status":"unavailable"}`,
+		},
+		{
+			name:         "should return 503 on readiness once draining, without checking anything",
+			url:          "/readyz",
+			draining:     true,
+			wantStatus:   http.StatusServiceUnavailable,
+			wantResponse: `{"status":"draining"}`,
+		},
+		{
+			name: "should return the status and latency of every check when verbose",
+			url:  "/healthz?verbose",
+			setup: func(t *testing.T, mockUsecase *mocks.HealthUsecase) {
+				mockUsecase.On("Check", mock.Anything).Return(unhealthy).Once()
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			wantResponse: `{"status":"unavailable","checks":[
+				{"name":"database","status":"fail","error":"connection refused","duration_ms":2000},
+				{"name":"pool","status":"ok","duration_ms":0}
+			]}`,
+		},
+		{
+			name:     "should check the health even when draining",
+			url:      "/healthz?verbose=true",
+			draining: true,
+			setup: func(t *testing.T, mockUsecase *mocks.HealthUsecase) {
+				mockUsecase.On("Check", mock.Anything).Return(healthy).Once()
+			},
+			wantStatus:   http.StatusOK,
+			wantResponse: `{"status":"ok","checks":[{"name":"database","status":"ok","duration_ms":1.5}]}`,
+		},
+		{
+			name: "should not return the checks when verbose is false",
+			url:  "/healthz?verbose=false",
+			setup: func(t *testing.T, mockUsecase *mocks.HealthUsecase) {
+				mockUsecase.On("Check", mock.Anything).Return(healthy).Once()
+			},
+			wantStatus:   http.StatusOK,
+			wantResponse: `{"status":"ok"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewHealthUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpHealthHandler: NewHTTPHealthHandler(mockUsecase),
+			}
+			if tt.draining {
+				handler.Drain()
+			}
+			router := gin.New()
+			handler.registerHealthRoutes(router)
+
+			// Execute request
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.JSONEq(t, tt.wantResponse, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	idempotentReplayedValue  = "true"
+	// maxIdempotentRequestSize bounds the body of the requests carrying a key, buffered to be fingerprinted
+	maxIdempotentRequestSize = 10 << 20
+	// idempotencyInProgressRetryAfter is the Retry-After, in seconds, of a duplicate sent while the first request runs
+	idempotencyInProgressRetryAfter = "1"
+)
+
+// idempotentRequestSizes raises maxIdempotentRequestSize for the routes accepting larger bodies
+var idempotentRequestSizes = map[string]int64{
+	"/api/import": maxImportSize,
+}
+
+// HTTPIdempotencyHandler makes POST requests carrying an Idempotency-Key header safe to retry
+type HTTPIdempotencyHandler struct {
+	idempotencyUsecase usecases.IdempotencyUsecase
+}
+
+// NewHTTPIdempotencyHandler creates a new HTTPIdempotencyHandler instance
+func NewHTTPIdempotencyHandler(idempotencyUsecase usecases.IdempotencyUsecase) *HTTPIdempotencyHandler {
+	return &HTTPIdempotencyHandler{
+		idempotencyUsecase: idempotencyUsecase,
+	}
+}
+
+// Middleware runs POST requests once per Idempotency-Key and replays the stored response to retries
+// Requests without the header are passed through untouched, the others have their body buffered up to the limit of
+// their route
+func (h *HTTPIdempotencyHandler) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" {
+			c.Next()
+			return
+		}
+
+		limit, ok := idempotentRequestSizes[c.FullPath()]
+		if !ok {
+			limit = maxIdempotentRequestSize
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, limit))
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				respondWithError(c, http.StatusRequestEntityTooLarge, "request body too large")
+			} else {
+				respondWithError(c, http.StatusBadRequest, "failed to read request body")
+			}
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		handled := false
+		result, err := h.idempotencyUsecase.Execute(c.Request.Context(), usecases.IdempotentRequestParams{
+			Key:    key,
+			Method: c.Request.Method,
+			Path:   c.Request.URL.Path,
+			Body:   body,
+		}, func() usecases.IdempotentResponseResult {
+			handled = true
+
+			writer := &bodyCaptureWriter{ResponseWriter: c.Writer}
+			c.Writer = writer
+			c.Next()
+			c.Writer = writer.ResponseWriter
+
+			return usecases.IdempotentResponseResult{
+				StatusCode:  writer.Status(),
+				ContentType: writer.Header().Get("Content-Type"),
+				Body:        writer.body.Bytes(),
+			}
+		})
+
+		switch {
+		case handled:
+			// The response already went out, only the stored copy may be missing
+			if err != nil {
+				logger.FromContext(c.Request.Context()).Error().Err(err).Str("path", c.Request.URL.Path).Msg("Failed to store idempotent response")
+			}
+		case err != nil:
+			if errors.Is(err, usecases.ErrIdempotencyKeyInProgress) {
+				c.Header("Retry-After", idempotencyInProgressRetryAfter)
+			}
+			respondWithDomainError(c, err)
+			c.Abort()
+		default:
+			c.Header(idempotentReplayedHeader, idempotentReplayedValue)
+			c.Data(result.StatusCode, result.ContentType, result.Body)
+			c.Abort()
+		}
+	}
+}
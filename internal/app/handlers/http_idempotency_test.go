@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+)
+
+func TestHTTPIdempotencyHandler_Middleware(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"title":"Shopping"}`
+
+	type args struct {
+		method string
+		key    string
+	}
+
+	type setup func(t *testing.T, mockIdempotency *mocks.IdempotencyUsecase, mockTask *mocks.TaskUsecase)
+
+	tests := []struct {
+		name         string
+		args         args
+		setup        setup
+		wantStatus   int
+		wantReplayed bool
+	}{
+		{
+			name: "should pass requests without key through",
+			args: args{method: http.MethodPost},
+			setup: func(t *testing.T, mockIdempotency *mocks.IdempotencyUsecase, mockTask *mocks.TaskUsecase) {
+				mockTask.On("CreateTask", mock.Anything, mock.Anything).
+					Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "should ignore the key on other methods",
+			args: args{method: http.MethodGet, key: "key-1"},
+			setup: func(t *testing.T, mockIdempotency *mocks.IdempotencyUsecase, mockTask *mocks.TaskUsecase) {
+				mockTask.On("ListTasks", mock.Anything).
+					Return(&usecases.TaskListResult{Tasks: []usecases.TaskResult{}}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "should run the request through the usecase for a new key",
+			args: args{method: http.MethodPost, key: "key-1"},
+			setup: func(t *testing.T, mockIdempotency *mocks.IdempotencyUsecase, mockTask *mocks.TaskUsecase) {
+				mockTask.On("CreateTask", mock.Anything, mock.Anything).
+					Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+				mockIdempotency.On("Execute", mock.Anything, usecases.IdempotentRequestParams{
+					Key:    "key-1",
+					Method: http.MethodPost,
+					Path:   "/api/tasks",
+					Body:   []byte(body),
+				}, mock.Anything).Return(
+					func(_ context.Context, _ usecases.IdempotentRequestParams, handle func() usecases.IdempotentResponseResult) (*usecases.IdempotentResponseResult, error) {
+						response := handle()
+						assert.Equal(t, http.StatusCreated, response.StatusCode)
+						assert.Contains(t, string(response.Body), `"id":1`)
+						return &response, nil
+					}).Once()
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "should replay the stored response",
+			args: args{method: http.MethodPost, key: "key-1"},
+			setup: func(t *testing.T, mockIdempotency *mocks.IdempotencyUsecase, mockTask *mocks.TaskUsecase) {
+				mockIdempotency.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+					Return(&usecases.IdempotentResponseResult{
+						StatusCode:  http.StatusCreated,
+						ContentType: "application/json",
+						Body:        []byte(`{"id":1}`),
+						Replayed:    true,
+					}, nil).Once()
+			},
+			wantStatus:   http.StatusCreated,
+			wantReplayed: true,
+		},
+		{
+			name: "should return 422 when the key was used with a different request",
+			args: args{method: http.MethodPost, key: "key-1"},
+			setup: func(t *testing.T, mockIdempotency *mocks.IdempotencyUsecase, mockTask *mocks.TaskUsecase) {
+				mockIdempotency.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, usecases.ErrIdempotencyKeyReused).Once()
+			},
+			wantStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			name: "should return 409 while the first request with the key is in progress",
+			args: args{method: http.MethodPost, key: "key-1"},
+			setup: func(t *testing.T, mockIdempotency *mocks.IdempotencyUsecase, mockTask *mocks.TaskUsecase) {
+				mockIdempotency.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, usecases.ErrIdempotencyKeyInProgress).Once()
+			},
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name: "should return 500 when the key cannot be claimed",
+			args: args{method: http.MethodPost, key: "key-1"},
+			setup: func(t *testing.T, mockIdempotency *mocks.IdempotencyUsecase, mockTask *mocks.TaskUsecase) {
+				mockIdempotency.On("Execute", mock.Anything, mock.Anything, mock.Anything).
+					Return(nil, errors.New("database error")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockIdempotency := mocks.NewIdempotencyUsecase(t)
+			mockTask := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockIdempotency, mockTask)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler:        NewHTTPTaskHandler(mockTask),
+				httpIdempotencyHandler: NewHTTPIdempotencyHandler(mockIdempotency),
+			}
+			router := gin.Default()
+			api := router.Group("/api", handler.httpIdempotencyHandler.Middleware())
+			handler.registerTaskRoutes(api)
+
+			// Create request
+			req, err := http.NewRequestWithContext(context.Background(), tt.args.method, "/api/tasks", bytes.NewBufferString(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			if tt.args.key != "" {
+				req.Header.Set(idempotencyKeyHeader, tt.args.key)
+			}
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantReplayed, w.Header().Get(idempotentReplayedHeader) == idempotentReplayedValue)
+		})
+	}
+
+	t.Run("should reject a body over the limit before running the request", func(t *testing.T) {
+		t.Parallel()
+
+		handler := HTTPHandler{
+			httpTaskHandler:        NewHTTPTaskHandler(mocks.NewTaskUsecase(t)),
+			httpIdempotencyHandler: NewHTTPIdempotencyHandler(mocks.NewIdempotencyUsecase(t)),
+		}
+		router := gin.New()
+		api := router.Group("/api", handler.httpIdempotencyHandler.Middleware())
+		handler.registerTaskRoutes(api)
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/tasks",
+			bytes.NewReader(make([]byte, maxIdempotentRequestSize+1)))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(idempotencyKeyHeader, "key-1")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
@@ -1,5 +1,10 @@
 package handlers
 
+import (
+	"mime/multipart"
+	"time"
+)
+
 type createTaskItemHTTPRequest struct {
 	Title     string `json:"title" binding:"required"`
 	Completed bool   `json:"completed"`
@@ -8,5 +13,83 @@ type createTaskItemHTTPRequest struct {
 type createTaskHTTPRequest struct {
 	Title       string                      `json:"title" binding:"required"`
 	Description string                      `json:"description"`
+	DueAt       *time.Time                  `json:"due_at"`
 	Items       []createTaskItemHTTPRequest `json:"items"`
 }
+
+type updateTaskHTTPRequest struct {
+	Title       *string    `json:"title" binding:"omitempty,min=1"`
+	Description *string    `json:"description"`
+	DueAt       *time.Time `json:"due_at"`
+	ClearDueAt  bool       `json:"clear_due_at"`
+}
+
+type updateTaskItemHTTPRequest struct {
+	Title     *string `json:"title" binding:"omitempty,min=1"`
+	Completed *bool   `json:"completed"`
+}
+
+type listTasksHTTPQuery struct {
+	Limit  *int `form:"limit" binding:"omitempty,min=1,max=100"`
+	Offset int  `form:"offset" binding:"min=0"`
+}
+
+type setLogLevelHTTPRequest struct {
+	Level string `json:"level" binding:"required"`
+}
+
+type listSlowQueriesHTTPQuery struct {
+	Limit int `form:"limit" binding:"min=0,max=500"`
+}
+
+type batchOperationHTTPRequest struct {
+	Op          string                 `json:"op" binding:"required,oneof=create_task update_task toggle_item delete_task"`
+	Ref         string                 `json:"ref"`
+	TaskID      int64                  `json:"task_id"`
+	TaskRef     string                 `json:"task_ref"`
+	ItemID      int64                  `json:"item_id"`
+	Task        *createTaskHTTPRequest `json:"task"`
+	Title       *string                `json:"title"`
+	Description *string                `json:"description"`
+	Completed   *bool                  `json:"completed"`
+}
+
+type batchHTTPRequest struct {
+	Operations []batchOperationHTTPRequest `json:"operations" binding:"required,min=1,max=100,dive"`
+}
+
+type syncHTTPQuery struct {
+	Since string `form:"since"`
+}
+
+type syncMutationHTTPRequest struct {
+	Op          string    `json:"op" binding:"required,oneof=create_task update_task delete_task create_item update_item delete_item"`
+	Ref         string    `json:"ref"`
+	TaskID      int64     `json:"task_id"`
+	TaskRef     string    `json:"task_ref"`
+	ItemID      int64     `json:"item_id"`
+	ItemRef     string    `json:"item_ref"`
+	ModifiedAt  time.Time `json:"modified_at" binding:"required"`
+	Title       *string   `json:"title"`
+	Description *string   `json:"description"`
+	Completed   *bool     `json:"completed"`
+}
+
+type syncHTTPRequest struct {
+	Mutations []syncMutationHTTPRequest `json:"mutations" binding:"required,min=1,max=500,dive"`
+}
+
+type exportHTTPQuery struct {
+	Format string `form:"format"`
+}
+
+type importHTTPRequest struct {
+	File            *multipart.FileHeader `form:"file" binding:"required"`
+	Format          string                `form:"format"`
+	DryRun          bool                  `form:"dry_run"`
+	AllowDuplicates bool                  `form:"allow_duplicates"`
+}
+
+type createCalendarFeedHTTPRequest struct {
+	Name string `json:"name" binding:"max=255"`
+}
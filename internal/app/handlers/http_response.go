@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"database/sql"
 	"errors"
 	"net/http"
 	"strings"
@@ -8,6 +9,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/requestid"
 )
 
 type taskItemHTTPResponse struct {
@@ -23,19 +29,157 @@ type taskHTTPResponse struct {
 	ID          int64                  `json:"id"`
 	Title       string                 `json:"title"`
 	Description string                 `json:"description"`
+	DueAt       *time.Time             `json:"due_at,omitempty"`
 	CreatedAt   time.Time              `json:"created_at"`
 	UpdatedAt   time.Time              `json:"updated_at"`
 	Items       []taskItemHTTPResponse `json:"items"`
 }
 
 type taskListHTTPResponse struct {
-	Tasks []taskHTTPResponse `json:"tasks"`
+	Tasks      []taskHTTPResponse `json:"tasks"`
+	TotalCount *int               `json:"total_count,omitempty"`
+	NextOffset *int               `json:"next_offset,omitempty"`
+}
+
+type batchOperationHTTPResponse struct {
+	Index  int                   `json:"index"`
+	Op     string                `json:"op"`
+	Ref    string                `json:"ref,omitempty"`
+	TaskID int64                 `json:"task_id"`
+	Task   *taskHTTPResponse     `json:"task,omitempty"`
+	Item   *taskItemHTTPResponse `json:"item,omitempty"`
+}
+
+type batchHTTPResponse struct {
+	Results []batchOperationHTTPResponse `json:"results"`
+}
+
+type batchErrorHTTPResponse struct {
+	Error     string `json:"error"`
+	Index     int    `json:"index"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+type tombstoneHTTPResponse struct {
+	Type      string    `json:"type"`
+	ID        int64     `json:"id"`
+	TaskID    int64     `json:"task_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+type changesHTTPResponse struct {
+	Token   string                  `json:"token"`
+	Tasks   []taskHTTPResponse      `json:"tasks"`
+	Items   []taskItemHTTPResponse  `json:"items"`
+	Deleted []tombstoneHTTPResponse `json:"deleted"`
+}
+
+type syncMutationHTTPResponse struct {
+	Index   int    `json:"index"`
+	Op      string `json:"op"`
+	Ref     string `json:"ref,omitempty"`
+	TaskID  int64  `json:"task_id,omitempty"`
+	ItemID  int64  `json:"item_id,omitempty"`
+	Applied bool   `json:"applied"`
+}
+
+type syncConflictHTTPResponse struct {
+	Index            int        `json:"index"`
+	Field            string     `json:"field,omitempty"`
+	Reason           string     `json:"reason"`
+	ServerValue      any        `json:"server_value,omitempty"`
+	ServerModifiedAt *time.Time `json:"server_modified_at,omitempty"`
+}
+
+type syncHTTPResponse struct {
+	Results   []syncMutationHTTPResponse `json:"results"`
+	Conflicts []syncConflictHTTPResponse `json:"conflicts"`
+}
+
+type importedTaskHTTPResponse struct {
+	Title     string `json:"title"`
+	ItemCount int    `json:"item_count"`
+	Duplicate bool   `json:"duplicate"`
+	ID        int64  `json:"id,omitempty"`
+}
+
+type importRejectHTTPResponse struct {
+	Task   int    `json:"task"`
+	Item   int    `json:"item,omitempty"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+type importHTTPResponse struct {
+	DryRun         bool                       `json:"dry_run"`
+	CreatedCount   int                        `json:"created_count"`
+	DuplicateCount int                        `json:"duplicate_count"`
+	Tasks          []importedTaskHTTPResponse `json:"tasks"`
+	Rejects        []importRejectHTTPResponse `json:"rejects"`
+}
+
+type healthCheckHTTPResponse struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS float64 `json:"duration_ms"`
+}
+
+type healthHTTPResponse struct {
+	Status string                    `json:"status"`
+	Checks []healthCheckHTTPResponse `json:"checks,omitempty"`
+}
+
+type logLevelHTTPResponse struct {
+	Level string `json:"level"`
+}
+
+type poolStatsHTTPResponse struct {
+	AcquiredConns           int32   `json:"acquired_conns"`
+	IdleConns               int32   `json:"idle_conns"`
+	ConstructingConns       int32   `json:"constructing_conns"`
+	TotalConns              int32   `json:"total_conns"`
+	MaxConns                int32   `json:"max_conns"`
+	AcquireCount            int64   `json:"acquire_count"`
+	AcquireDurationMS       float64 `json:"acquire_duration_ms"`
+	EmptyAcquireCount       int64   `json:"empty_acquire_count"`
+	EmptyAcquireWaitTimeMS  float64 `json:"empty_acquire_wait_time_ms"`
+	CanceledAcquireCount    int64   `json:"canceled_acquire_count"`
+	NewConnsCount           int64   `json:"new_conns_count"`
+	MaxLifetimeDestroyCount int64   `json:"max_lifetime_destroy_count"`
+	MaxIdleDestroyCount     int64   `json:"max_idle_destroy_count"`
+}
+
+type slowQueryPlanHTTPResponse struct {
+	ID         int64     `json:"id"`
+	Query      string    `json:"query"`
+	Operation  string    `json:"operation"`
+	DurationMS float64   `json:"duration_ms"`
+	Plan       string    `json:"plan"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+type slowQueryPlanListHTTPResponse struct {
+	Plans []slowQueryPlanHTTPResponse `json:"plans"`
+}
+
+type calendarFeedHTTPResponse struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	Token     string    `json:"token,omitempty"`
+	URL       string    `json:"url,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type calendarFeedListHTTPResponse struct {
+	Feeds []calendarFeedHTTPResponse `json:"feeds"`
 }
 
 type validationErrorResponse map[string]string
 
 type errorResponse struct {
-	Error string `json:"error"`
+	Error     string `json:"error"`
+	RequestID string `json:"request_id,omitempty"` // To be quoted when reporting the error
 }
 
 func respondWithValidationError(c *gin.Context, err error) {
@@ -47,16 +191,104 @@ func respondWithValidationError(c *gin.Context, err error) {
 			jsonField := toJSONFieldName(field)
 			validationErrorResponse[jsonField] = getValidationErrorMessage(fe)
 		}
+		if id := requestid.FromContext(c.Request.Context()); id != "" {
+			validationErrorResponse["request_id"] = id
+		}
 		c.JSON(http.StatusBadRequest, validationErrorResponse)
 		return
 	}
 
 	// Fallback to generic error
-	c.JSON(http.StatusBadRequest, errorResponse{Error: err.Error()})
+	respondWithError(c, http.StatusBadRequest, err.Error())
 }
 
+// internalErrorMessage is returned in place of unexpected errors, such as database ones
+const internalErrorMessage = "internal server error"
+
 func respondWithError(c *gin.Context, statusCode int, message string) {
-	c.JSON(statusCode, errorResponse{Error: message})
+	c.JSON(statusCode, errorResponse{Error: message, RequestID: requestid.FromContext(c.Request.Context())})
+}
+
+// respondWithDomainError responds with the status code matching a usecase or repository error
+// Unexpected errors are logged, with the request ID returned to the client
+func respondWithDomainError(c *gin.Context, err error) {
+	status := domainErrorStatus(err)
+	if status >= http.StatusInternalServerError {
+		logger.FromContext(c.Request.Context()).Error().
+			Err(err).
+			Str("method", c.Request.Method).
+			Str("path", c.Request.URL.Path).
+			Msg("Request failed")
+	}
+
+	respondWithError(c, status, domainErrorMessage(err))
+}
+
+// respondWithBatchError responds with the index of the failed operation when err is a *usecases.BatchOperationError
+func respondWithBatchError(c *gin.Context, err error) {
+	var opErr *usecases.BatchOperationError
+	if errors.As(err, &opErr) {
+		c.JSON(domainErrorStatus(opErr.Err), batchErrorHTTPResponse{
+			Error:     domainErrorMessage(opErr.Err),
+			Index:     opErr.Index,
+			RequestID: requestid.FromContext(c.Request.Context()),
+		})
+		return
+	}
+
+	respondWithDomainError(c, err)
+}
+
+// domainErrorStatus maps usecase and repository errors to HTTP status codes
+func domainErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, db.ErrTaskNotFound),
+		errors.Is(err, db.ErrTaskItemNotFound),
+		errors.Is(err, db.ErrCalendarFeedNotFound),
+		errors.Is(err, usecases.ErrCalendarObjectNotFound),
+		errors.Is(err, sql.ErrNoRows):
+		return http.StatusNotFound
+	case errors.Is(err, usecases.ErrInvalidTaskID),
+		errors.Is(err, usecases.ErrInvalidTaskItemID),
+		errors.Is(err, usecases.ErrTaskTitleRequired),
+		errors.Is(err, usecases.ErrTaskItemTitleRequired),
+		errors.Is(err, usecases.ErrInvalidPagination),
+		errors.Is(err, usecases.ErrInvalidIdempotencyKey),
+		errors.Is(err, usecases.ErrEmptyBatch),
+		errors.Is(err, usecases.ErrBatchTooLarge),
+		errors.Is(err, usecases.ErrUnknownBatchOperation),
+		errors.Is(err, usecases.ErrUnknownBatchRef),
+		errors.Is(err, usecases.ErrDuplicateBatchRef),
+		errors.Is(err, usecases.ErrInvalidSyncToken),
+		errors.Is(err, usecases.ErrSyncModifiedAtRequired),
+		errors.Is(err, usecases.ErrUnknownTransferFormat),
+		errors.Is(err, usecases.ErrInvalidImportFile),
+		errors.Is(err, usecases.ErrInvalidCalendarFeedID),
+		errors.Is(err, usecases.ErrInvalidCalendarObject),
+		errors.Is(err, usecases.ErrInvalidLogLevel):
+		return http.StatusBadRequest
+	case errors.Is(err, usecases.ErrIdempotencyKeyReused):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, usecases.ErrIdempotencyKeyInProgress):
+		return http.StatusConflict
+	case errors.Is(err, usecases.ErrCalendarObjectPreconditionFailed):
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// domainErrorMessage hides driver errors that have a domain equivalent, and unexpected errors behind a generic
+// message, the details of which are logged
+func domainErrorMessage(err error) string {
+	if errors.Is(err, sql.ErrNoRows) {
+		return db.ErrTaskNotFound.Error()
+	}
+	if domainErrorStatus(err) >= http.StatusInternalServerError {
+		return internalErrorMessage
+	}
+
+	return err.Error()
 }
 
 func toJSONFieldName(field string) string {
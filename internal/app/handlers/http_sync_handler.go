@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+)
+
+// GetChanges handles GET /api/sync
+// Without a since token, every task and item is returned
+func (h *HTTPTaskHandler) GetChanges(c *gin.Context) {
+	var query syncHTTPQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// Call usecase
+	result, err := h.taskUsecase.GetChanges(c.Request.Context(), usecases.GetChangesParams{Since: query.Since})
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	// Map usecase result to HTTP response
+	response := changesHTTPResponse{
+		Token:   result.Token,
+		Tasks:   make([]taskHTTPResponse, 0, len(result.Tasks)),
+		Items:   make([]taskItemHTTPResponse, 0, len(result.Items)),
+		Deleted: make([]tombstoneHTTPResponse, 0, len(result.Deleted)),
+	}
+	for _, task := range result.Tasks {
+		response.Tasks = append(response.Tasks, *h.resultToResponse(&task))
+	}
+	for _, item := range result.Items {
+		response.Items = append(response.Items, *h.itemResultToResponse(&item))
+	}
+	for _, tombstone := range result.Deleted {
+		response.Deleted = append(response.Deleted, tombstoneHTTPResponse{
+			Type:      tombstone.Type,
+			ID:        tombstone.ID,
+			TaskID:    tombstone.TaskID,
+			DeletedAt: tombstone.DeletedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ApplyChanges handles POST /api/sync
+func (h *HTTPTaskHandler) ApplyChanges(c *gin.Context) {
+	var req syncHTTPRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// Call usecase
+	result, err := h.taskUsecase.ApplyChanges(c.Request.Context(), h.syncRequestToParams(req))
+	if err != nil {
+		respondWithBatchError(c, err)
+		return
+	}
+
+	// Map usecase result to HTTP response
+	response := syncHTTPResponse{
+		Results:   make([]syncMutationHTTPResponse, 0, len(result.Results)),
+		Conflicts: make([]syncConflictHTTPResponse, 0, len(result.Conflicts)),
+	}
+	for _, mutation := range result.Results {
+		response.Results = append(response.Results, syncMutationHTTPResponse{
+			Index:   mutation.Index,
+			Op:      string(mutation.Type),
+			Ref:     mutation.Ref,
+			TaskID:  mutation.TaskID,
+			ItemID:  mutation.ItemID,
+			Applied: mutation.Applied,
+		})
+	}
+	for _, conflict := range result.Conflicts {
+		response.Conflicts = append(response.Conflicts, syncConflictHTTPResponse{
+			Index:            conflict.Index,
+			Field:            conflict.Field,
+			Reason:           string(conflict.Reason),
+			ServerValue:      conflict.ServerValue,
+			ServerModifiedAt: conflict.ServerModifiedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// syncRequestToParams maps HTTP sync request to usecase params
+func (h *HTTPTaskHandler) syncRequestToParams(req syncHTTPRequest) usecases.ApplyChangesParams {
+	mutations := make([]usecases.SyncMutationParams, 0, len(req.Mutations))
+	for _, m := range req.Mutations {
+		mutations = append(mutations, usecases.SyncMutationParams{
+			Type:        usecases.SyncMutationType(m.Op),
+			Ref:         m.Ref,
+			TaskID:      m.TaskID,
+			TaskRef:     m.TaskRef,
+			ItemID:      m.ItemID,
+			ItemRef:     m.ItemRef,
+			ModifiedAt:  m.ModifiedAt,
+			Title:       m.Title,
+			Description: m.Description,
+			Completed:   m.Completed,
+		})
+	}
+
+	return usecases.ApplyChangesParams{Mutations: mutations}
+}
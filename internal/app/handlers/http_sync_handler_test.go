@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+)
+
+func TestHTTPTaskHandler_GetChanges(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name             string
+		url              string
+		setup            setup
+		wantStatus       int
+		wantResponseBody interface{}
+	}{
+		{
+			name: "should return 200 with the changes and the next token",
+			url:  "/api/sync?since=abc",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetChanges", mock.Anything, usecases.GetChangesParams{Since: "abc"}).
+					Return(&usecases.ChangesResult{
+						Token:   "def",
+						Tasks:   []usecases.TaskResult{{ID: 1, Title: "Shopping"}},
+						Items:   []usecases.TaskItemResult{{ID: 2, TaskID: 1, Title: "Buy milk"}},
+						Deleted: []usecases.TombstoneResult{{Type: "task", ID: 3, TaskID: 3, DeletedAt: deletedAt}},
+					}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantResponseBody: changesHTTPResponse{
+				Token:   "def",
+				Tasks:   []taskHTTPResponse{{ID: 1, Title: "Shopping", Items: []taskItemHTTPResponse{}}},
+				Items:   []taskItemHTTPResponse{{ID: 2, TaskID: 1, Title: "Buy milk"}},
+				Deleted: []tombstoneHTTPResponse{{Type: "task", ID: 3, TaskID: 3, DeletedAt: deletedAt}},
+			},
+		},
+		{
+			name: "should return 400 when the token is invalid",
+			url:  "/api/sync?since=garbage",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetChanges", mock.Anything, usecases.GetChangesParams{Since: "garbage"}).
+					Return(nil, usecases.ErrInvalidSyncToken).Once()
+			},
+			wantStatus:       http.StatusBadRequest,
+			wantResponseBody: errorResponse{Error: usecases.ErrInvalidSyncToken.Error()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerSyncRoutes(api)
+
+			// Create request
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			want, err := json.Marshal(tt.wantResponseBody)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), w.Body.String())
+		})
+	}
+}
+
+func TestHTTPTaskHandler_ApplyChanges(t *testing.T) {
+	t.Parallel()
+
+	modifiedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	serverModifiedAt := modifiedAt.Add(time.Hour)
+	title := "Groceries"
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name             string
+		requestBody      interface{}
+		setup            setup
+		wantStatus       int
+		wantResponseBody interface{}
+	}{
+		{
+			name: "should return 200 with the results and conflicts",
+			requestBody: map[string]interface{}{
+				"mutations": []map[string]interface{}{
+					{"op": "update_task", "task_id": 1, "modified_at": modifiedAt, "title": title},
+				},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ApplyChanges", mock.Anything, usecases.ApplyChangesParams{
+					Mutations: []usecases.SyncMutationParams{
+						{Type: usecases.SyncUpdateTask, TaskID: 1, ModifiedAt: modifiedAt, Title: &title},
+					},
+				}).Return(&usecases.ApplyChangesResult{
+					Results: []usecases.SyncMutationResult{
+						{Index: 0, Type: usecases.SyncUpdateTask, TaskID: 1},
+					},
+					Conflicts: []usecases.SyncConflictResult{
+						{Index: 0, Field: "title", Reason: usecases.SyncConflictStale, ServerValue: "Shopping", ServerModifiedAt: &serverModifiedAt},
+					},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantResponseBody: syncHTTPResponse{
+				Results: []syncMutationHTTPResponse{
+					{Index: 0, Op: "update_task", TaskID: 1},
+				},
+				Conflicts: []syncConflictHTTPResponse{
+					{Index: 0, Field: "title", Reason: "stale", ServerValue: "Shopping", ServerModifiedAt: &serverModifiedAt},
+				},
+			},
+		},
+		{
+			name: "should return the index of the failed mutation",
+			requestBody: map[string]interface{}{
+				"mutations": []map[string]interface{}{
+					{"op": "create_task", "modified_at": modifiedAt, "title": ""},
+				},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ApplyChanges", mock.Anything, mock.Anything).
+					Return(nil, &usecases.BatchOperationError{Index: 0, Err: usecases.ErrTaskTitleRequired}).Once()
+			},
+			wantStatus: http.StatusBadRequest,
+			wantResponseBody: batchErrorHTTPResponse{
+				Error: usecases.ErrTaskTitleRequired.Error(),
+				Index: 0,
+			},
+		},
+		{
+			name: "should return 400 when modified_at is missing",
+			requestBody: map[string]interface{}{
+				"mutations": []map[string]interface{}{
+					{"op": "delete_task", "task_id": 1},
+				},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerSyncRoutes(api)
+
+			// Create request
+			body, err := json.Marshal(tt.requestBody)
+			require.NoError(t, err)
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/sync", bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantResponseBody != nil {
+				want, err := json.Marshal(tt.wantResponseBody)
+				require.NoError(t, err)
+				assert.JSONEq(t, string(want), w.Body.String())
+			}
+		})
+	}
+}
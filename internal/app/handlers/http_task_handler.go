@@ -1,17 +1,20 @@
 package handlers
 
 import (
-	"database/sql"
-	"errors"
+	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 
-	"github.com/clevertechware/todo-bun-app/internal/app/db"
 	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/requestid"
 )
 
+// ndjsonMIMEType is the media type of newline-delimited JSON, requested with the Accept header to stream tasks
+const ndjsonMIMEType = "application/x-ndjson"
+
 // HTTPTaskHandler handles HTTP requests for tasks
 type HTTPTaskHandler struct {
 	taskUsecase usecases.TaskUsecase
@@ -39,7 +42,7 @@ func (h *HTTPTaskHandler) CreateTask(c *gin.Context) {
 	// Call usecase
 	result, err := h.taskUsecase.CreateTask(c.Request.Context(), params)
 	if err != nil {
-		respondWithError(c, http.StatusInternalServerError, err.Error())
+		respondWithDomainError(c, err)
 		return
 	}
 
@@ -61,11 +64,7 @@ func (h *HTTPTaskHandler) GetTask(c *gin.Context) {
 	// Call usecase
 	result, err := h.taskUsecase.GetTask(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			respondWithError(c, http.StatusNotFound, "task not found")
-			return
-		}
-		respondWithError(c, http.StatusInternalServerError, err.Error())
+		respondWithDomainError(c, err)
 		return
 	}
 
@@ -76,11 +75,28 @@ func (h *HTTPTaskHandler) GetTask(c *gin.Context) {
 }
 
 // ListTasks handles GET /api/tasks
+// Without limit or offset query parameters, all tasks are returned, streamed one per line when
+// application/x-ndjson is accepted
 func (h *HTTPTaskHandler) ListTasks(c *gin.Context) {
+	var query listTasksHTTPQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	if query.Limit != nil || query.Offset > 0 {
+		h.listTasksPage(c, query)
+		return
+	}
+	if c.NegotiateFormat(binding.MIMEJSON, ndjsonMIMEType) == ndjsonMIMEType {
+		h.streamTasks(c)
+		return
+	}
+
 	// Call usecase
 	result, err := h.taskUsecase.ListTasks(c.Request.Context())
 	if err != nil {
-		respondWithError(c, http.StatusInternalServerError, err.Error())
+		respondWithDomainError(c, err)
 		return
 	}
 
@@ -102,17 +118,185 @@ func (h *HTTPTaskHandler) DeleteTask(c *gin.Context) {
 	// Call usecase
 	err = h.taskUsecase.DeleteTask(c.Request.Context(), id)
 	if err != nil {
-		if errors.Is(err, db.ErrTaskNotFound) {
-			respondWithError(c, http.StatusNotFound, "task not found")
-			return
-		}
-		respondWithError(c, http.StatusInternalServerError, err.Error())
+		respondWithDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// UpdateTask handles PATCH /api/tasks/:id
+func (h *HTTPTaskHandler) UpdateTask(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "invalid task ID")
+		return
+	}
+
+	var req updateTaskHTTPRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// Call usecase
+	result, err := h.taskUsecase.UpdateTask(c.Request.Context(), id, usecases.UpdateTaskParams{
+		Title:       req.Title,
+		Description: req.Description,
+		DueAt:       req.DueAt,
+		ClearDueAt:  req.ClearDueAt,
+	})
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.resultToResponse(result))
+}
+
+// AddTaskItem handles POST /api/tasks/:id/items
+func (h *HTTPTaskHandler) AddTaskItem(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "invalid task ID")
+		return
+	}
+
+	var req createTaskItemHTTPRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// Call usecase
+	result, err := h.taskUsecase.AddTaskItem(c.Request.Context(), taskID, usecases.CreateTaskItemParams{
+		Title:     req.Title,
+		Completed: req.Completed,
+	})
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, h.itemResultToResponse(result))
+}
+
+// UpdateTaskItem handles PATCH /api/tasks/:id/items/:itemId
+func (h *HTTPTaskHandler) UpdateTaskItem(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "invalid task ID")
+		return
+	}
+	itemID, err := strconv.ParseInt(c.Param("itemId"), 10, 64)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "invalid task item ID")
+		return
+	}
+
+	var req updateTaskItemHTTPRequest
+	if err = c.ShouldBindJSON(&req); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	// Call usecase
+	result, err := h.taskUsecase.UpdateTaskItem(c.Request.Context(), taskID, itemID, usecases.UpdateTaskItemParams{
+		Title:     req.Title,
+		Completed: req.Completed,
+	})
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, h.itemResultToResponse(result))
+}
+
+// DeleteTaskItem handles DELETE /api/tasks/:id/items/:itemId
+func (h *HTTPTaskHandler) DeleteTaskItem(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "invalid task ID")
+		return
+	}
+	itemID, err := strconv.ParseInt(c.Param("itemId"), 10, 64)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "invalid task item ID")
+		return
+	}
+
+	// Call usecase
+	if err = h.taskUsecase.DeleteTaskItem(c.Request.Context(), taskID, itemID); err != nil {
+		respondWithDomainError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// listTasksPage serves a single page of tasks with their items
+func (h *HTTPTaskHandler) listTasksPage(c *gin.Context, query listTasksHTTPQuery) {
+	params := usecases.SearchTasksParams{
+		Offset:       query.Offset,
+		IncludeItems: true,
+	}
+	if query.Limit != nil {
+		params.Limit = *query.Limit
+	}
+
+	// Call usecase
+	result, err := h.taskUsecase.SearchTasks(c.Request.Context(), params)
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	// Map usecase result to HTTP response
+	response := h.listResultToResponse(&usecases.TaskListResult{Tasks: result.Tasks})
+	response.TotalCount = &result.TotalCount
+	if next := query.Offset + len(result.Tasks); len(result.Tasks) > 0 && next < result.TotalCount {
+		response.NextOffset = &next
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// streamTasks writes every task as a line of newline-delimited JSON, flushed as soon as it is read
+// An error occurring once the response has started can no longer change its status, so it ends the stream
+// with an error line instead; a client disconnecting cancels the request context and thus the cursor,
+// and one that stops reading fails the next write once its deadline passes
+func (h *HTTPTaskHandler) streamTasks(c *gin.Context) {
+	encoder := json.NewEncoder(c.Writer)
+	started := false
+
+	err := h.taskUsecase.StreamTasks(c.Request.Context(), func(task *usecases.TaskResult) error {
+		extendWriteDeadline(c)
+		if !started {
+			c.Header("Content-Type", ndjsonMIMEType)
+			c.Header("Cache-Control", "no-cache")
+			c.Header("X-Accel-Buffering", "no")
+			c.Status(http.StatusOK)
+			started = true
+		}
+
+		if err := encoder.Encode(h.resultToResponse(task)); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+
+	switch {
+	case !started && err != nil:
+		respondWithDomainError(c, err)
+	case !started:
+		c.Data(http.StatusOK, ndjsonMIMEType, nil)
+	case err != nil && c.Request.Context().Err() == nil:
+		_ = encoder.Encode(errorResponse{Error: domainErrorMessage(err), RequestID: requestid.FromContext(c.Request.Context())})
+	}
+}
+
 // requestToParams maps HTTP request to usecase params
 func (h *HTTPTaskHandler) requestToParams(req createTaskHTTPRequest) usecases.CreateTaskParams {
 	items := make([]usecases.CreateTaskItemParams, 0, len(req.Items))
@@ -126,6 +310,7 @@ func (h *HTTPTaskHandler) requestToParams(req createTaskHTTPRequest) usecases.Cr
 	return usecases.CreateTaskParams{
 		Title:       req.Title,
 		Description: req.Description,
+		DueAt:       req.DueAt,
 		Items:       items,
 	}
 }
@@ -134,26 +319,32 @@ func (h *HTTPTaskHandler) requestToParams(req createTaskHTTPRequest) usecases.Cr
 func (h *HTTPTaskHandler) resultToResponse(result *usecases.TaskResult) *taskHTTPResponse {
 	items := make([]taskItemHTTPResponse, 0, len(result.Items))
 	for _, item := range result.Items {
-		items = append(items, taskItemHTTPResponse{
-			ID:        item.ID,
-			TaskID:    item.TaskID,
-			Title:     item.Title,
-			Completed: item.Completed,
-			CreatedAt: item.CreatedAt,
-			UpdatedAt: item.UpdatedAt,
-		})
+		items = append(items, *h.itemResultToResponse(&item))
 	}
 
 	return &taskHTTPResponse{
 		ID:          result.ID,
 		Title:       result.Title,
 		Description: result.Description,
+		DueAt:       result.DueAt,
 		CreatedAt:   result.CreatedAt,
 		UpdatedAt:   result.UpdatedAt,
 		Items:       items,
 	}
 }
 
+// itemResultToResponse maps usecase item result to HTTP response
+func (h *HTTPTaskHandler) itemResultToResponse(item *usecases.TaskItemResult) *taskItemHTTPResponse {
+	return &taskItemHTTPResponse{
+		ID:        item.ID,
+		TaskID:    item.TaskID,
+		Title:     item.Title,
+		Completed: item.Completed,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
+
 // listResultToResponse maps usecase list result to HTTP response
 func (h *HTTPTaskHandler) listResultToResponse(result *usecases.TaskListResult) *taskListHTTPResponse {
 	tasks := make([]taskHTTPResponse, 0, len(result.Tasks))
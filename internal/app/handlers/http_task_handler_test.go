@@ -8,7 +8,9 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +20,7 @@ import (
 	"github.com/clevertechware/todo-bun-app/internal/app/db"
 	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
 	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/requestid"
 )
 
 func TestHTTPTaskHandler_CreateTask(t *testing.T) {
@@ -274,12 +277,99 @@ func TestHTTPTaskHandler_GetTask(t *testing.T) {
 	}
 }
 
+func TestHTTPTaskHandler_ErrorRequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		requestID string
+		wantID    func(t *testing.T, id string)
+	}{
+		{
+			name:      "should return the request ID sent by the client",
+			requestID: "req-42",
+			wantID: func(t *testing.T, id string) {
+				assert.Equal(t, "req-42", id)
+			},
+		},
+		{
+			name:      "should generate a request ID when the client sent none",
+			requestID: "",
+			wantID: func(t *testing.T, id string) {
+				assert.NotEmpty(t, id)
+			},
+		},
+		{
+			name:      "should replace a request ID with control characters",
+			requestID: "req-42\nforged",
+			wantID: func(t *testing.T, id string) {
+				assert.NotContains(t, id, "forged")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUsecase := mocks.NewTaskUsecase(t)
+			mockUsecase.On("GetTask", mock.Anything, int64(1)).
+				Return(nil, errors.New("connection refused")).Once()
+
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.New()
+			router.Use(requestid.GinMiddleware())
+			handler.registerTaskRoutes(router.Group("/api"))
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/api/tasks/1", nil)
+			require.NoError(t, err)
+			if tt.requestID != "" {
+				req.Header.Set(requestid.Header, tt.requestID)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusInternalServerError, w.Code)
+			id := w.Header().Get(requestid.Header)
+			tt.wantID(t, id)
+			assert.JSONEq(t, `{"error": "internal server error", "request_id": "`+id+`"}`, w.Body.String())
+		})
+	}
+
+	t.Run("should return the request ID with validation errors", func(t *testing.T) {
+		t.Parallel()
+
+		handler := HTTPHandler{
+			httpTaskHandler: NewHTTPTaskHandler(mocks.NewTaskUsecase(t)),
+		}
+		router := gin.New()
+		router.Use(requestid.GinMiddleware())
+		handler.registerTaskRoutes(router.Group("/api"))
+
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/tasks",
+			strings.NewReader(`{"description": "Missing title"}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(requestid.Header, "req-42")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.JSONEq(t, `{"title": "required", "request_id": "req-42"}`, w.Body.String())
+	})
+}
+
 func TestHTTPTaskHandler_ListTasks(t *testing.T) {
 	t.Parallel()
 
 	type args struct {
 		method string
 		url    string
+		accept string
 	}
 
 	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
@@ -289,6 +379,7 @@ func TestHTTPTaskHandler_ListTasks(t *testing.T) {
 		args       args
 		setup      setup
 		wantStatus int
+		wantBody   func(t *testing.T, body []byte)
 	}{
 		{
 			name: "should return 200 with list of tasks",
@@ -321,6 +412,137 @@ func TestHTTPTaskHandler_ListTasks(t *testing.T) {
 			},
 			wantStatus: http.StatusOK,
 		},
+		{
+			name: "should return a page of tasks with their items when limit is given",
+			args: args{
+				method: http.MethodGet,
+				url:    "/api/tasks?limit=1&offset=1",
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("SearchTasks", mock.Anything, usecases.SearchTasksParams{Limit: 1, Offset: 1, IncludeItems: true}).
+					Return(&usecases.TaskPageResult{
+						Tasks: []usecases.TaskResult{
+							{ID: 2, Title: "Work", Items: []usecases.TaskItemResult{{ID: 5, TaskID: 2, Title: "Review"}}},
+						},
+						TotalCount: 3,
+					}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func(t *testing.T, body []byte) {
+				var response taskListHTTPResponse
+				require.NoError(t, json.Unmarshal(body, &response))
+				require.Len(t, response.Tasks, 1)
+				assert.Len(t, response.Tasks[0].Items, 1)
+				require.NotNil(t, response.TotalCount)
+				assert.Equal(t, 3, *response.TotalCount)
+				require.NotNil(t, response.NextOffset)
+				assert.Equal(t, 2, *response.NextOffset)
+			},
+		},
+		{
+			name: "should omit next offset on the last page",
+			args: args{
+				method: http.MethodGet,
+				url:    "/api/tasks?offset=2",
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("SearchTasks", mock.Anything, usecases.SearchTasksParams{Offset: 2, IncludeItems: true}).
+					Return(&usecases.TaskPageResult{
+						Tasks:      []usecases.TaskResult{{ID: 1, Title: "Shopping", Items: []usecases.TaskItemResult{}}},
+						TotalCount: 3,
+					}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func(t *testing.T, body []byte) {
+				assert.NotContains(t, string(body), "next_offset")
+				assert.Contains(t, string(body), `"total_count":3`)
+			},
+		},
+		{
+			name: "should return 400 when limit is invalid",
+			args: args{
+				method: http.MethodGet,
+				url:    "/api/tasks?limit=0",
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should return 400 when limit exceeds the maximum",
+			args: args{
+				method: http.MethodGet,
+				url:    "/api/tasks?limit=1000",
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should stream one task per line when NDJSON is accepted",
+			args: args{
+				method: http.MethodGet,
+				url:    "/api/tasks",
+				accept: ndjsonMIMEType,
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("StreamTasks", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, fn func(*usecases.TaskResult) error) error {
+						if err := fn(&usecases.TaskResult{ID: 1, Title: "Shopping", Items: []usecases.TaskItemResult{{ID: 3, TaskID: 1, Title: "Buy milk"}}}); err != nil {
+							return err
+						}
+						return fn(&usecases.TaskResult{ID: 2, Title: "Work", Items: []usecases.TaskItemResult{}})
+					}).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func(t *testing.T, body []byte) {
+				lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+				require.Len(t, lines, 2)
+				var first taskHTTPResponse
+				require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+				assert.Equal(t, "Shopping", first.Title)
+				assert.Len(t, first.Items, 1)
+				assert.Contains(t, lines[1], `"title":"Work"`)
+			},
+		},
+		{
+			name: "should return 500 when streaming fails before the first task",
+			args: args{
+				method: http.MethodGet,
+				url:    "/api/tasks",
+				accept: ndjsonMIMEType,
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("StreamTasks", mock.Anything, mock.Anything).
+					Return(errors.New("internal error")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "should end the stream with an error line when streaming fails midway",
+			args: args{
+				method: http.MethodGet,
+				url:    "/api/tasks",
+				accept: ndjsonMIMEType,
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("StreamTasks", mock.Anything, mock.Anything).
+					Return(func(_ context.Context, fn func(*usecases.TaskResult) error) error {
+						if err := fn(&usecases.TaskResult{ID: 1, Title: "Shopping", Items: []usecases.TaskItemResult{}}); err != nil {
+							return err
+						}
+						return errors.New("connection lost")
+					}).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantBody: func(t *testing.T, body []byte) {
+				lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+				require.Len(t, lines, 2)
+				assert.Contains(t, lines[1], `"error"`)
+			},
+		},
 		{
 			name: "should return 500 when usecase returns error",
 			args: args{
@@ -356,6 +578,9 @@ func TestHTTPTaskHandler_ListTasks(t *testing.T) {
 			// Create request
 			req, err := http.NewRequestWithContext(context.Background(), tt.args.method, tt.args.url, nil)
 			require.NoError(t, err)
+			if tt.args.accept != "" {
+				req.Header.Set("Accept", tt.args.accept)
+			}
 
 			// Execute request
 			w := httptest.NewRecorder()
@@ -363,6 +588,9 @@ func TestHTTPTaskHandler_ListTasks(t *testing.T) {
 
 			// Assert
 			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantBody != nil {
+				tt.wantBody(t, w.Body.Bytes())
+			}
 		})
 	}
 }
@@ -463,3 +691,434 @@ func TestHTTPTaskHandler_DeleteTask(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPTaskHandler_UpdateTask(t *testing.T) {
+	t.Parallel()
+
+	title := "Groceries"
+	dueAt := time.Date(2025, 4, 15, 18, 0, 0, 0, time.UTC)
+
+	type args struct {
+		url         string
+		requestBody interface{}
+	}
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name       string
+		args       args
+		setup      setup
+		wantStatus int
+	}{
+		{
+			name: "should return 200 when task is renamed",
+			args: args{
+				url:         "/api/tasks/1",
+				requestBody: map[string]interface{}{"title": title},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("UpdateTask", mock.Anything, int64(1), usecases.UpdateTaskParams{Title: &title}).
+					Return(&usecases.TaskResult{ID: 1, Title: title}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "should return 200 when the due date is set",
+			args: args{
+				url:         "/api/tasks/1",
+				requestBody: map[string]interface{}{"due_at": "2025-04-15T18:00:00Z"},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("UpdateTask", mock.Anything, int64(1), usecases.UpdateTaskParams{DueAt: &dueAt}).
+					Return(&usecases.TaskResult{ID: 1, Title: title, DueAt: &dueAt}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "should return 200 when the due date is cleared",
+			args: args{
+				url:         "/api/tasks/1",
+				requestBody: map[string]interface{}{"clear_due_at": true},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("UpdateTask", mock.Anything, int64(1), usecases.UpdateTaskParams{ClearDueAt: true}).
+					Return(&usecases.TaskResult{ID: 1, Title: title}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "should return 400 when task ID is invalid",
+			args: args{
+				url:         "/api/tasks/invalid",
+				requestBody: map[string]interface{}{"title": title},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should return 400 when title is empty",
+			args: args{
+				url:         "/api/tasks/1",
+				requestBody: map[string]interface{}{"title": ""},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should return 404 when task is not found",
+			args: args{
+				url:         "/api/tasks/999",
+				requestBody: map[string]interface{}{"title": title},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("UpdateTask", mock.Anything, int64(999), usecases.UpdateTaskParams{Title: &title}).
+					Return(nil, sql.ErrNoRows).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerTaskRoutes(api)
+
+			// Create request
+			body, err := json.Marshal(tt.args.requestBody)
+			require.NoError(t, err)
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, tt.args.url, bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHTTPTaskHandler_AddTaskItem(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		url         string
+		requestBody interface{}
+	}
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name       string
+		args       args
+		setup      setup
+		wantStatus int
+	}{
+		{
+			name: "should return 201 when item is added successfully",
+			args: args{
+				url:         "/api/tasks/1/items",
+				requestBody: map[string]interface{}{"title": "Milk", "completed": true},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("AddTaskItem", mock.Anything, int64(1), usecases.CreateTaskItemParams{Title: "Milk", Completed: true}).
+					Return(&usecases.TaskItemResult{ID: 10, TaskID: 1, Title: "Milk", Completed: true}, nil).Once()
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "should return 400 when task ID is invalid",
+			args: args{
+				url:         "/api/tasks/invalid/items",
+				requestBody: map[string]interface{}{"title": "Milk"},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should return 400 when title is missing",
+			args: args{
+				url:         "/api/tasks/1/items",
+				requestBody: map[string]interface{}{"completed": true},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should return 404 when task is not found",
+			args: args{
+				url:         "/api/tasks/999/items",
+				requestBody: map[string]interface{}{"title": "Milk"},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("AddTaskItem", mock.Anything, int64(999), usecases.CreateTaskItemParams{Title: "Milk"}).
+					Return(nil, db.ErrTaskNotFound).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "should return 500 when usecase returns error",
+			args: args{
+				url:         "/api/tasks/1/items",
+				requestBody: map[string]interface{}{"title": "Milk"},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("AddTaskItem", mock.Anything, int64(1), usecases.CreateTaskItemParams{Title: "Milk"}).
+					Return(nil, errors.New("internal error")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerTaskRoutes(api)
+
+			// Create request
+			body, err := json.Marshal(tt.args.requestBody)
+			require.NoError(t, err)
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, tt.args.url, bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHTTPTaskHandler_UpdateTaskItem(t *testing.T) {
+	t.Parallel()
+
+	title := "Oat milk"
+	completed := true
+
+	type args struct {
+		url         string
+		requestBody interface{}
+	}
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name       string
+		args       args
+		setup      setup
+		wantStatus int
+	}{
+		{
+			name: "should return 200 when item is toggled",
+			args: args{
+				url:         "/api/tasks/1/items/10",
+				requestBody: map[string]interface{}{"completed": true},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("UpdateTaskItem", mock.Anything, int64(1), int64(10), usecases.UpdateTaskItemParams{Completed: &completed}).
+					Return(&usecases.TaskItemResult{ID: 10, TaskID: 1, Title: "Milk", Completed: true}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "should return 200 when item is renamed",
+			args: args{
+				url:         "/api/tasks/1/items/10",
+				requestBody: map[string]interface{}{"title": title},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("UpdateTaskItem", mock.Anything, int64(1), int64(10), usecases.UpdateTaskItemParams{Title: &title}).
+					Return(&usecases.TaskItemResult{ID: 10, TaskID: 1, Title: title}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "should return 400 when item ID is invalid",
+			args: args{
+				url:         "/api/tasks/1/items/invalid",
+				requestBody: map[string]interface{}{"completed": true},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should return 400 when title is empty",
+			args: args{
+				url:         "/api/tasks/1/items/10",
+				requestBody: map[string]interface{}{"title": ""},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should return 404 when item is not found",
+			args: args{
+				url:         "/api/tasks/1/items/999",
+				requestBody: map[string]interface{}{"completed": true},
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("UpdateTaskItem", mock.Anything, int64(1), int64(999), usecases.UpdateTaskItemParams{Completed: &completed}).
+					Return(nil, db.ErrTaskItemNotFound).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerTaskRoutes(api)
+
+			// Create request
+			body, err := json.Marshal(tt.args.requestBody)
+			require.NoError(t, err)
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPatch, tt.args.url, bytes.NewBuffer(body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+func TestHTTPTaskHandler_DeleteTaskItem(t *testing.T) {
+	t.Parallel()
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name       string
+		url        string
+		setup      setup
+		wantStatus int
+	}{
+		{
+			name: "should return 204 when item is deleted successfully",
+			url:  "/api/tasks/1/items/10",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("DeleteTaskItem", mock.Anything, int64(1), int64(10)).
+					Return(nil).Once()
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name: "should return 400 when task ID is invalid",
+			url:  "/api/tasks/invalid/items/10",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should return 404 when item is not found",
+			url:  "/api/tasks/1/items/999",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("DeleteTaskItem", mock.Anything, int64(1), int64(999)).
+					Return(db.ErrTaskItemNotFound).Once()
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "should return 500 when usecase returns error",
+			url:  "/api/tasks/1/items/10",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("DeleteTaskItem", mock.Anything, int64(1), int64(10)).
+					Return(errors.New("internal error")).Once()
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerTaskRoutes(api)
+
+			// Create request
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodDelete, tt.url, nil)
+			require.NoError(t, err)
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
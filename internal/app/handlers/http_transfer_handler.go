@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+)
+
+const (
+	// maxImportSize bounds the size of an import request, file included
+	maxImportSize = 100 << 20
+	// bulkImportSize is the file size from which the import goes through PostgreSQL COPY
+	bulkImportSize = 1 << 20
+)
+
+// ExportTasks handles GET /api/export
+// The format defaults to JSON
+func (h *HTTPTaskHandler) ExportTasks(c *gin.Context) {
+	var query exportHTTPQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		respondWithValidationError(c, err)
+		return
+	}
+
+	format := usecases.TransferJSON
+	if query.Format != "" {
+		var err error
+		if format, err = usecases.ParseTransferFormat(query.Format); err != nil {
+			respondWithDomainError(c, err)
+			return
+		}
+	}
+
+	// Call usecase, buffering the file so that a failure can still be reported as JSON
+	var buf bytes.Buffer
+	if err := h.taskUsecase.ExportTasks(c.Request.Context(), format, &buf); err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="tasks%s"`, format.Extension()))
+	c.Data(http.StatusOK, format.ContentType(), buf.Bytes())
+}
+
+// ImportTasks handles POST /api/import
+// Without a format, it is guessed from the extension of the uploaded file. Large files are bulk imported,
+// invalid tasks and items being reported as rejects instead of failing the whole import
+func (h *HTTPTaskHandler) ImportTasks(c *gin.Context) {
+	var req importHTTPRequest
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxImportSize)
+	if err := c.ShouldBind(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondWithError(c, http.StatusRequestEntityTooLarge, "import file too large")
+			return
+		}
+		respondWithValidationError(c, err)
+		return
+	}
+
+	var (
+		format usecases.TransferFormat
+		err    error
+	)
+	if req.Format != "" {
+		format, err = usecases.ParseTransferFormat(req.Format)
+	} else {
+		format, err = usecases.TransferFormatFromFilename(req.File.Filename)
+	}
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	file, err := req.File.Open()
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, "failed to read import file")
+		return
+	}
+	defer file.Close()
+
+	// Call usecase
+	result, err := h.taskUsecase.ImportTasks(c.Request.Context(), usecases.ImportTasksParams{
+		Format:          format,
+		Data:            file,
+		DryRun:          req.DryRun,
+		AllowDuplicates: req.AllowDuplicates,
+		Bulk:            req.File.Size >= bulkImportSize,
+	})
+	if err != nil {
+		respondWithDomainError(c, err)
+		return
+	}
+
+	// Map usecase result to HTTP response
+	response := importHTTPResponse{
+		DryRun:         result.DryRun,
+		CreatedCount:   result.CreatedCount,
+		DuplicateCount: result.DuplicateCount,
+		Tasks:          make([]importedTaskHTTPResponse, 0, len(result.Tasks)),
+		Rejects:        make([]importRejectHTTPResponse, 0, len(result.Rejects)),
+	}
+	for _, task := range result.Tasks {
+		response.Tasks = append(response.Tasks, importedTaskHTTPResponse{
+			Title:     task.Title,
+			ItemCount: task.ItemCount,
+			Duplicate: task.Duplicate,
+			ID:        task.ID,
+		})
+	}
+	for _, reject := range result.Rejects {
+		response.Rejects = append(response.Rejects, importRejectHTTPResponse{
+			Task:   reject.Task,
+			Item:   reject.Item,
+			Title:  reject.Title,
+			Reason: reject.Reason,
+		})
+	}
+
+	status := http.StatusCreated
+	if result.DryRun {
+		status = http.StatusOK
+	}
+	c.JSON(status, response)
+}
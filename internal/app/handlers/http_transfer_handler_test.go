@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+)
+
+func TestHTTPTaskHandler_ExportTasks(t *testing.T) {
+	t.Parallel()
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name            string
+		url             string
+		setup           setup
+		wantStatus      int
+		wantContentType string
+		wantDisposition string
+		wantBody        string
+	}{
+		{
+			name: "should return 200 with a JSON export by default",
+			url:  "/api/export",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ExportTasks", mock.Anything, usecases.TransferJSON, mock.Anything).
+					Run(func(args mock.Arguments) {
+						_, _ = io.WriteString(args.Get(2).(io.Writer), `{"version":1}`)
+					}).Return(nil).Once()
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/json",
+			wantDisposition: `attachment; filename="tasks.json"`,
+			wantBody:        `{"version":1}`,
+		},
+		{
+			name: "should return 200 with a Markdown export",
+			url:  "/api/export?format=markdown",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ExportTasks", mock.Anything, usecases.TransferMarkdown, mock.Anything).
+					Run(func(args mock.Arguments) {
+						_, _ = io.WriteString(args.Get(2).(io.Writer), "## Shopping\n")
+					}).Return(nil).Once()
+			},
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/markdown; charset=utf-8",
+			wantDisposition: `attachment; filename="tasks.md"`,
+			wantBody:        "## Shopping\n",
+		},
+		{
+			name:            "should return 400 when the format is unknown",
+			url:             "/api/export?format=xml",
+			wantStatus:      http.StatusBadRequest,
+			wantContentType: "application/json; charset=utf-8",
+			wantBody:        `{"error":"unknown format: \"xml\""}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerTransferRoutes(api)
+
+			// Create request
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantDisposition, w.Header().Get("Content-Disposition"))
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestHTTPTaskHandler_ImportTasks(t *testing.T) {
+	t.Parallel()
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name             string
+		filename         string
+		content          string
+		fields           map[string]string
+		setup            setup
+		wantStatus       int
+		wantResponseBody interface{}
+	}{
+		{
+			name:     "should return 201 with the created tasks, guessing the format from the file name",
+			filename: "tasks.csv",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ImportTasks", mock.Anything, mock.MatchedBy(func(params usecases.ImportTasksParams) bool {
+					return params.Format == usecases.TransferCSV && !params.DryRun && !params.AllowDuplicates && !params.Bulk
+				})).Return(&usecases.ImportResult{
+					Tasks: []usecases.ImportedTaskResult{
+						{Title: "Shopping", ItemCount: 2, ID: 1},
+						{Title: "Work", Duplicate: true},
+					},
+					CreatedCount:   1,
+					DuplicateCount: 1,
+				}, nil).Once()
+			},
+			wantStatus: http.StatusCreated,
+			wantResponseBody: importHTTPResponse{
+				Tasks: []importedTaskHTTPResponse{
+					{Title: "Shopping", ItemCount: 2, ID: 1},
+					{Title: "Work", Duplicate: true},
+				},
+				Rejects:        []importRejectHTTPResponse{},
+				CreatedCount:   1,
+				DuplicateCount: 1,
+			},
+		},
+		{
+			name:     "should bulk import a large file and return its rejects",
+			filename: "tasks.md",
+			content:  "## Shopping\n" + strings.Repeat("- [ ] Buy milk\n", bulkImportSize/15+1),
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ImportTasks", mock.Anything, mock.MatchedBy(func(params usecases.ImportTasksParams) bool {
+					return params.Bulk
+				})).Return(&usecases.ImportResult{
+					Tasks:        []usecases.ImportedTaskResult{{Title: "Shopping", ItemCount: 2, ID: 1}},
+					Rejects:      []usecases.ImportRejectResult{{Task: 1, Item: 2, Title: "", Reason: "title is required"}},
+					CreatedCount: 1,
+				}, nil).Once()
+			},
+			wantStatus: http.StatusCreated,
+			wantResponseBody: importHTTPResponse{
+				Tasks:        []importedTaskHTTPResponse{{Title: "Shopping", ItemCount: 2, ID: 1}},
+				Rejects:      []importRejectHTTPResponse{{Task: 1, Item: 2, Reason: "title is required"}},
+				CreatedCount: 1,
+			},
+		},
+		{
+			name:     "should return 200 with the preview of a dry run",
+			filename: "export",
+			fields:   map[string]string{"format": "todotxt", "dry_run": "true", "allow_duplicates": "true"},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ImportTasks", mock.Anything, mock.MatchedBy(func(params usecases.ImportTasksParams) bool {
+					return params.Format == usecases.TransferTodoTxt && params.DryRun && params.AllowDuplicates
+				})).Return(&usecases.ImportResult{
+					DryRun: true,
+					Tasks:  []usecases.ImportedTaskResult{{Title: "Inbox", ItemCount: 3}},
+				}, nil).Once()
+			},
+			wantStatus: http.StatusOK,
+			wantResponseBody: importHTTPResponse{
+				DryRun:  true,
+				Tasks:   []importedTaskHTTPResponse{{Title: "Inbox", ItemCount: 3}},
+				Rejects: []importRejectHTTPResponse{},
+			},
+		},
+		{
+			name:             "should return 400 when the format cannot be guessed",
+			filename:         "tasks.xml",
+			wantStatus:       http.StatusBadRequest,
+			wantResponseBody: errorResponse{Error: `unknown format: cannot guess the format of "tasks.xml"`},
+		},
+		{
+			name:     "should return 400 when the file is invalid",
+			filename: "tasks.md",
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("ImportTasks", mock.Anything, mock.Anything).
+					Return(nil, usecases.ErrInvalidImportFile).Once()
+			},
+			wantStatus:       http.StatusBadRequest,
+			wantResponseBody: errorResponse{Error: usecases.ErrInvalidImportFile.Error()},
+		},
+		{
+			name:             "should return 400 when the file is missing",
+			fields:           map[string]string{"format": "json"},
+			wantStatus:       http.StatusBadRequest,
+			wantResponseBody: map[string]string{"file": "required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := HTTPHandler{
+				httpTaskHandler: NewHTTPTaskHandler(mockUsecase),
+			}
+			router := gin.Default()
+			api := router.Group("/api")
+			handler.registerTransferRoutes(api)
+
+			// Create request
+			var body bytes.Buffer
+			form := multipart.NewWriter(&body)
+			for name, value := range tt.fields {
+				require.NoError(t, form.WriteField(name, value))
+			}
+			if tt.filename != "" {
+				part, err := form.CreateFormFile("file", tt.filename)
+				require.NoError(t, err)
+				content := tt.content
+				if content == "" {
+					content = "## Shopping\n"
+				}
+				_, err = io.WriteString(part, content)
+				require.NoError(t, err)
+			}
+			require.NoError(t, form.Close())
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "/api/import", &body)
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", form.FormDataContentType())
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			want, err := json.Marshal(tt.wantResponseBody)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(want), w.Body.String())
+		})
+	}
+}
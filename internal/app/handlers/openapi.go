@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files/v2"
+
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+)
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// swaggerInitializer points the bundled Swagger UI to our own document
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/openapi.json",
+    dom_id: '#swagger-ui',
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// OpenAPIHandler serves the OpenAPI document, the Swagger UI and validates traffic against the document
+type OpenAPIHandler struct {
+	spec     *openapi3.T
+	specJSON []byte
+	router   routers.Router
+}
+
+// NewOpenAPIHandler loads and validates the embedded OpenAPI document
+func NewOpenAPIHandler() (*OpenAPIHandler, error) {
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromData(openAPISpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+
+	if err = spec.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+
+	specJSON, err := spec.MarshalJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode OpenAPI spec: %w", err)
+	}
+
+	router, err := legacy.NewRouter(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build OpenAPI router: %w", err)
+	}
+
+	return &OpenAPIHandler{
+		spec:     spec,
+		specJSON: specJSON,
+		router:   router,
+	}, nil
+}
+
+// Spec handles GET /openapi.json
+func (h *OpenAPIHandler) Spec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", h.specJSON)
+}
+
+// SwaggerUI handles GET /swagger/*filepath with the bundled Swagger UI assets
+func (h *OpenAPIHandler) SwaggerUI(c *gin.Context) {
+	file := strings.TrimPrefix(c.Param("filepath"), "/")
+
+	switch file {
+	case "":
+		c.Redirect(http.StatusMovedPermanently, "/swagger/index.html")
+	case "swagger-initializer.js":
+		c.Data(http.StatusOK, "application/javascript", []byte(swaggerInitializer))
+	default:
+		data, err := fs.ReadFile(swaggerFiles.FS, file)
+		if err != nil {
+			respondWithError(c, http.StatusNotFound, "file not found")
+			return
+		}
+		c.Data(http.StatusOK, mime.TypeByExtension(path.Ext(file)), data)
+	}
+}
+
+// ValidationMiddleware validates requests and responses against the OpenAPI document
+// Invalid requests are rejected with 400, invalid responses are logged
+// Routes missing from the document are not validated
+func (h *OpenAPIHandler) ValidationMiddleware() gin.HandlerFunc {
+	options := &openapi3filter.Options{
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		IncludeResponseStatus: true,
+		MultiError:            true,
+	}
+
+	return func(c *gin.Context) {
+		route, pathParams, err := h.router.FindRoute(c.Request)
+		if err != nil {
+			c.Next()
+			return
+		}
+
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		if err = openapi3filter.ValidateRequest(c.Request.Context(), requestInput); err != nil {
+			respondWithError(c, http.StatusBadRequest, err.Error())
+			c.Abort()
+			return
+		}
+
+		writer := &bodyCaptureWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		c.Next()
+
+		// Streamed responses cannot be validated as a whole
+		if writer.streaming() {
+			return
+		}
+
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 writer.Status(),
+			Header:                 writer.Header(),
+			Options:                options,
+		}
+		responseInput.SetBodyBytes(writer.body.Bytes())
+
+		if err = openapi3filter.ValidateResponse(c.Request.Context(), responseInput); err != nil {
+			logger.FromContext(c.Request.Context()).Warn().
+				Err(err).
+				Str("method", c.Request.Method).
+				Str("path", c.Request.URL.Path).
+				Int("status", writer.Status()).
+				Msg("Response does not match the OpenAPI spec")
+		}
+	}
+}
+
+// Operations lists the "METHOD /path" pairs documented in the OpenAPI document, using gin path syntax
+func (h *OpenAPIHandler) Operations() []string {
+	var operations []string
+	for path, item := range h.spec.Paths.Map() {
+		ginPath := strings.NewReplacer("{", ":", "}", "").Replace(path)
+		for method := range item.Operations() {
+			operations = append(operations, method+" "+ginPath)
+		}
+	}
+
+	return operations
+}
+
+// bodyCaptureWriter keeps a copy of the response body for validation, except for streamed responses
+type bodyCaptureWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyCaptureWriter) Write(b []byte) (int, error) {
+	if !w.streaming() {
+		w.body.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyCaptureWriter) WriteString(s string) (int, error) {
+	if !w.streaming() {
+		w.body.WriteString(s)
+	}
+	return w.ResponseWriter.WriteString(s)
+}
+
+// streaming reports whether the response is a stream of events or of JSON lines
+// Unwrap lets http.ResponseController reach the connection, e.g. to lift the write deadline of a stream
+func (w *bodyCaptureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *bodyCaptureWriter) streaming() bool {
+	contentType := w.Header().Get("Content-Type")
+	return strings.HasPrefix(contentType, "text/event-stream") || strings.HasPrefix(contentType, ndjsonMIMEType)
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+)
+
+// undocumentedRoutes are registered routes that cannot be described in the OpenAPI document
+var undocumentedRoutes = map[string]bool{
+	"GET /swagger/*filepath":       true,
+	"GET /.well-known/caldav":      true,
+	"PROPFIND /.well-known/caldav": true,
+}
+
+func init() {
+	// CalDAV uses WebDAV methods and XML bodies
+	for _, method := range caldavMethods {
+		undocumentedRoutes[method+" "+caldavPrefix+"/*path"] = true
+	}
+}
+
+func newTestHTTPHandler(t *testing.T, mockUsecase *mocks.TaskUsecase) *HTTPHandler {
+	t.Helper()
+
+	openAPIHandler, err := NewOpenAPIHandler()
+	require.NoError(t, err)
+
+	return NewHTTPHandler(
+		NewHTTPTaskHandler(mockUsecase),
+		nil,
+		nil,
+		nil,
+		NewGraphQLHandler(mockUsecase),
+		openAPIHandler,
+		nil,
+	)
+}
+
+func TestOpenAPIHandler_SpecMatchesRoutes(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHTTPHandler(t, mocks.NewTaskUsecase(t))
+	router := gin.New()
+	handler.RegisterRoutes(router)
+
+	var registered []string
+	for _, route := range router.Routes() {
+		operation := route.Method + " " + route.Path
+		if !undocumentedRoutes[operation] {
+			registered = append(registered, operation)
+		}
+	}
+
+	assert.ElementsMatch(t, registered, handler.openAPIHandler.Operations(),
+		"routes registered in HTTPHandler.RegisterRoutes and openapi.yaml have drifted")
+}
+
+func TestOpenAPIHandler_Spec(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHTTPHandler(t, mocks.NewTaskUsecase(t))
+	router := gin.New()
+	handler.RegisterRoutes(router)
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "should serve the OpenAPI document",
+			url:        "/openapi.json",
+			wantStatus: http.StatusOK,
+			wantBody:   `"openapi":"3.1.0"`,
+		},
+		{
+			name:       "should serve the Swagger UI",
+			url:        "/swagger/index.html",
+			wantStatus: http.StatusOK,
+			wantBody:   "swagger-ui",
+		},
+		{
+			name:       "should point the Swagger UI to the OpenAPI document",
+			url:        "/swagger/swagger-initializer.js",
+			wantStatus: http.StatusOK,
+			wantBody:   "/openapi.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+			require.NoError(t, err)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.Contains(t, w.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestOpenAPIHandler_ValidationMiddleware(t *testing.T) {
+	t.Parallel()
+
+	type args struct {
+		method string
+		url    string
+		body   string
+	}
+
+	type setup func(t *testing.T, mockUsecase *mocks.TaskUsecase)
+
+	tests := []struct {
+		name       string
+		args       args
+		setup      setup
+		wantStatus int
+	}{
+		{
+			name: "should pass valid request through",
+			args: args{
+				method: http.MethodPost,
+				url:    "/api/tasks",
+				body:   `{"title": "Shopping", "items": [{"title": "Buy milk"}]}`,
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("CreateTask", mock.Anything, mock.Anything).
+					Return(&usecases.TaskResult{ID: 1, Title: "Shopping", Items: []usecases.TaskItemResult{}}, nil).Once()
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "should reject request body not matching the spec",
+			args: args{
+				method: http.MethodPost,
+				url:    "/api/tasks",
+				body:   `{"title": 42}`,
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "should reject path parameter not matching the spec",
+			args: args{
+				method: http.MethodGet,
+				url:    "/api/tasks/abc",
+			},
+			setup: func(t *testing.T, mockUsecase *mocks.TaskUsecase) {
+				// Usecase should not be called
+			},
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// Setup mocks
+			mockUsecase := mocks.NewTaskUsecase(t)
+			if tt.setup != nil {
+				tt.setup(t, mockUsecase)
+			}
+
+			// Setup handler and router
+			handler := newTestHTTPHandler(t, mockUsecase)
+			router := gin.New()
+			router.Use(handler.OpenAPIValidationMiddleware())
+			handler.RegisterRoutes(router)
+
+			// Create request
+			req, err := http.NewRequestWithContext(context.Background(), tt.args.method, tt.args.url, strings.NewReader(tt.args.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			// Execute request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Assert
+			assert.Equal(t, tt.wantStatus, w.Code)
+			assert.True(t, json.Valid(w.Body.Bytes()))
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// CalendarFeed represents a secret iCalendar feed URL calendar apps subscribe to
+// Only the SHA-256 hash of the token is stored
+type CalendarFeed struct {
+	bun.BaseModel `bun:"table:calendar_feeds,alias:cf"`
+
+	ID        int64     `bun:"id,pk,autoincrement"`
+	Name      string    `bun:"name,notnull"`
+	TokenHash string    `bun:"token_hash,notnull"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// Field names tracked in FieldVersions
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldDueAt       = "due_at"
+	FieldCompleted   = "completed"
+)
+
+// FieldVersions records when each field of a row was last modified, used to merge offline changes
+// with per-field last-writer-wins
+type FieldVersions map[string]time.Time
+
+// Touch records that fields were modified at t
+func (v *FieldVersions) Touch(t time.Time, fields ...string) {
+	if *v == nil {
+		*v = make(FieldVersions, len(fields))
+	}
+	for _, field := range fields {
+		(*v)[field] = t
+	}
+}
+
+// Init records that fields were modified at t, keeping the fields already recorded
+func (v *FieldVersions) Init(t time.Time, fields ...string) {
+	for _, field := range fields {
+		if _, ok := (*v)[field]; !ok {
+			v.Touch(t, field)
+		}
+	}
+}
+
+// ModifiedAt returns when field was last modified, or fallback for rows written before it was recorded
+func (v FieldVersions) ModifiedAt(field string, fallback time.Time) time.Time {
+	if t, ok := v[field]; ok {
+		return t
+	}
+
+	return fallback
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// IdempotencyKey represents the stored outcome of a request sent with an Idempotency-Key header
+type IdempotencyKey struct {
+	bun.BaseModel `bun:"table:idempotency_keys,alias:ik"`
+
+	Key                 string    `bun:"key,pk"`
+	RequestHash         string    `bun:"request_hash,notnull"`
+	ClaimToken          string    `bun:"claim_token,notnull"` // Identifies the request holding the key
+	ResponseStatus      int       `bun:"response_status,notnull"`
+	ResponseContentType string    `bun:"response_content_type,notnull"`
+	ResponseBody        []byte    `bun:"response_body"`
+	CreatedAt           time.Time `bun:"created_at,notnull,default:current_timestamp"`
+	ExpiresAt           time.Time `bun:"expires_at,notnull"`
+}
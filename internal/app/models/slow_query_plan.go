@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// SlowQueryPlan represents the EXPLAIN plan captured for a slow statement
+// The query is stored with its values redacted
+type SlowQueryPlan struct {
+	bun.BaseModel `bun:"table:slow_query_plans,alias:sqp"`
+
+	ID         int64     `bun:"id,pk,autoincrement"`
+	Query      string    `bun:"query,notnull"`
+	Operation  string    `bun:"operation,notnull"`
+	DurationMS float64   `bun:"duration_ms,notnull"`
+	Plan       string    `bun:"plan,notnull"`
+	CapturedAt time.Time `bun:"captured_at,notnull,default:current_timestamp"`
+}
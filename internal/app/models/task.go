@@ -10,10 +10,14 @@ import (
 type Task struct {
 	bun.BaseModel `bun:"table:tasks,alias:t"`
 
-	ID          int64       `bun:"id,pk,autoincrement"`
-	Title       string      `bun:"title,notnull"`
-	Description string      `bun:"description"`
-	CreatedAt   time.Time   `bun:"created_at,notnull,default:current_timestamp"`
-	UpdatedAt   time.Time   `bun:"updated_at,notnull,default:current_timestamp"`
-	Items       []*TaskItem `bun:"rel:has-many,join:id=task_id"`
+	ID            int64         `bun:"id,pk,autoincrement"`
+	Title         string        `bun:"title,notnull"`
+	Description   string        `bun:"description"`
+	DueAt         *time.Time    `bun:"due_at"`
+	ICalUID       string        `bun:"ical_uid,nullzero,notnull"` // derived from ID by the database when empty
+	CreatedAt     time.Time     `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt     time.Time     `bun:"updated_at,notnull,default:current_timestamp"`
+	ChangeSeq     int64         `bun:"change_seq,nullzero,notnull,default:nextval('change_seq')"`
+	FieldVersions FieldVersions `bun:"field_versions,type:jsonb,nullzero,notnull,default:'{}'"`
+	Items         []*TaskItem   `bun:"rel:has-many,join:id=task_id"`
 }
@@ -10,11 +10,14 @@ import (
 type TaskItem struct {
 	bun.BaseModel `bun:"table:task_items,alias:ti"`
 
-	ID        int64     `bun:"id,pk,autoincrement"`
-	TaskID    int64     `bun:"task_id,notnull"`
-	Title     string    `bun:"title,notnull"`
-	Completed bool      `bun:"completed,notnull,default:false"`
-	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp"`
-	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp"`
-	Task      *Task     `bun:"rel:belongs-to,join:task_id=id"`
+	ID            int64         `bun:"id,pk,autoincrement"`
+	TaskID        int64         `bun:"task_id,notnull"`
+	Title         string        `bun:"title,notnull"`
+	Completed     bool          `bun:"completed,notnull,default:false"`
+	ICalUID       string        `bun:"ical_uid,nullzero,notnull"` // derived from ID by the database when empty
+	CreatedAt     time.Time     `bun:"created_at,notnull,default:current_timestamp"`
+	UpdatedAt     time.Time     `bun:"updated_at,notnull,default:current_timestamp"`
+	ChangeSeq     int64         `bun:"change_seq,nullzero,notnull,default:nextval('change_seq')"`
+	FieldVersions FieldVersions `bun:"field_versions,type:jsonb,nullzero,notnull,default:'{}'"`
+	Task          *Task         `bun:"rel:belongs-to,join:task_id=id"`
 }
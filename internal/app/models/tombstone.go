@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// Entity types of a Tombstone
+const (
+	TombstoneTask     = "task"
+	TombstoneTaskItem = "task_item"
+)
+
+// Tombstone records the deletion of a task or an item, so that sync clients can delete their copy
+type Tombstone struct {
+	bun.BaseModel `bun:"table:tombstones,alias:tb"`
+
+	EntityType string    `bun:"entity_type,pk"`
+	EntityID   int64     `bun:"entity_id,pk"`
+	TaskID     int64     `bun:"task_id,notnull"`
+	ICalUID    string    `bun:"ical_uid,notnull"`
+	ChangeSeq  int64     `bun:"change_seq,nullzero,notnull,default:nextval('change_seq')"`
+	DeletedAt  time.Time `bun:"deleted_at,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,28 @@
+package app
+
+import (
+	"github.com/clevertechware/todo-bun-app/internal/config"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+)
+
+// Config returns the configuration in effect, updated by ApplyConfig
+func (a *App) Config() *config.Config {
+	return a.config.Load()
+}
+
+// ApplyConfig applies the log settings of cfg, the only ones changed at runtime, cfg being validated beforehand
+// It returns the changed settings kept until the next restart, such as the pool settings that pgxpool fixes once
+// created
+func (a *App) ApplyConfig(cfg *config.Config) []string {
+	a.configMu.Lock()
+	defer a.configMu.Unlock()
+
+	next := *a.config.Load()
+	if next.Log != cfg.Log {
+		logger.Init(logger.Config{Level: cfg.Log.Level, Pretty: cfg.Log.Pretty})
+		next.Log = cfg.Log
+	}
+
+	a.config.Store(&next)
+	return next.Changes(cfg)
+}
@@ -0,0 +1,30 @@
+package app
+
+import (
+	"sync/atomic"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/clevertechware/todo-bun-app/internal/config"
+)
+
+func TestApp_ApplyConfig(t *testing.T) {
+	current := config.NewDefaultConfig()
+	a := &App{config: new(atomic.Pointer[config.Config])}
+	a.config.Store(current)
+	defer zerolog.SetGlobalLevel(zerolog.GlobalLevel())
+
+	next := config.NewDefaultConfig()
+	next.Log.Level = "debug"
+	next.Database.Pool.MaxConns = 10
+
+	restartRequired := a.ApplyConfig(next)
+
+	assert.Equal(t, []string{"db.pool.maxConns"}, restartRequired)
+	assert.Equal(t, "debug", a.Config().Log.Level)
+	assert.Equal(t, current.Database.Pool.MaxConns, a.Config().Database.Pool.MaxConns)
+	assert.Equal(t, zerolog.DebugLevel, zerolog.GlobalLevel())
+	assert.Equal(t, "info", current.Log.Level, "the previous configuration must not be modified")
+}
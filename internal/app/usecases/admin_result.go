@@ -0,0 +1,20 @@
+package usecases
+
+import "time"
+
+// PoolStatsResult represents the state of the connection pool in the output
+type PoolStatsResult struct {
+	AcquiredConns           int32
+	IdleConns               int32
+	ConstructingConns       int32
+	TotalConns              int32
+	MaxConns                int32
+	AcquireCount            int64
+	AcquireDuration         time.Duration
+	EmptyAcquireCount       int64
+	EmptyAcquireWaitTime    time.Duration
+	CanceledAcquireCount    int64
+	NewConnsCount           int64
+	MaxLifetimeDestroyCount int64
+	MaxIdleDestroyCount     int64
+}
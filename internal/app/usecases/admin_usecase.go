@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"fmt"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+)
+
+// AdminUsecase defines the interface for the runtime state operators inspect and tune
+type AdminUsecase interface {
+	GetLogLevel() string
+	// SetLogLevel changes the log level of the running server, until the next restart
+	SetLogLevel(level string) error
+	GetPoolStats() *PoolStatsResult
+}
+
+// adminUsecase implements AdminUsecase
+type adminUsecase struct {
+	healthRepo db.HealthRepository
+}
+
+// NewAdminUsecase creates a new instance of AdminUsecase
+func NewAdminUsecase(healthRepo db.HealthRepository) AdminUsecase {
+	return &adminUsecase{healthRepo: healthRepo}
+}
+
+func (u *adminUsecase) GetLogLevel() string {
+	return logger.Level()
+}
+
+func (u *adminUsecase) SetLogLevel(level string) error {
+	if err := logger.SetLevel(level); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidLogLevel, err)
+	}
+
+	return nil
+}
+
+func (u *adminUsecase) GetPoolStats() *PoolStatsResult {
+	stats := u.healthRepo.PoolStats()
+	return &PoolStatsResult{
+		AcquiredConns:           stats.AcquiredConns,
+		IdleConns:               stats.IdleConns,
+		ConstructingConns:       stats.ConstructingConns,
+		TotalConns:              stats.TotalConns,
+		MaxConns:                stats.MaxConns,
+		AcquireCount:            stats.AcquireCount,
+		AcquireDuration:         stats.AcquireDuration,
+		EmptyAcquireCount:       stats.EmptyAcquireCount,
+		EmptyAcquireWaitTime:    stats.EmptyAcquireWaitTime,
+		CanceledAcquireCount:    stats.CanceledAcquireCount,
+		NewConnsCount:           stats.NewConnsCount,
+		MaxLifetimeDestroyCount: stats.MaxLifetimeDestroyCount,
+		MaxIdleDestroyCount:     stats.MaxIdleDestroyCount,
+	}
+}
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+)
+
+func TestAdminUsecase_SetLogLevel(t *testing.T) {
+	previous := zerolog.GlobalLevel()
+	t.Cleanup(func() { zerolog.SetGlobalLevel(previous) })
+
+	u := NewAdminUsecase(nil)
+
+	require.NoError(t, u.SetLogLevel("debug"))
+	assert.Equal(t, "debug", u.GetLogLevel())
+
+	assert.ErrorIs(t, u.SetLogLevel("verbose"), ErrInvalidLogLevel)
+	assert.ErrorIs(t, u.SetLogLevel(""), ErrInvalidLogLevel)
+	assert.Equal(t, "debug", u.GetLogLevel(), "an invalid level leaves the level unchanged")
+}
+
+func TestAdminUsecase_GetPoolStats(t *testing.T) {
+	t.Parallel()
+
+	healthRepo := mocks.NewHealthRepository(t)
+	healthRepo.On("PoolStats").Return(db.PoolStats{
+		AcquiredConns:   2,
+		TotalConns:      3,
+		MaxConns:        5,
+		AcquireCount:    10,
+		AcquireDuration: time.Second,
+	}).Once()
+	u := NewAdminUsecase(healthRepo)
+
+	got := u.GetPoolStats()
+
+	assert.Equal(t, &PoolStatsResult{
+		AcquiredConns:   2,
+		TotalConns:      3,
+		MaxConns:        5,
+		AcquireCount:    10,
+		AcquireDuration: time.Second,
+	}, got)
+}
@@ -0,0 +1,30 @@
+package usecases
+
+// BatchOperationType identifies the kind of a batch operation
+type BatchOperationType string
+
+const (
+	BatchCreateTask BatchOperationType = "create_task"
+	BatchUpdateTask BatchOperationType = "update_task"
+	BatchToggleItem BatchOperationType = "toggle_item"
+	BatchDeleteTask BatchOperationType = "delete_task"
+)
+
+// BatchOperationParams represents a single operation of a batch
+// The target task is given either by TaskID or by TaskRef, the Ref of a task created earlier in the batch
+type BatchOperationParams struct {
+	Type    BatchOperationType
+	Ref     string // create_task: client reference of the created task
+	TaskID  int64
+	TaskRef string
+	ItemID  int64            // toggle_item
+	Task    CreateTaskParams // create_task
+	Update  UpdateTaskParams // update_task
+	// toggle_item: the new completion status, nil flips the current one
+	Completed *bool
+}
+
+// BatchParams represents an ordered list of operations applied atomically
+type BatchParams struct {
+	Operations []BatchOperationParams
+}
@@ -0,0 +1,16 @@
+package usecases
+
+// BatchOperationResult represents the outcome of a single batch operation
+type BatchOperationResult struct {
+	Index  int
+	Type   BatchOperationType
+	Ref    string
+	TaskID int64
+	Task   *TaskResult     // create_task and update_task
+	Item   *TaskItemResult // toggle_item
+}
+
+// BatchResult represents the outcome of a committed batch, one result per operation
+type BatchResult struct {
+	Results []BatchOperationResult
+}
@@ -0,0 +1,17 @@
+package usecases
+
+import "github.com/emersion/go-ical"
+
+// PutCalendarObjectParams represents a calendar object uploaded by a CalDAV client
+// IfMatch and IfNoneMatch hold the unquoted entity tag of the matching precondition, "*" for any, or are empty
+type PutCalendarObjectParams struct {
+	Calendar    *ical.Calendar
+	IfMatch     string
+	IfNoneMatch string
+}
+
+// DeleteCalendarObjectParams represents the deletion of a calendar object by a CalDAV client
+type DeleteCalendarObjectParams struct {
+	UID     string
+	IfMatch string
+}
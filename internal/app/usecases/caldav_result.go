@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+// CalendarObjectResult represents a task or a task item as a calendar object holding a single VTODO
+type CalendarObjectResult struct {
+	UID     string
+	ETag    string // unquoted, changes whenever Data does
+	ModTime time.Time
+	Data    *ical.Calendar
+}
+
+// CalendarSyncResult represents the calendar objects changed or deleted since a sync token
+type CalendarSyncResult struct {
+	// Token is passed to the next sync
+	Token   string
+	Objects []CalendarObjectResult
+	Deleted []string // UIDs
+}
@@ -0,0 +1,459 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/emersion/go-ical"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// CalDAVUsecase defines the interface for the calendar of tasks served to CalDAV clients
+// Every task and every task item is a calendar object holding a single VTODO, the VTODO of an item
+// being related to the VTODO of its task
+type CalDAVUsecase interface {
+	ListObjects(ctx context.Context) ([]CalendarObjectResult, error)
+	GetObject(ctx context.Context, uid string) (*CalendarObjectResult, error)
+	// PutObject creates or updates the task or item of the VTODO of params.Calendar
+	PutObject(ctx context.Context, params PutCalendarObjectParams) (*CalendarObjectResult, error)
+	DeleteObject(ctx context.Context, params DeleteCalendarObjectParams) error
+	// SyncToken returns the token of the current state of the calendar
+	SyncToken(ctx context.Context) (string, error)
+	// SyncObjects returns the objects changed and deleted since token, every object for an empty token
+	SyncObjects(ctx context.Context, token string) (*CalendarSyncResult, error)
+}
+
+// caldavUsecase implements CalDAVUsecase
+type caldavUsecase struct {
+	taskUsecase TaskUsecase
+	syncRepo    db.SyncRepository
+	transactor  db.Transactor
+}
+
+// NewCalDAVUsecase creates a new instance of CalDAVUsecase
+func NewCalDAVUsecase(taskUsecase TaskUsecase, syncRepo db.SyncRepository, transactor db.Transactor) CalDAVUsecase {
+	return &caldavUsecase{
+		taskUsecase: taskUsecase,
+		syncRepo:    syncRepo,
+		transactor:  transactor,
+	}
+}
+
+// ListObjects retrieves the objects of every task and item
+func (u *caldavUsecase) ListObjects(ctx context.Context) ([]CalendarObjectResult, error) {
+	list, err := u.taskUsecase.ListTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]CalendarObjectResult, 0, len(list.Tasks))
+	for _, task := range list.Tasks {
+		taskObjects, err := taskCalendarObjects(task)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, taskObjects...)
+	}
+
+	return objects, nil
+}
+
+// GetObject retrieves the object of the task or item with the given UID
+func (u *caldavUsecase) GetObject(ctx context.Context, uid string) (*CalendarObjectResult, error) {
+	task, err := u.findTask(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return findCalendarObject(*task, uid)
+}
+
+// PutObject updates the task or item with the UID of the VTODO, or creates it
+// A new VTODO related to the VTODO of a task becomes an item of that task, any other one a task
+// A task is completed once all its items are, so completing or reopening a task does the same to all its items;
+// a task without items cannot be completed
+func (u *caldavUsecase) PutObject(ctx context.Context, params PutCalendarObjectParams) (*CalendarObjectResult, error) {
+	todo, err := calendarObjectToDo(params.Calendar)
+	if err != nil {
+		return nil, err
+	}
+	uid := icalUID(todo)
+
+	var result *CalendarObjectResult
+	err = u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		task, err := u.findTask(ctx, uid)
+		if err != nil && !errors.Is(err, ErrCalendarObjectNotFound) {
+			return err
+		}
+
+		var current *CalendarObjectResult
+		if task != nil {
+			if current, err = findCalendarObject(*task, uid); err != nil {
+				return err
+			}
+		}
+		if err = checkCalendarPreconditions(current, params.IfMatch, params.IfNoneMatch); err != nil {
+			return err
+		}
+
+		switch {
+		case task == nil:
+			err = u.createObject(ctx, todo)
+		case taskResultUID(*task) == uid:
+			err = u.updateTaskObject(ctx, *task, todo)
+		default:
+			err = u.updateItemObject(ctx, *task, todo)
+		}
+		if err != nil {
+			return err
+		}
+
+		result, err = u.GetObject(ctx, uid)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DeleteObject deletes the task, with its items, or the item with the given UID
+func (u *caldavUsecase) DeleteObject(ctx context.Context, params DeleteCalendarObjectParams) error {
+	return u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		task, err := u.findTask(ctx, params.UID)
+		if err != nil {
+			return err
+		}
+
+		current, err := findCalendarObject(*task, params.UID)
+		if err != nil {
+			return err
+		}
+		if err = checkCalendarPreconditions(current, params.IfMatch, ""); err != nil {
+			return err
+		}
+
+		if taskResultUID(*task) == params.UID {
+			return u.taskUsecase.DeleteTask(ctx, task.ID)
+		}
+
+		item, _ := findTaskItem(*task, params.UID)
+		return u.taskUsecase.DeleteTaskItem(ctx, task.ID, item.ID)
+	})
+}
+
+// SyncToken returns the current change sequence value as a token
+func (u *caldavUsecase) SyncToken(ctx context.Context) (string, error) {
+	seq, err := u.syncRepo.CurrentChangeSeq(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return encodeSyncToken(seq), nil
+}
+
+// SyncObjects lists the objects of the tasks changed since token and the UIDs of the deleted tasks and items
+// The object of a task shows the status of its items and the objects of its items show its due date,
+// so a change to a task or to one of its items is reported as a change to all their objects
+func (u *caldavUsecase) SyncObjects(ctx context.Context, token string) (*CalendarSyncResult, error) {
+	if token == "" {
+		// The token is read first: a change made while listing is reported again on the next sync
+		current, err := u.SyncToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+		objects, err := u.ListObjects(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return &CalendarSyncResult{Token: current, Objects: objects, Deleted: []string{}}, nil
+	}
+
+	changes, err := u.taskUsecase.GetChanges(ctx, GetChangesParams{Since: token})
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[int64]bool, len(changes.Tasks)+len(changes.Items))
+	for _, task := range changes.Tasks {
+		changed[task.ID] = true
+	}
+	for _, item := range changes.Items {
+		changed[item.TaskID] = true
+	}
+
+	result := &CalendarSyncResult{
+		Token:   changes.Token,
+		Objects: make([]CalendarObjectResult, 0),
+		Deleted: make([]string, 0, len(changes.Deleted)),
+	}
+	for _, tombstone := range changes.Deleted {
+		if tombstone.Type == models.TombstoneTaskItem {
+			changed[tombstone.TaskID] = true
+		}
+		result.Deleted = append(result.Deleted, tombstoneUID(tombstone))
+	}
+	if len(changed) == 0 {
+		return result, nil
+	}
+
+	list, err := u.taskUsecase.ListTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range list.Tasks {
+		if !changed[task.ID] {
+			continue
+		}
+		objects, err := taskCalendarObjects(task)
+		if err != nil {
+			return nil, err
+		}
+		result.Objects = append(result.Objects, objects...)
+	}
+
+	return result, nil
+}
+
+// findTask retrieves the task whose object, or the object of one of whose items, has the given UID
+func (u *caldavUsecase) findTask(ctx context.Context, uid string) (*TaskResult, error) {
+	task, err := u.taskUsecase.GetTaskByICalUID(ctx, uid)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCalendarObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// createObject creates an item when the VTODO is related to the VTODO of a task, and a task otherwise
+func (u *caldavUsecase) createObject(ctx context.Context, todo *ical.Component) error {
+	params, err := readICalTask(todo)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCalendarObject, err)
+	}
+	params.ICalUID = icalUID(todo)
+
+	if parentUID := icalParentUID(todo); parentUID != "" {
+		parent, err := u.findTask(ctx, parentUID)
+		if err != nil && !errors.Is(err, ErrCalendarObjectNotFound) {
+			return err
+		}
+
+		// Sub-to-dos of items or of unknown to-dos become tasks
+		if parent != nil && taskResultUID(*parent) == parentUID {
+			_, err = u.taskUsecase.AddTaskItem(ctx, parent.ID, CreateTaskItemParams{
+				Title:     params.Title,
+				Completed: icalCompleted(todo),
+				ICalUID:   params.ICalUID,
+			})
+			return err
+		}
+	}
+
+	_, err = u.taskUsecase.CreateTask(ctx, params)
+	return err
+}
+
+// updateTaskObject updates a task from its VTODO, completing or reopening its items along with it
+func (u *caldavUsecase) updateTaskObject(ctx context.Context, task TaskResult, todo *ical.Component) error {
+	fields, err := readICalTask(todo)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCalendarObject, err)
+	}
+
+	if _, err = u.taskUsecase.UpdateTask(ctx, task.ID, UpdateTaskParams{
+		Title:       &fields.Title,
+		Description: &fields.Description,
+		DueAt:       fields.DueAt,
+		ClearDueAt:  fields.DueAt == nil,
+	}); err != nil {
+		return err
+	}
+
+	completed := icalCompleted(todo)
+	if len(task.Items) == 0 || completed == allItemsCompleted(task) {
+		return nil
+	}
+	for _, item := range task.Items {
+		if item.Completed == completed {
+			continue
+		}
+		if _, err = u.taskUsecase.UpdateTaskItem(ctx, task.ID, item.ID, UpdateTaskItemParams{Completed: &completed}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateItemObject updates the title and completion status of an item of task from its VTODO
+func (u *caldavUsecase) updateItemObject(ctx context.Context, task TaskResult, todo *ical.Component) error {
+	item, _ := findTaskItem(task, icalUID(todo))
+
+	title, err := todo.Props.Text(ical.PropSummary)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidCalendarObject, err)
+	}
+	completed := icalCompleted(todo)
+
+	_, err = u.taskUsecase.UpdateTaskItem(ctx, task.ID, item.ID, UpdateTaskItemParams{
+		Title:     &title,
+		Completed: &completed,
+	})
+	return err
+}
+
+// calendarObjectToDo returns the single VTODO of an uploaded calendar object, which may also hold time zones
+func calendarObjectToDo(cal *ical.Calendar) (*ical.Component, error) {
+	if cal == nil {
+		return nil, fmt.Errorf("%w: empty calendar", ErrInvalidCalendarObject)
+	}
+
+	var todo *ical.Component
+	for _, child := range cal.Children {
+		switch child.Name {
+		case ical.CompTimezone:
+		case ical.CompToDo:
+			if todo != nil {
+				return nil, fmt.Errorf("%w: more than one VTODO", ErrInvalidCalendarObject)
+			}
+			todo = child
+		default:
+			return nil, fmt.Errorf("%w: unsupported %s component", ErrInvalidCalendarObject, child.Name)
+		}
+	}
+
+	if todo == nil {
+		return nil, fmt.Errorf("%w: no VTODO", ErrInvalidCalendarObject)
+	}
+	if icalUID(todo) == "" {
+		return nil, fmt.Errorf("%w: VTODO without UID", ErrInvalidCalendarObject)
+	}
+
+	return todo, nil
+}
+
+// checkCalendarPreconditions evaluates If-Match and If-None-Match against the current object, nil when there is none
+func checkCalendarPreconditions(current *CalendarObjectResult, ifMatch, ifNoneMatch string) error {
+	if ifMatch != "" && (current == nil || (ifMatch != "*" && ifMatch != current.ETag)) {
+		return ErrCalendarObjectPreconditionFailed
+	}
+	if ifNoneMatch != "" && current != nil && (ifNoneMatch == "*" || ifNoneMatch == current.ETag) {
+		return ErrCalendarObjectPreconditionFailed
+	}
+
+	return nil
+}
+
+// taskCalendarObjects returns the objects of a task and of its items
+func taskCalendarObjects(task TaskResult) ([]CalendarObjectResult, error) {
+	objects := make([]CalendarObjectResult, 0, len(task.Items)+1)
+
+	object, err := newCalendarObject(taskToVTodo(task), taskModTime(task))
+	if err != nil {
+		return nil, err
+	}
+	objects = append(objects, *object)
+
+	for _, item := range task.Items {
+		object, err = newCalendarObject(taskItemToVTodo(task, item), item.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, *object)
+	}
+
+	return objects, nil
+}
+
+// findCalendarObject returns the object of task, or of the item of task, with the given UID
+func findCalendarObject(task TaskResult, uid string) (*CalendarObjectResult, error) {
+	if taskResultUID(task) == uid {
+		return newCalendarObject(taskToVTodo(task), taskModTime(task))
+	}
+
+	item, ok := findTaskItem(task, uid)
+	if !ok {
+		return nil, ErrCalendarObjectNotFound
+	}
+
+	return newCalendarObject(taskItemToVTodo(task, item), item.UpdatedAt)
+}
+
+// newCalendarObject wraps a VTODO in a calendar, its entity tag being a hash of its encoding
+func newCalendarObject(todo *ical.Component, modTime time.Time) (*CalendarObjectResult, error) {
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, icalProductID)
+	cal.Children = append(cal.Children, todo)
+
+	var buf bytes.Buffer
+	if err := ical.NewEncoder(&buf).Encode(cal); err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+
+	return &CalendarObjectResult{
+		UID:     icalUID(todo),
+		ETag:    hex.EncodeToString(sum[:16]),
+		ModTime: modTime,
+		Data:    cal,
+	}, nil
+}
+
+func findTaskItem(task TaskResult, uid string) (TaskItemResult, bool) {
+	for _, item := range task.Items {
+		if taskItemResultUID(item) == uid {
+			return item, true
+		}
+	}
+
+	return TaskItemResult{}, false
+}
+
+// taskModTime returns when a task or one of its items was last modified
+func taskModTime(task TaskResult) time.Time {
+	modTime := task.UpdatedAt
+	for _, item := range task.Items {
+		if item.UpdatedAt.After(modTime) {
+			modTime = item.UpdatedAt
+		}
+	}
+
+	return modTime
+}
+
+func allItemsCompleted(task TaskResult) bool {
+	for _, item := range task.Items {
+		if !item.Completed {
+			return false
+		}
+	}
+
+	return len(task.Items) > 0
+}
+
+// tombstoneUID returns the UID of a deleted task or item, derived from its ID when it was deleted without one
+func tombstoneUID(tombstone TombstoneResult) string {
+	switch {
+	case tombstone.ICalUID != "":
+		return tombstone.ICalUID
+	case tombstone.Type == models.TombstoneTaskItem:
+		return taskItemUID(tombstone.ID)
+	default:
+		return taskUID(tombstone.ID)
+	}
+}
@@ -0,0 +1,355 @@
+package usecases
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-ical"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// decodeTestCalendar decodes a calendar written with line feeds
+func decodeTestCalendar(t *testing.T, data string) *ical.Calendar {
+	t.Helper()
+
+	cal, err := ical.NewDecoder(strings.NewReader(strings.ReplaceAll(data, "\n", "\r\n"))).Decode()
+	require.NoError(t, err)
+
+	return cal
+}
+
+func newTestCalDAVUsecase(t *testing.T, taskRepo db.TaskRepository, syncRepo db.SyncRepository) CalDAVUsecase {
+	transactor := newTestTransactor(t, nil)
+	return NewCalDAVUsecase(NewTaskUsecase(taskRepo, syncRepo, nil, transactor), syncRepo, transactor)
+}
+
+func TestCalDAVUsecase_PutObject(t *testing.T) {
+	t.Parallel()
+
+	shopping := func() *models.Task {
+		return &models.Task{
+			ID:      1,
+			Title:   "Shopping",
+			ICalUID: "task-1@todo-bun-app",
+			Items: []*models.TaskItem{
+				{ID: 2, TaskID: 1, Title: "Buy milk", ICalUID: "item-2@todo-bun-app"},
+				{ID: 3, TaskID: 1, Title: "Buy bread", Completed: true, ICalUID: "item-3@todo-bun-app"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		calendar string
+		ifMatch  string
+		ifNone   string
+		taskRepo func(t *testing.T) db.TaskRepository
+		wantUID  string
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should create a task keeping the UID of the client",
+			calendar: `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//test//EN
+BEGIN:VTODO
+UID:A1B2C3
+DTSTAMP:20250301T100000Z
+SUMMARY:Call the bank
+DUE:20250310T090000Z
+END:VTODO
+END:VCALENDAR
+`,
+			ifNone: "*",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetByICalUID", mock.Anything, "A1B2C3").Return(nil, sql.ErrNoRows).Once()
+				m.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.ICalUID == "A1B2C3" && task.Title == "Call the bank" && task.DueAt != nil
+				})).Return(nil).Once()
+				m.On("GetByICalUID", mock.Anything, "A1B2C3").Return(&models.Task{ID: 4, Title: "Call the bank", ICalUID: "A1B2C3"}, nil).Once()
+				return m
+			},
+			wantUID: "A1B2C3",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should add an item to the task the to-do is related to",
+			calendar: `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//test//EN
+BEGIN:VTODO
+UID:D4E5F6
+DTSTAMP:20250301T100000Z
+SUMMARY:Buy eggs
+RELATED-TO:task-1@todo-bun-app
+END:VTODO
+END:VCALENDAR
+`,
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetByICalUID", mock.Anything, "D4E5F6").Return(nil, sql.ErrNoRows).Once()
+				m.On("GetByICalUID", mock.Anything, "task-1@todo-bun-app").Return(shopping(), nil).Once()
+				m.On("CreateItem", mock.Anything, mock.MatchedBy(func(item *models.TaskItem) bool {
+					return item.TaskID == 1 && item.ICalUID == "D4E5F6" && item.Title == "Buy eggs"
+				})).Return(nil).Once()
+				task := shopping()
+				task.Items = append(task.Items, &models.TaskItem{ID: 5, TaskID: 1, Title: "Buy eggs", ICalUID: "D4E5F6"})
+				m.On("GetByICalUID", mock.Anything, "D4E5F6").Return(task, nil).Once()
+				return m
+			},
+			wantUID: "D4E5F6",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should complete the open items of a completed task",
+			calendar: `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//test//EN
+BEGIN:VTODO
+UID:task-1@todo-bun-app
+DTSTAMP:20250301T100000Z
+SUMMARY:Shopping
+STATUS:COMPLETED
+END:VTODO
+END:VCALENDAR
+`,
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetByICalUID", mock.Anything, "task-1@todo-bun-app").Return(shopping(), nil)
+				m.On("GetForUpdate", mock.Anything, int64(1)).Return(shopping(), nil).Once()
+				m.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.Title == "Shopping" && task.DueAt == nil
+				})).Return(nil).Once()
+				m.On("GetItemForUpdate", mock.Anything, int64(1), int64(2)).Return(shopping().Items[0], nil).Once()
+				m.On("UpdateItem", mock.Anything, mock.MatchedBy(func(item *models.TaskItem) bool {
+					return item.ID == 2 && item.Completed
+				})).Return(nil).Once()
+				return m
+			},
+			wantUID: "task-1@todo-bun-app",
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should reject an update of an object that changed",
+			calendar: `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//test//EN
+BEGIN:VTODO
+UID:item-2@todo-bun-app
+DTSTAMP:20250301T100000Z
+SUMMARY:Buy oat milk
+END:VTODO
+END:VCALENDAR
+`,
+			ifMatch: "stale",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetByICalUID", mock.Anything, "item-2@todo-bun-app").Return(shopping(), nil).Once()
+				return m
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrCalendarObjectPreconditionFailed)
+			},
+		},
+		{
+			name: "should reject the creation of an object that exists",
+			calendar: `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//test//EN
+BEGIN:VTODO
+UID:task-1@todo-bun-app
+DTSTAMP:20250301T100000Z
+SUMMARY:Shopping
+END:VTODO
+END:VCALENDAR
+`,
+			ifNone: "*",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetByICalUID", mock.Anything, "task-1@todo-bun-app").Return(shopping(), nil).Once()
+				return m
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrCalendarObjectPreconditionFailed)
+			},
+		},
+		{
+			name: "should reject a calendar object without a to-do",
+			calendar: `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//test//EN
+BEGIN:VEVENT
+UID:G7H8I9
+DTSTAMP:20250301T100000Z
+DTSTART:20250310T090000Z
+SUMMARY:Meeting
+END:VEVENT
+END:VCALENDAR
+`,
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				// Repository should not be called
+				return mocks.NewTaskRepository(t)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidCalendarObject)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := newTestCalDAVUsecase(t, tt.taskRepo(t), mocks.NewSyncRepository(t))
+			got, err := u.PutObject(context.Background(), PutCalendarObjectParams{
+				Calendar:    decodeTestCalendar(t, tt.calendar),
+				IfMatch:     tt.ifMatch,
+				IfNoneMatch: tt.ifNone,
+			})
+
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantUID, got.UID)
+			assert.NotEmpty(t, got.ETag)
+		})
+	}
+}
+
+func TestCalDAVUsecase_DeleteObject(t *testing.T) {
+	t.Parallel()
+
+	task := &models.Task{
+		ID:      1,
+		Title:   "Shopping",
+		ICalUID: "task-1@todo-bun-app",
+		Items:   []*models.TaskItem{{ID: 2, TaskID: 1, Title: "Buy milk", ICalUID: "A1B2C3"}},
+	}
+
+	t.Run("should delete the item with the UID", func(t *testing.T) {
+		t.Parallel()
+
+		taskRepo := mocks.NewTaskRepository(t)
+		taskRepo.On("GetByICalUID", mock.Anything, "A1B2C3").Return(task, nil).Twice()
+		taskRepo.On("DeleteItem", mock.Anything, int64(1), int64(2)).Return(nil).Once()
+		u := newTestCalDAVUsecase(t, taskRepo, mocks.NewSyncRepository(t))
+
+		current, err := u.GetObject(context.Background(), "A1B2C3")
+		require.NoError(t, err)
+
+		err = u.DeleteObject(context.Background(), DeleteCalendarObjectParams{UID: "A1B2C3", IfMatch: current.ETag})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return not found for an unknown UID", func(t *testing.T) {
+		t.Parallel()
+
+		taskRepo := mocks.NewTaskRepository(t)
+		taskRepo.On("GetByICalUID", mock.Anything, "unknown").Return(nil, sql.ErrNoRows).Once()
+		u := newTestCalDAVUsecase(t, taskRepo, mocks.NewSyncRepository(t))
+
+		err := u.DeleteObject(context.Background(), DeleteCalendarObjectParams{UID: "unknown"})
+		assert.ErrorIs(t, err, ErrCalendarObjectNotFound)
+	})
+}
+
+func TestCalDAVUsecase_SyncObjects(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	tasks := []*models.Task{
+		{ID: 1, Title: "Shopping", ICalUID: "task-1@todo-bun-app", Items: []*models.TaskItem{
+			{ID: 2, TaskID: 1, Title: "Buy milk", ICalUID: "item-2@todo-bun-app"},
+		}},
+		{ID: 4, Title: "Call the bank", ICalUID: "A1B2C3"},
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		syncRepo    func(t *testing.T) db.SyncRepository
+		wantToken   string
+		wantObjects []string
+		wantDeleted []string
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "should return every object on an initial sync",
+			token: "",
+			syncRepo: func(t *testing.T) db.SyncRepository {
+				m := mocks.NewSyncRepository(t)
+				m.On("CurrentChangeSeq", mock.Anything).Return(int64(12), nil).Once()
+				return m
+			},
+			wantToken:   encodeSyncToken(12),
+			wantObjects: []string{"task-1@todo-bun-app", "item-2@todo-bun-app", "A1B2C3"},
+			wantDeleted: []string{},
+			wantErr:     assert.NoError,
+		},
+		{
+			name:  "should return the objects of the tasks changed since the token",
+			token: encodeSyncToken(7),
+			syncRepo: func(t *testing.T) db.SyncRepository {
+				m := mocks.NewSyncRepository(t)
+				m.On("CurrentChangeSeq", mock.Anything).Return(int64(12), nil).Once()
+				m.On("ListChanges", mock.Anything, int64(7), int64(12)).Return(&db.Changes{
+					Tombstones: []*models.Tombstone{
+						{EntityType: models.TombstoneTaskItem, EntityID: 3, TaskID: 1, ICalUID: "D4E5F6", DeletedAt: deletedAt},
+						{EntityType: models.TombstoneTask, EntityID: 5, TaskID: 5, DeletedAt: deletedAt},
+					},
+				}, nil).Once()
+				return m
+			},
+			wantToken:   encodeSyncToken(12),
+			wantObjects: []string{"task-1@todo-bun-app", "item-2@todo-bun-app"},
+			wantDeleted: []string{"D4E5F6", "task-5@todo-bun-app"},
+			wantErr:     assert.NoError,
+		},
+		{
+			name:  "should reject a malformed token",
+			token: "not-a-token",
+			syncRepo: func(t *testing.T) db.SyncRepository {
+				// Repository should not be called
+				return mocks.NewSyncRepository(t)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidSyncToken)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			taskRepo := mocks.NewTaskRepository(t)
+			taskRepo.On("List", mock.Anything).Return(tasks, nil).Maybe()
+			u := newTestCalDAVUsecase(t, taskRepo, tt.syncRepo(t))
+
+			got, err := u.SyncObjects(context.Background(), tt.token)
+
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.wantToken, got.Token)
+			uids := make([]string, 0, len(got.Objects))
+			for _, object := range got.Objects {
+				uids = append(uids, object.UID)
+			}
+			assert.Equal(t, tt.wantObjects, uids)
+			assert.Equal(t, tt.wantDeleted, got.Deleted)
+		})
+	}
+}
@@ -0,0 +1,7 @@
+package usecases
+
+// CreateCalendarFeedParams represents the input for creating a calendar feed
+type CreateCalendarFeedParams struct {
+	// Name helps telling feeds apart, e.g. the device subscribed to it
+	Name string
+}
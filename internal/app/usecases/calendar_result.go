@@ -0,0 +1,16 @@
+package usecases
+
+import "time"
+
+// CalendarFeedResult represents a calendar feed in the output
+type CalendarFeedResult struct {
+	ID        int64
+	Name      string
+	Token     string // Only set when the feed is created, the token cannot be retrieved afterwards
+	CreatedAt time.Time
+}
+
+// CalendarFeedListResult represents a list of calendar feeds
+type CalendarFeedListResult struct {
+	Feeds []CalendarFeedResult
+}
@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// CalendarUsecase defines the interface for the iCalendar feeds calendar apps subscribe to
+type CalendarUsecase interface {
+	CreateFeed(ctx context.Context, params CreateCalendarFeedParams) (*CalendarFeedResult, error)
+	ListFeeds(ctx context.Context) (*CalendarFeedListResult, error)
+	DeleteFeed(ctx context.Context, feedID int64) error
+	// WriteFeed writes the tasks that have a due date as an iCalendar file, if token belongs to a feed
+	WriteFeed(ctx context.Context, token string, w io.Writer) error
+}
+
+// calendarUsecase implements CalendarUsecase
+type calendarUsecase struct {
+	feedRepo    db.CalendarFeedRepository
+	taskUsecase TaskUsecase
+}
+
+// NewCalendarUsecase creates a new instance of CalendarUsecase
+func NewCalendarUsecase(feedRepo db.CalendarFeedRepository, taskUsecase TaskUsecase) CalendarUsecase {
+	return &calendarUsecase{
+		feedRepo:    feedRepo,
+		taskUsecase: taskUsecase,
+	}
+}
+
+// CreateFeed creates a feed with a new random token
+func (u *calendarUsecase) CreateFeed(ctx context.Context, params CreateCalendarFeedParams) (*CalendarFeedResult, error) {
+	token := rand.Text()
+	feed := &models.CalendarFeed{
+		Name:      params.Name,
+		TokenHash: hashFeedToken(token),
+	}
+	if err := u.feedRepo.Create(ctx, feed); err != nil {
+		return nil, err
+	}
+
+	result := feedModelToResult(feed)
+	result.Token = token
+
+	return &result, nil
+}
+
+// ListFeeds retrieves all feeds, without their tokens
+func (u *calendarUsecase) ListFeeds(ctx context.Context) (*CalendarFeedListResult, error) {
+	feeds, err := u.feedRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CalendarFeedResult, 0, len(feeds))
+	for _, feed := range feeds {
+		results = append(results, feedModelToResult(feed))
+	}
+
+	return &CalendarFeedListResult{Feeds: results}, nil
+}
+
+// DeleteFeed deletes a feed, its URL no longer serving the calendar
+func (u *calendarUsecase) DeleteFeed(ctx context.Context, feedID int64) error {
+	if feedID <= 0 {
+		return ErrInvalidCalendarFeedID
+	}
+
+	return u.feedRepo.Delete(ctx, feedID)
+}
+
+// WriteFeed looks the feed up by the hash of its token and exports the tasks to iCalendar
+func (u *calendarUsecase) WriteFeed(ctx context.Context, token string, w io.Writer) error {
+	if token == "" {
+		return db.ErrCalendarFeedNotFound
+	}
+
+	if _, err := u.feedRepo.GetByTokenHash(ctx, hashFeedToken(token)); err != nil {
+		return err
+	}
+
+	return u.taskUsecase.ExportTasks(ctx, TransferICal, w)
+}
+
+// hashFeedToken returns the hex-encoded SHA-256 of a feed token, as stored in the database
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// feedModelToResult converts a CalendarFeed model to CalendarFeedResult
+func feedModelToResult(feed *models.CalendarFeed) CalendarFeedResult {
+	return CalendarFeedResult{
+		ID:        feed.ID,
+		Name:      feed.Name,
+		CreatedAt: feed.CreatedAt,
+	}
+}
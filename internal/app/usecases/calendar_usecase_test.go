@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func TestCalendarUsecase_CreateFeed(t *testing.T) {
+	t.Parallel()
+
+	var stored *models.CalendarFeed
+	feedRepo := mocks.NewCalendarFeedRepository(t)
+	feedRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.CalendarFeed")).
+		Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*models.CalendarFeed)
+			stored.ID = 1
+		}).Return(nil).Once()
+	u := NewCalendarUsecase(feedRepo, nil)
+
+	got, err := u.CreateFeed(context.Background(), CreateCalendarFeedParams{Name: "Phone"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), got.ID)
+	assert.Equal(t, "Phone", got.Name)
+	assert.NotEmpty(t, got.Token)
+	assert.Equal(t, hashFeedToken(got.Token), stored.TokenHash, "only the hash of the token is stored")
+	assert.NotEqual(t, got.Token, stored.TokenHash)
+}
+
+func TestCalendarUsecase_WriteFeed(t *testing.T) {
+	t.Parallel()
+
+	dueAt := time.Date(2025, 3, 7, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		token    string
+		feedRepo func(t *testing.T) db.CalendarFeedRepository
+		taskRepo func(t *testing.T) db.TaskRepository
+		want     string
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "should write the tasks with a due date",
+			token: "secret",
+			feedRepo: func(t *testing.T) db.CalendarFeedRepository {
+				m := mocks.NewCalendarFeedRepository(t)
+				m.On("GetByTokenHash", mock.Anything, hashFeedToken("secret")).Return(&models.CalendarFeed{ID: 1}, nil)
+				return m
+			},
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("List", mock.Anything).Return([]*models.Task{
+					{ID: 1, Title: "Taxes", DueAt: &dueAt},
+					{ID: 2, Title: "Someday"},
+				}, nil)
+				return m
+			},
+			want:    "SUMMARY:Taxes",
+			wantErr: assert.NoError,
+		},
+		{
+			name:  "should reject an unknown token",
+			token: "guess",
+			feedRepo: func(t *testing.T) db.CalendarFeedRepository {
+				m := mocks.NewCalendarFeedRepository(t)
+				m.On("GetByTokenHash", mock.Anything, hashFeedToken("guess")).Return(nil, db.ErrCalendarFeedNotFound)
+				return m
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, db.ErrCalendarFeedNotFound)
+			},
+		},
+		{
+			name: "should reject an empty token",
+			feedRepo: func(t *testing.T) db.CalendarFeedRepository {
+				return mocks.NewCalendarFeedRepository(t)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, db.ErrCalendarFeedNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			taskUsecase := &taskUsecase{}
+			if tt.taskRepo != nil {
+				taskUsecase.taskRepo = tt.taskRepo(t)
+			}
+			u := NewCalendarUsecase(tt.feedRepo(t), taskUsecase)
+
+			var buf bytes.Buffer
+			err := u.WriteFeed(context.Background(), tt.token, &buf)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Contains(t, buf.String(), tt.want)
+			assert.NotContains(t, buf.String(), "Someday")
+		})
+	}
+}
+
+func TestCalendarUsecase_DeleteFeed(t *testing.T) {
+	t.Parallel()
+
+	feedRepo := mocks.NewCalendarFeedRepository(t)
+	feedRepo.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+	u := NewCalendarUsecase(feedRepo, nil)
+
+	assert.NoError(t, u.DeleteFeed(context.Background(), 1))
+	assert.ErrorIs(t, u.DeleteFeed(context.Background(), 0), ErrInvalidCalendarFeedID)
+}
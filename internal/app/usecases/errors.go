@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidTaskID is returned when a task ID is not a positive integer
+	ErrInvalidTaskID = errors.New("invalid task ID")
+
+	// ErrInvalidTaskItemID is returned when a task item ID is not a positive integer
+	ErrInvalidTaskItemID = errors.New("invalid task item ID")
+
+	// ErrTaskTitleRequired is returned when a task is created without a title
+	ErrTaskTitleRequired = errors.New("task title is required")
+
+	// ErrTaskItemTitleRequired is returned when a task item is created without a title
+	ErrTaskItemTitleRequired = errors.New("task item title is required")
+
+	// ErrInvalidPagination is returned when a limit or offset is out of range
+	ErrInvalidPagination = errors.New("invalid pagination")
+
+	// ErrInvalidIdempotencyKey is returned when an Idempotency-Key header is empty or too long
+	ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent again with a different request
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used with a different request")
+
+	// ErrIdempotencyKeyInProgress is returned when an idempotency key is sent again before its first request ended
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is in progress")
+
+	// ErrEmptyBatch is returned when a batch has no operations
+	ErrEmptyBatch = errors.New("batch has no operations")
+
+	// ErrBatchTooLarge is returned when a batch has more than MaxBatchOperations operations
+	ErrBatchTooLarge = errors.New("batch has too many operations")
+
+	// ErrUnknownBatchOperation is returned for an unsupported batch operation type
+	ErrUnknownBatchOperation = errors.New("unknown batch operation")
+
+	// ErrUnknownBatchRef is returned when an operation targets a reference not created earlier in the batch
+	ErrUnknownBatchRef = errors.New("unknown batch reference")
+
+	// ErrDuplicateBatchRef is returned when two operations of a batch declare the same reference
+	ErrDuplicateBatchRef = errors.New("duplicate batch reference")
+
+	// ErrInvalidSyncToken is returned when a sync token is malformed or was not issued by this server
+	ErrInvalidSyncToken = errors.New("invalid sync token")
+
+	// ErrSyncModifiedAtRequired is returned when an offline mutation does not say when it was made
+	ErrSyncModifiedAtRequired = errors.New("modification time is required")
+
+	// ErrUnknownTransferFormat is returned for an unsupported export or import format
+	ErrUnknownTransferFormat = errors.New("unknown format")
+
+	// ErrInvalidImportFile is returned when an import file cannot be parsed or holds invalid tasks
+	ErrInvalidImportFile = errors.New("invalid import file")
+
+	// ErrInvalidCalendarFeedID is returned when a calendar feed ID is not a positive integer
+	ErrInvalidCalendarFeedID = errors.New("invalid calendar feed ID")
+
+	// ErrCalendarObjectNotFound is returned when no task or item has the UID of a CalDAV calendar object
+	ErrCalendarObjectNotFound = errors.New("calendar object not found")
+
+	// ErrInvalidCalendarObject is returned when a CalDAV calendar object does not hold a single VTODO with a UID
+	ErrInvalidCalendarObject = errors.New("invalid calendar object")
+
+	// ErrCalendarObjectPreconditionFailed is returned when the If-Match or If-None-Match entity tag of a
+	// CalDAV request does not match the calendar object
+	ErrCalendarObjectPreconditionFailed = errors.New("calendar object precondition failed")
+
+	// ErrBulkImportUnavailable is returned when a bulk import is requested without a bulk repository
+	ErrBulkImportUnavailable = errors.New("bulk import is not available")
+
+	// ErrChangeFeedUnavailable is returned when the task change feed cannot be subscribed to
+	ErrChangeFeedUnavailable = errors.New("task change feed is not available")
+
+	// ErrInvalidLogLevel is returned when the log level is not one of zerolog's
+	ErrInvalidLogLevel = errors.New("invalid log level")
+)
+
+// BatchOperationError reports which operation of a batch failed
+// The whole batch was rolled back
+type BatchOperationError struct {
+	Index int
+	Err   error
+}
+
+// Error implements the error interface
+func (e *BatchOperationError) Error() string {
+	return fmt.Sprintf("operation %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the failed operation
+func (e *BatchOperationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,17 @@
+package usecases
+
+import "time"
+
+// HealthResult represents the outcome of the health checks
+type HealthResult struct {
+	Healthy bool
+	Checks  []HealthCheckResult
+}
+
+// HealthCheckResult represents the outcome of a single health check
+type HealthCheckResult struct {
+	Name     string
+	Healthy  bool
+	Error    string // Only set when the check failed
+	Duration time.Duration
+}
@@ -0,0 +1,142 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+)
+
+// HealthChecker checks a dependency of the application, subsystems registering theirs with HealthUsecase
+type HealthChecker interface {
+	// Name identifies the check in the reports
+	Name() string
+	// Check returns an error when the dependency cannot be used, giving up when ctx is done
+	Check(ctx context.Context) error
+}
+
+// HealthUsecase defines the interface for checking the health of the application
+type HealthUsecase interface {
+	// Register adds a check to the ones run by Check
+	Register(checker HealthChecker)
+	// Check runs every check concurrently, each within the check timeout
+	Check(ctx context.Context) *HealthResult
+}
+
+// DefaultHealthCheckTimeout is how long a check may take before it is reported as failed
+const DefaultHealthCheckTimeout = 2 * time.Second
+
+// healthUsecase implements HealthUsecase
+type healthUsecase struct {
+	mu       sync.RWMutex
+	checkers []HealthChecker
+	timeout  time.Duration
+}
+
+// NewHealthUsecase creates a new instance of HealthUsecase
+func NewHealthUsecase(timeout time.Duration, checkers ...HealthChecker) HealthUsecase {
+	return &healthUsecase{
+		checkers: checkers,
+		timeout:  timeout,
+	}
+}
+
+func (u *healthUsecase) Register(checker HealthChecker) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.checkers = append(u.checkers, checker)
+}
+
+// Check reports the checks in their registration order, the application being healthy when all of them pass
+func (u *healthUsecase) Check(ctx context.Context) *HealthResult {
+	u.mu.RLock()
+	checkers := slices.Clone(u.checkers)
+	u.mu.RUnlock()
+
+	result := &HealthResult{
+		Healthy: true,
+		Checks:  make([]HealthCheckResult, len(checkers)),
+	}
+
+	var wg sync.WaitGroup
+	for i, checker := range checkers {
+		wg.Go(func() {
+			result.Checks[i] = u.run(ctx, checker)
+		})
+	}
+	wg.Wait()
+
+	for _, check := range result.Checks {
+		result.Healthy = result.Healthy && check.Healthy
+	}
+
+	return result
+}
+
+func (u *healthUsecase) run(ctx context.Context, checker HealthChecker) HealthCheckResult {
+	ctx, cancel := context.WithTimeout(ctx, u.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := checker.Check(ctx)
+	check := HealthCheckResult{
+		Name:     checker.Name(),
+		Healthy:  err == nil,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		check.Error = err.Error()
+	}
+
+	return check
+}
+
+// healthCheckerFunc adapts a function to HealthChecker
+type healthCheckerFunc struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// NewHealthChecker returns a HealthChecker named name running check
+func NewHealthChecker(name string, check func(ctx context.Context) error) HealthChecker {
+	return &healthCheckerFunc{name: name, check: check}
+}
+
+func (c *healthCheckerFunc) Name() string {
+	return c.name
+}
+
+func (c *healthCheckerFunc) Check(ctx context.Context) error {
+	return c.check(ctx)
+}
+
+// NewDatabaseHealthCheckers returns the checks of the database: that it answers, that its schema is not behind
+// expectedVersion, and that the pool has a connection available
+// A schema ahead of expectedVersion is healthy, a newer release having migrated it during a rolling deploy
+func NewDatabaseHealthCheckers(healthRepo db.HealthRepository, expectedVersion uint) []HealthChecker {
+	return []HealthChecker{
+		NewHealthChecker("database", healthRepo.Ping),
+		NewHealthChecker("migrations", func(ctx context.Context) error {
+			version, dirty, err := healthRepo.MigrationVersion(ctx)
+			switch {
+			case err != nil:
+				return err
+			case dirty:
+				return fmt.Errorf("migration %d failed half-way", version)
+			case version < expectedVersion:
+				return fmt.Errorf("schema version %d is behind the expected version %d", version, expectedVersion)
+			}
+			return nil
+		}),
+		NewHealthChecker("pool", func(context.Context) error {
+			if acquired, total := healthRepo.PoolStat(); acquired >= total {
+				return fmt.Errorf("all %d connections are in use", total)
+			}
+			return nil
+		}),
+	}
+}
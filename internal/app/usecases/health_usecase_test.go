@@ -0,0 +1,160 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+)
+
+func TestHealthUsecase_Check(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		checkers    []HealthChecker
+		wantHealthy bool
+		wantChecks  []HealthCheckResult
+	}{
+		{
+			name:        "should be healthy without any check",
+			wantHealthy: true,
+			wantChecks:  []HealthCheckResult{},
+		},
+		{
+			name: "should report every check in order, unhealthy when one fails",
+			checkers: []HealthChecker{
+				NewHealthChecker("first", func(context.Context) error { return nil }),
+				NewHealthChecker("second", func(context.Context) error { return errors.New("down") }),
+			},
+			wantChecks: []HealthCheckResult{
+				{Name: "first", Healthy: true},
+				{Name: "second", Error: "down"},
+			},
+		},
+		{
+			name: "should fail a check that does not answer within the timeout",
+			checkers: []HealthChecker{
+				NewHealthChecker("slow", func(ctx context.Context) error {
+					<-ctx.Done()
+					return ctx.Err()
+				}),
+			},
+			wantChecks: []HealthCheckResult{
+				{Name: "slow", Error: context.DeadlineExceeded.Error()},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := NewHealthUsecase(10*time.Millisecond, tt.checkers...)
+
+			got := u.Check(context.Background())
+
+			assert.Equal(t, tt.wantHealthy, got.Healthy)
+			for i := range got.Checks {
+				got.Checks[i].Duration = 0
+			}
+			assert.Equal(t, tt.wantChecks, got.Checks)
+		})
+	}
+}
+
+func TestHealthUsecase_Register(t *testing.T) {
+	t.Parallel()
+
+	u := NewHealthUsecase(time.Second)
+	u.Register(NewHealthChecker("registered", func(context.Context) error { return errors.New("down") }))
+
+	got := u.Check(context.Background())
+
+	assert.False(t, got.Healthy)
+	assert.Len(t, got.Checks, 1)
+}
+
+func TestNewDatabaseHealthCheckers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		healthRepo func(t *testing.T) db.HealthRepository
+		wantErrors map[string]string
+	}{
+		{
+			name: "should pass when the database is reachable, migrated and has connections available",
+			healthRepo: func(t *testing.T) db.HealthRepository {
+				m := mocks.NewHealthRepository(t)
+				m.On("Ping", mock.Anything).Return(nil)
+				m.On("MigrationVersion", mock.Anything).Return(uint(5), false, nil)
+				m.On("PoolStat").Return(int32(2), int32(5))
+				return m
+			},
+			wantErrors: map[string]string{},
+		},
+		{
+			name: "should pass when the schema is ahead of the binary",
+			healthRepo: func(t *testing.T) db.HealthRepository {
+				m := mocks.NewHealthRepository(t)
+				m.On("Ping", mock.Anything).Return(nil)
+				m.On("MigrationVersion", mock.Anything).Return(uint(6), false, nil)
+				m.On("PoolStat").Return(int32(0), int32(5))
+				return m
+			},
+			wantErrors: map[string]string{},
+		},
+		{
+			name: "should fail when the database is down, behind and out of connections",
+			healthRepo: func(t *testing.T) db.HealthRepository {
+				m := mocks.NewHealthRepository(t)
+				m.On("Ping", mock.Anything).Return(errors.New("connection refused"))
+				m.On("MigrationVersion", mock.Anything).Return(uint(4), false, nil)
+				m.On("PoolStat").Return(int32(5), int32(5))
+				return m
+			},
+			wantErrors: map[string]string{
+				"database":   "connection refused",
+				"migrations": "schema version 4 is behind the expected version 5",
+				"pool":       "all 5 connections are in use",
+			},
+		},
+		{
+			name: "should fail when the last migration is dirty",
+			healthRepo: func(t *testing.T) db.HealthRepository {
+				m := mocks.NewHealthRepository(t)
+				m.On("Ping", mock.Anything).Return(nil)
+				m.On("MigrationVersion", mock.Anything).Return(uint(5), true, nil)
+				m.On("PoolStat").Return(int32(1), int32(5))
+				return m
+			},
+			wantErrors: map[string]string{"migrations": "migration 5 failed half-way"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := NewHealthUsecase(time.Second, NewDatabaseHealthCheckers(tt.healthRepo(t), 5)...)
+
+			got := u.Check(context.Background())
+
+			gotErrors := map[string]string{}
+			for _, check := range got.Checks {
+				if !check.Healthy {
+					gotErrors[check.Name] = check.Error
+				}
+			}
+			assert.Equal(t, tt.wantErrors, gotErrors)
+			assert.Equal(t, len(tt.wantErrors) == 0, got.Healthy)
+		})
+	}
+}
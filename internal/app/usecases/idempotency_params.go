@@ -0,0 +1,9 @@
+package usecases
+
+// IdempotentRequestParams represents a request sent with an Idempotency-Key header
+type IdempotentRequestParams struct {
+	Key    string
+	Method string
+	Path   string
+	Body   []byte
+}
@@ -0,0 +1,9 @@
+package usecases
+
+// IdempotentResponseResult represents the response of a request sent with an Idempotency-Key header
+type IdempotentResponseResult struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	Replayed    bool // The response was stored by an earlier request with the same key
+}
@@ -0,0 +1,131 @@
+package usecases
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// IdempotencyUsecase defines the interface for replaying requests sent with an Idempotency-Key header
+type IdempotencyUsecase interface {
+	// Execute runs handle once per key and replays its response to later requests with the same key
+	Execute(ctx context.Context, params IdempotentRequestParams, handle func() IdempotentResponseResult) (*IdempotentResponseResult, error)
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+const (
+	// DefaultIdempotencyKeyTTL is how long a response is replayed for a given key
+	DefaultIdempotencyKeyTTL = 24 * time.Hour
+	// MaxIdempotencyKeyLength is the longest key accepted
+	MaxIdempotencyKeyLength = 255
+	// idempotencyClaimTTL is how long a key stays in progress, e.g. when the server stopped while handling it
+	idempotencyClaimTTL = 10 * time.Minute
+)
+
+// idempotencyUsecase implements IdempotencyUsecase
+type idempotencyUsecase struct {
+	idempotencyRepo db.IdempotencyRepository
+	ttl             time.Duration
+}
+
+// NewIdempotencyUsecase creates a new instance of IdempotencyUsecase
+func NewIdempotencyUsecase(idempotencyRepo db.IdempotencyRepository, ttl time.Duration) IdempotencyUsecase {
+	return &idempotencyUsecase{
+		idempotencyRepo: idempotencyRepo,
+		ttl:             ttl,
+	}
+}
+
+// Execute claims the key before handle runs and stores the response once it returned, without holding a database
+// connection in between: concurrent duplicates get ErrIdempotencyKeyInProgress
+// Server errors are not stored, letting clients retry them with the same key
+func (u *idempotencyUsecase) Execute(ctx context.Context, params IdempotentRequestParams, handle func() IdempotentResponseResult) (*IdempotentResponseResult, error) {
+	if params.Key == "" || len(params.Key) > MaxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	fingerprint := requestFingerprint(params)
+
+	// The token ties the response to this claim, which another request takes over once it expired
+	claimToken := uuid.NewString()
+	now := time.Now()
+	live, err := u.idempotencyRepo.Claim(ctx, &models.IdempotencyKey{
+		Key:         params.Key,
+		RequestHash: fingerprint,
+		ClaimToken:  claimToken,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(idempotencyClaimTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Live key: replay or reject
+	if live != nil {
+		if live.RequestHash != fingerprint {
+			return nil, ErrIdempotencyKeyReused
+		}
+		if live.ResponseStatus == 0 {
+			return nil, ErrIdempotencyKeyInProgress
+		}
+
+		return &IdempotentResponseResult{
+			StatusCode:  live.ResponseStatus,
+			ContentType: live.ResponseContentType,
+			Body:        live.ResponseBody,
+			Replayed:    true,
+		}, nil
+	}
+
+	// The response goes out even when the client is gone, its outcome is recorded the same
+	recordCtx := context.WithoutCancel(ctx)
+	defer func() {
+		if r := recover(); r != nil {
+			_ = u.idempotencyRepo.Release(recordCtx, params.Key, claimToken)
+			panic(r)
+		}
+	}()
+
+	response := handle()
+	if response.StatusCode >= http.StatusInternalServerError {
+		return &response, u.idempotencyRepo.Release(recordCtx, params.Key, claimToken)
+	}
+
+	now = time.Now()
+	err = u.idempotencyRepo.Complete(recordCtx, &models.IdempotencyKey{
+		Key:                 params.Key,
+		RequestHash:         fingerprint,
+		ClaimToken:          claimToken,
+		ResponseStatus:      response.StatusCode,
+		ResponseContentType: response.ContentType,
+		ResponseBody:        response.Body,
+		CreatedAt:           now,
+		ExpiresAt:           now.Add(u.ttl),
+	})
+
+	return &response, err
+}
+
+// PurgeExpired deletes the keys whose TTL elapsed
+func (u *idempotencyUsecase) PurgeExpired(ctx context.Context) (int64, error) {
+	return u.idempotencyRepo.DeleteExpired(ctx, time.Now())
+}
+
+// requestFingerprint identifies a request by its method, path and body
+func requestFingerprint(params IdempotentRequestParams) string {
+	hash := sha256.New()
+	hash.Write([]byte(params.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(params.Path))
+	hash.Write([]byte{0})
+	hash.Write(params.Body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
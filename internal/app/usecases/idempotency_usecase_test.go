@@ -0,0 +1,249 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func TestIdempotencyUsecase_Execute(t *testing.T) {
+	t.Parallel()
+
+	params := IdempotentRequestParams{Key: "key-1", Method: http.MethodPost, Path: "/api/tasks", Body: []byte(`{"title":"Shopping"}`)}
+	created := IdempotentResponseResult{StatusCode: http.StatusCreated, ContentType: "application/json", Body: []byte(`{"id":1}`)}
+	claimed := mock.MatchedBy(func(claim *models.IdempotencyKey) bool {
+		return claim.Key == "key-1" && claim.RequestHash == requestFingerprint(params) && claim.ClaimToken != "" &&
+			claim.ResponseStatus == 0 && claim.ExpiresAt.After(time.Now())
+	})
+	completed := mock.MatchedBy(func(record *models.IdempotencyKey) bool {
+		return record.Key == "key-1" && record.RequestHash == requestFingerprint(params) && record.ClaimToken != "" &&
+			record.ResponseStatus == http.StatusCreated && string(record.ResponseBody) == `{"id":1}` &&
+			record.ExpiresAt.After(time.Now().Add(59*time.Minute))
+	})
+
+	tests := []struct {
+		name            string
+		params          IdempotentRequestParams
+		idempotencyRepo func(t *testing.T) *mocks.IdempotencyRepository
+		response        IdempotentResponseResult
+		wantHandled     bool
+		want            *IdempotentResponseResult
+		wantErr         assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "should run the request and store its response for a new key",
+			params: params,
+			idempotencyRepo: func(t *testing.T) *mocks.IdempotencyRepository {
+				m := mocks.NewIdempotencyRepository(t)
+				m.On("Claim", mock.Anything, claimed).Return(nil, nil).Once()
+				m.On("Complete", mock.Anything, completed).Return(nil).Once()
+				return m
+			},
+			response:    created,
+			wantHandled: true,
+			want:        &created,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:   "should replay the stored response for the same request",
+			params: params,
+			idempotencyRepo: func(t *testing.T) *mocks.IdempotencyRepository {
+				m := mocks.NewIdempotencyRepository(t)
+				m.On("Claim", mock.Anything, claimed).Return(&models.IdempotencyKey{
+					Key:                 "key-1",
+					RequestHash:         requestFingerprint(params),
+					ResponseStatus:      http.StatusCreated,
+					ResponseContentType: "application/json",
+					ResponseBody:        []byte(`{"id":1}`),
+					ExpiresAt:           time.Now().Add(time.Hour),
+				}, nil).Once()
+				return m
+			},
+			want: &IdempotentResponseResult{
+				StatusCode:  http.StatusCreated,
+				ContentType: "application/json",
+				Body:        []byte(`{"id":1}`),
+				Replayed:    true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "should reject a key reused with a different body",
+			params: IdempotentRequestParams{Key: "key-1", Method: http.MethodPost, Path: "/api/tasks", Body: []byte(`{"title":"Work"}`)},
+			idempotencyRepo: func(t *testing.T) *mocks.IdempotencyRepository {
+				m := mocks.NewIdempotencyRepository(t)
+				m.On("Claim", mock.Anything, mock.Anything).Return(&models.IdempotencyKey{
+					Key:            "key-1",
+					RequestHash:    requestFingerprint(params),
+					ResponseStatus: http.StatusCreated,
+					ExpiresAt:      time.Now().Add(time.Hour),
+				}, nil).Once()
+				return m
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+			},
+		},
+		{
+			name:   "should reject a duplicate while the first request is in progress",
+			params: params,
+			idempotencyRepo: func(t *testing.T) *mocks.IdempotencyRepository {
+				m := mocks.NewIdempotencyRepository(t)
+				m.On("Claim", mock.Anything, claimed).Return(&models.IdempotencyKey{
+					Key:         "key-1",
+					RequestHash: requestFingerprint(params),
+					ExpiresAt:   time.Now().Add(time.Minute),
+				}, nil).Once()
+				return m
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrIdempotencyKeyInProgress)
+			},
+		},
+		{
+			name:   "should release the key on server errors, so that they are not stored",
+			params: params,
+			idempotencyRepo: func(t *testing.T) *mocks.IdempotencyRepository {
+				m := mocks.NewIdempotencyRepository(t)
+				m.On("Claim", mock.Anything, claimed).Return(nil, nil).Once()
+				m.On("Release", mock.Anything, "key-1", mock.AnythingOfType("string")).Return(nil).Once()
+				return m
+			},
+			response:    IdempotentResponseResult{StatusCode: http.StatusInternalServerError},
+			wantHandled: true,
+			want:        &IdempotentResponseResult{StatusCode: http.StatusInternalServerError},
+			wantErr:     assert.NoError,
+		},
+		{
+			name:   "should return the response with the error when it cannot be stored",
+			params: params,
+			idempotencyRepo: func(t *testing.T) *mocks.IdempotencyRepository {
+				m := mocks.NewIdempotencyRepository(t)
+				m.On("Claim", mock.Anything, claimed).Return(nil, nil).Once()
+				m.On("Complete", mock.Anything, completed).Return(errors.New("database error")).Once()
+				return m
+			},
+			response:    created,
+			wantHandled: true,
+			want:        &created,
+			wantErr:     assert.Error,
+		},
+		{
+			name:   "should report a claim taken over while the request ran",
+			params: params,
+			idempotencyRepo: func(t *testing.T) *mocks.IdempotencyRepository {
+				m := mocks.NewIdempotencyRepository(t)
+				m.On("Claim", mock.Anything, claimed).Return(nil, nil).Once()
+				m.On("Complete", mock.Anything, completed).Return(db.ErrIdempotencyClaimLost).Once()
+				return m
+			},
+			response:    created,
+			wantHandled: true,
+			want:        &created,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, db.ErrIdempotencyClaimLost)
+			},
+		},
+		{
+			name:   "should return error when the key cannot be claimed",
+			params: params,
+			idempotencyRepo: func(t *testing.T) *mocks.IdempotencyRepository {
+				m := mocks.NewIdempotencyRepository(t)
+				m.On("Claim", mock.Anything, claimed).Return(nil, errors.New("database error")).Once()
+				return m
+			},
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := NewIdempotencyUsecase(tt.idempotencyRepo(t), time.Hour)
+
+			handled := false
+			got, err := u.Execute(context.Background(), tt.params, func() IdempotentResponseResult {
+				handled = true
+				return tt.response
+			})
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantHandled, handled)
+		})
+	}
+
+	t.Run("should complete the key with the token it was claimed with", func(t *testing.T) {
+		t.Parallel()
+
+		var claimToken string
+		m := mocks.NewIdempotencyRepository(t)
+		m.On("Claim", mock.Anything, claimed).Run(func(args mock.Arguments) {
+			claimToken = args.Get(1).(*models.IdempotencyKey).ClaimToken
+		}).Return(nil, nil).Once()
+		m.On("Complete", mock.Anything, mock.MatchedBy(func(record *models.IdempotencyKey) bool {
+			return record.ClaimToken == claimToken
+		})).Return(nil).Once()
+		u := NewIdempotencyUsecase(m, time.Hour)
+
+		_, err := u.Execute(context.Background(), params, func() IdempotentResponseResult {
+			return created
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("should release the key when the request panics", func(t *testing.T) {
+		t.Parallel()
+
+		m := mocks.NewIdempotencyRepository(t)
+		m.On("Claim", mock.Anything, claimed).Return(nil, nil).Once()
+		m.On("Release", mock.Anything, "key-1", mock.AnythingOfType("string")).Return(nil).Once()
+		u := NewIdempotencyUsecase(m, time.Hour)
+
+		assert.Panics(t, func() {
+			_, _ = u.Execute(context.Background(), params, func() IdempotentResponseResult {
+				panic("handler failed")
+			})
+		})
+	})
+}
+
+func TestIdempotencyUsecase_Execute_InvalidKey(t *testing.T) {
+	t.Parallel()
+
+	u := NewIdempotencyUsecase(mocks.NewIdempotencyRepository(t), time.Hour)
+
+	_, err := u.Execute(context.Background(), IdempotentRequestParams{Key: ""}, nil)
+	assert.ErrorIs(t, err, ErrInvalidIdempotencyKey)
+
+	_, err = u.Execute(context.Background(), IdempotentRequestParams{Key: string(make([]byte, MaxIdempotencyKeyLength+1))}, nil)
+	assert.ErrorIs(t, err, ErrInvalidIdempotencyKey)
+}
+
+func TestIdempotencyUsecase_PurgeExpired(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewIdempotencyRepository(t)
+	m.On("DeleteExpired", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(3), nil).Once()
+	m.On("DeleteExpired", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(0), errors.New("database error")).Once()
+
+	u := NewIdempotencyUsecase(m, time.Hour)
+
+	purged, err := u.PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+
+	_, err = u.PurgeExpired(context.Background())
+	assert.Error(t, err)
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"io"
 
 	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
 	mock "github.com/stretchr/testify/mock"
@@ -38,6 +39,148 @@ func (_m *TaskUsecase) EXPECT() *TaskUsecase_Expecter {
 	return &TaskUsecase_Expecter{mock: &_m.Mock}
 }
 
+// AddTaskItem provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) AddTaskItem(ctx context.Context, taskID int64, params usecases.CreateTaskItemParams) (*usecases.TaskItemResult, error) {
+	ret := _mock.Called(ctx, taskID, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTaskItem")
+	}
+
+	var r0 *usecases.TaskItemResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, usecases.CreateTaskItemParams) (*usecases.TaskItemResult, error)); ok {
+		return returnFunc(ctx, taskID, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, usecases.CreateTaskItemParams) *usecases.TaskItemResult); ok {
+		r0 = returnFunc(ctx, taskID, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.TaskItemResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, usecases.CreateTaskItemParams) error); ok {
+		r1 = returnFunc(ctx, taskID, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_AddTaskItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddTaskItem'
+type TaskUsecase_AddTaskItem_Call struct {
+	*mock.Call
+}
+
+// AddTaskItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID int64
+//   - params usecases.CreateTaskItemParams
+func (_e *TaskUsecase_Expecter) AddTaskItem(ctx interface{}, taskID interface{}, params interface{}) *TaskUsecase_AddTaskItem_Call {
+	return &TaskUsecase_AddTaskItem_Call{Call: _e.mock.On("AddTaskItem", ctx, taskID, params)}
+}
+
+func (_c *TaskUsecase_AddTaskItem_Call) Run(run func(ctx context.Context, taskID int64, params usecases.CreateTaskItemParams)) *TaskUsecase_AddTaskItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 usecases.CreateTaskItemParams
+		if args[2] != nil {
+			arg2 = args[2].(usecases.CreateTaskItemParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_AddTaskItem_Call) Return(taskItemResult *usecases.TaskItemResult, err error) *TaskUsecase_AddTaskItem_Call {
+	_c.Call.Return(taskItemResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_AddTaskItem_Call) RunAndReturn(run func(ctx context.Context, taskID int64, params usecases.CreateTaskItemParams) (*usecases.TaskItemResult, error)) *TaskUsecase_AddTaskItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ApplyChanges provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) ApplyChanges(ctx context.Context, params usecases.ApplyChangesParams) (*usecases.ApplyChangesResult, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyChanges")
+	}
+
+	var r0 *usecases.ApplyChangesResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.ApplyChangesParams) (*usecases.ApplyChangesResult, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.ApplyChangesParams) *usecases.ApplyChangesResult); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.ApplyChangesResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, usecases.ApplyChangesParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_ApplyChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyChanges'
+type TaskUsecase_ApplyChanges_Call struct {
+	*mock.Call
+}
+
+// ApplyChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params usecases.ApplyChangesParams
+func (_e *TaskUsecase_Expecter) ApplyChanges(ctx interface{}, params interface{}) *TaskUsecase_ApplyChanges_Call {
+	return &TaskUsecase_ApplyChanges_Call{Call: _e.mock.On("ApplyChanges", ctx, params)}
+}
+
+func (_c *TaskUsecase_ApplyChanges_Call) Run(run func(ctx context.Context, params usecases.ApplyChangesParams)) *TaskUsecase_ApplyChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 usecases.ApplyChangesParams
+		if args[1] != nil {
+			arg1 = args[1].(usecases.ApplyChangesParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_ApplyChanges_Call) Return(applyChangesResult *usecases.ApplyChangesResult, err error) *TaskUsecase_ApplyChanges_Call {
+	_c.Call.Return(applyChangesResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_ApplyChanges_Call) RunAndReturn(run func(ctx context.Context, params usecases.ApplyChangesParams) (*usecases.ApplyChangesResult, error)) *TaskUsecase_ApplyChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTask provides a mock function for the type TaskUsecase
 func (_mock *TaskUsecase) CreateTask(ctx context.Context, params usecases.CreateTaskParams) (*usecases.TaskResult, error) {
 	ret := _mock.Called(ctx, params)
@@ -163,55 +306,118 @@ func (_c *TaskUsecase_DeleteTask_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
-// GetTask provides a mock function for the type TaskUsecase
-func (_mock *TaskUsecase) GetTask(ctx context.Context, taskID int64) (*usecases.TaskResult, error) {
-	ret := _mock.Called(ctx, taskID)
+// DeleteTaskItem provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) DeleteTaskItem(ctx context.Context, taskID int64, itemID int64) error {
+	ret := _mock.Called(ctx, taskID, itemID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTask")
+		panic("no return value specified for DeleteTaskItem")
 	}
 
-	var r0 *usecases.TaskResult
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = returnFunc(ctx, taskID, itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskUsecase_DeleteTaskItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTaskItem'
+type TaskUsecase_DeleteTaskItem_Call struct {
+	*mock.Call
+}
+
+// DeleteTaskItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID int64
+//   - itemID int64
+func (_e *TaskUsecase_Expecter) DeleteTaskItem(ctx interface{}, taskID interface{}, itemID interface{}) *TaskUsecase_DeleteTaskItem_Call {
+	return &TaskUsecase_DeleteTaskItem_Call{Call: _e.mock.On("DeleteTaskItem", ctx, taskID, itemID)}
+}
+
+func (_c *TaskUsecase_DeleteTaskItem_Call) Run(run func(ctx context.Context, taskID int64, itemID int64)) *TaskUsecase_DeleteTaskItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_DeleteTaskItem_Call) Return(err error) *TaskUsecase_DeleteTaskItem_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskUsecase_DeleteTaskItem_Call) RunAndReturn(run func(ctx context.Context, taskID int64, itemID int64) error) *TaskUsecase_DeleteTaskItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExecuteBatch provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) ExecuteBatch(ctx context.Context, params usecases.BatchParams) (*usecases.BatchResult, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteBatch")
+	}
+
+	var r0 *usecases.BatchResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*usecases.TaskResult, error)); ok {
-		return returnFunc(ctx, taskID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.BatchParams) (*usecases.BatchResult, error)); ok {
+		return returnFunc(ctx, params)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *usecases.TaskResult); ok {
-		r0 = returnFunc(ctx, taskID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.BatchParams) *usecases.BatchResult); ok {
+		r0 = returnFunc(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*usecases.TaskResult)
+			r0 = ret.Get(0).(*usecases.BatchResult)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
-		r1 = returnFunc(ctx, taskID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, usecases.BatchParams) error); ok {
+		r1 = returnFunc(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// TaskUsecase_GetTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTask'
-type TaskUsecase_GetTask_Call struct {
+// TaskUsecase_ExecuteBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecuteBatch'
+type TaskUsecase_ExecuteBatch_Call struct {
 	*mock.Call
 }
 
-// GetTask is a helper method to define mock.On call
+// ExecuteBatch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - taskID int64
-func (_e *TaskUsecase_Expecter) GetTask(ctx interface{}, taskID interface{}) *TaskUsecase_GetTask_Call {
-	return &TaskUsecase_GetTask_Call{Call: _e.mock.On("GetTask", ctx, taskID)}
+//   - params usecases.BatchParams
+func (_e *TaskUsecase_Expecter) ExecuteBatch(ctx interface{}, params interface{}) *TaskUsecase_ExecuteBatch_Call {
+	return &TaskUsecase_ExecuteBatch_Call{Call: _e.mock.On("ExecuteBatch", ctx, params)}
 }
 
-func (_c *TaskUsecase_GetTask_Call) Run(run func(ctx context.Context, taskID int64)) *TaskUsecase_GetTask_Call {
+func (_c *TaskUsecase_ExecuteBatch_Call) Run(run func(ctx context.Context, params usecases.BatchParams)) *TaskUsecase_ExecuteBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int64
+		var arg1 usecases.BatchParams
 		if args[1] != nil {
-			arg1 = args[1].(int64)
+			arg1 = args[1].(usecases.BatchParams)
 		}
 		run(
 			arg0,
@@ -221,74 +427,880 @@ func (_c *TaskUsecase_GetTask_Call) Run(run func(ctx context.Context, taskID int
 	return _c
 }
 
-func (_c *TaskUsecase_GetTask_Call) Return(taskResult *usecases.TaskResult, err error) *TaskUsecase_GetTask_Call {
-	_c.Call.Return(taskResult, err)
+func (_c *TaskUsecase_ExecuteBatch_Call) Return(batchResult *usecases.BatchResult, err error) *TaskUsecase_ExecuteBatch_Call {
+	_c.Call.Return(batchResult, err)
 	return _c
 }
 
-func (_c *TaskUsecase_GetTask_Call) RunAndReturn(run func(ctx context.Context, taskID int64) (*usecases.TaskResult, error)) *TaskUsecase_GetTask_Call {
+func (_c *TaskUsecase_ExecuteBatch_Call) RunAndReturn(run func(ctx context.Context, params usecases.BatchParams) (*usecases.BatchResult, error)) *TaskUsecase_ExecuteBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListTasks provides a mock function for the type TaskUsecase
-func (_mock *TaskUsecase) ListTasks(ctx context.Context) (*usecases.TaskListResult, error) {
-	ret := _mock.Called(ctx)
+// ExportTasks provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) ExportTasks(ctx context.Context, format usecases.TransferFormat, w io.Writer) error {
+	ret := _mock.Called(ctx, format, w)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListTasks")
+		panic("no return value specified for ExportTasks")
 	}
 
-	var r0 *usecases.TaskListResult
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.TransferFormat, io.Writer) error); ok {
+		r0 = returnFunc(ctx, format, w)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskUsecase_ExportTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTasks'
+type TaskUsecase_ExportTasks_Call struct {
+	*mock.Call
+}
+
+// ExportTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - format usecases.TransferFormat
+//   - w io.Writer
+func (_e *TaskUsecase_Expecter) ExportTasks(ctx interface{}, format interface{}, w interface{}) *TaskUsecase_ExportTasks_Call {
+	return &TaskUsecase_ExportTasks_Call{Call: _e.mock.On("ExportTasks", ctx, format, w)}
+}
+
+func (_c *TaskUsecase_ExportTasks_Call) Run(run func(ctx context.Context, format usecases.TransferFormat, w io.Writer)) *TaskUsecase_ExportTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 usecases.TransferFormat
+		if args[1] != nil {
+			arg1 = args[1].(usecases.TransferFormat)
+		}
+		var arg2 io.Writer
+		if args[2] != nil {
+			arg2 = args[2].(io.Writer)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_ExportTasks_Call) Return(err error) *TaskUsecase_ExportTasks_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskUsecase_ExportTasks_Call) RunAndReturn(run func(ctx context.Context, format usecases.TransferFormat, w io.Writer) error) *TaskUsecase_ExportTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetChanges provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) GetChanges(ctx context.Context, params usecases.GetChangesParams) (*usecases.ChangesResult, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetChanges")
+	}
+
+	var r0 *usecases.ChangesResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) (*usecases.TaskListResult, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.GetChangesParams) (*usecases.ChangesResult, error)); ok {
+		return returnFunc(ctx, params)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) *usecases.TaskListResult); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.GetChangesParams) *usecases.ChangesResult); ok {
+		r0 = returnFunc(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*usecases.TaskListResult)
+			r0 = ret.Get(0).(*usecases.ChangesResult)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, usecases.GetChangesParams) error); ok {
+		r1 = returnFunc(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// TaskUsecase_ListTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTasks'
-type TaskUsecase_ListTasks_Call struct {
+// TaskUsecase_GetChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetChanges'
+type TaskUsecase_GetChanges_Call struct {
 	*mock.Call
 }
 
-// ListTasks is a helper method to define mock.On call
+// GetChanges is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *TaskUsecase_Expecter) ListTasks(ctx interface{}) *TaskUsecase_ListTasks_Call {
-	return &TaskUsecase_ListTasks_Call{Call: _e.mock.On("ListTasks", ctx)}
+//   - params usecases.GetChangesParams
+func (_e *TaskUsecase_Expecter) GetChanges(ctx interface{}, params interface{}) *TaskUsecase_GetChanges_Call {
+	return &TaskUsecase_GetChanges_Call{Call: _e.mock.On("GetChanges", ctx, params)}
 }
 
-func (_c *TaskUsecase_ListTasks_Call) Run(run func(ctx context.Context)) *TaskUsecase_ListTasks_Call {
+func (_c *TaskUsecase_GetChanges_Call) Run(run func(ctx context.Context, params usecases.GetChangesParams)) *TaskUsecase_GetChanges_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 usecases.GetChangesParams
+		if args[1] != nil {
+			arg1 = args[1].(usecases.GetChangesParams)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *TaskUsecase_ListTasks_Call) Return(taskListResult *usecases.TaskListResult, err error) *TaskUsecase_ListTasks_Call {
-	_c.Call.Return(taskListResult, err)
+func (_c *TaskUsecase_GetChanges_Call) Return(changesResult *usecases.ChangesResult, err error) *TaskUsecase_GetChanges_Call {
+	_c.Call.Return(changesResult, err)
 	return _c
 }
 
-func (_c *TaskUsecase_ListTasks_Call) RunAndReturn(run func(ctx context.Context) (*usecases.TaskListResult, error)) *TaskUsecase_ListTasks_Call {
+func (_c *TaskUsecase_GetChanges_Call) RunAndReturn(run func(ctx context.Context, params usecases.GetChangesParams) (*usecases.ChangesResult, error)) *TaskUsecase_GetChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTask provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) GetTask(ctx context.Context, taskID int64) (*usecases.TaskResult, error) {
+	ret := _mock.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTask")
+	}
+
+	var r0 *usecases.TaskResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (*usecases.TaskResult, error)); ok {
+		return returnFunc(ctx, taskID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) *usecases.TaskResult); ok {
+		r0 = returnFunc(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.TaskResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_GetTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTask'
+type TaskUsecase_GetTask_Call struct {
+	*mock.Call
+}
+
+// GetTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID int64
+func (_e *TaskUsecase_Expecter) GetTask(ctx interface{}, taskID interface{}) *TaskUsecase_GetTask_Call {
+	return &TaskUsecase_GetTask_Call{Call: _e.mock.On("GetTask", ctx, taskID)}
+}
+
+func (_c *TaskUsecase_GetTask_Call) Run(run func(ctx context.Context, taskID int64)) *TaskUsecase_GetTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_GetTask_Call) Return(taskResult *usecases.TaskResult, err error) *TaskUsecase_GetTask_Call {
+	_c.Call.Return(taskResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_GetTask_Call) RunAndReturn(run func(ctx context.Context, taskID int64) (*usecases.TaskResult, error)) *TaskUsecase_GetTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTaskByICalUID provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) GetTaskByICalUID(ctx context.Context, uid string) (*usecases.TaskResult, error) {
+	ret := _mock.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskByICalUID")
+	}
+
+	var r0 *usecases.TaskResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*usecases.TaskResult, error)); ok {
+		return returnFunc(ctx, uid)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *usecases.TaskResult); ok {
+		r0 = returnFunc(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.TaskResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_GetTaskByICalUID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskByICalUID'
+type TaskUsecase_GetTaskByICalUID_Call struct {
+	*mock.Call
+}
+
+// GetTaskByICalUID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - uid string
+func (_e *TaskUsecase_Expecter) GetTaskByICalUID(ctx interface{}, uid interface{}) *TaskUsecase_GetTaskByICalUID_Call {
+	return &TaskUsecase_GetTaskByICalUID_Call{Call: _e.mock.On("GetTaskByICalUID", ctx, uid)}
+}
+
+func (_c *TaskUsecase_GetTaskByICalUID_Call) Run(run func(ctx context.Context, uid string)) *TaskUsecase_GetTaskByICalUID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_GetTaskByICalUID_Call) Return(taskResult *usecases.TaskResult, err error) *TaskUsecase_GetTaskByICalUID_Call {
+	_c.Call.Return(taskResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_GetTaskByICalUID_Call) RunAndReturn(run func(ctx context.Context, uid string) (*usecases.TaskResult, error)) *TaskUsecase_GetTaskByICalUID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTaskStats provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) GetTaskStats(ctx context.Context) (*usecases.TaskStatsResult, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskStats")
+	}
+
+	var r0 *usecases.TaskStatsResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*usecases.TaskStatsResult, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *usecases.TaskStatsResult); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.TaskStatsResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_GetTaskStats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskStats'
+type TaskUsecase_GetTaskStats_Call struct {
+	*mock.Call
+}
+
+// GetTaskStats is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TaskUsecase_Expecter) GetTaskStats(ctx interface{}) *TaskUsecase_GetTaskStats_Call {
+	return &TaskUsecase_GetTaskStats_Call{Call: _e.mock.On("GetTaskStats", ctx)}
+}
+
+func (_c *TaskUsecase_GetTaskStats_Call) Run(run func(ctx context.Context)) *TaskUsecase_GetTaskStats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_GetTaskStats_Call) Return(taskStatsResult *usecases.TaskStatsResult, err error) *TaskUsecase_GetTaskStats_Call {
+	_c.Call.Return(taskStatsResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_GetTaskStats_Call) RunAndReturn(run func(ctx context.Context) (*usecases.TaskStatsResult, error)) *TaskUsecase_GetTaskStats_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ImportTasks provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) ImportTasks(ctx context.Context, params usecases.ImportTasksParams) (*usecases.ImportResult, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportTasks")
+	}
+
+	var r0 *usecases.ImportResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.ImportTasksParams) (*usecases.ImportResult, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.ImportTasksParams) *usecases.ImportResult); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.ImportResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, usecases.ImportTasksParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_ImportTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ImportTasks'
+type TaskUsecase_ImportTasks_Call struct {
+	*mock.Call
+}
+
+// ImportTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params usecases.ImportTasksParams
+func (_e *TaskUsecase_Expecter) ImportTasks(ctx interface{}, params interface{}) *TaskUsecase_ImportTasks_Call {
+	return &TaskUsecase_ImportTasks_Call{Call: _e.mock.On("ImportTasks", ctx, params)}
+}
+
+func (_c *TaskUsecase_ImportTasks_Call) Run(run func(ctx context.Context, params usecases.ImportTasksParams)) *TaskUsecase_ImportTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 usecases.ImportTasksParams
+		if args[1] != nil {
+			arg1 = args[1].(usecases.ImportTasksParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_ImportTasks_Call) Return(importResult *usecases.ImportResult, err error) *TaskUsecase_ImportTasks_Call {
+	_c.Call.Return(importResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_ImportTasks_Call) RunAndReturn(run func(ctx context.Context, params usecases.ImportTasksParams) (*usecases.ImportResult, error)) *TaskUsecase_ImportTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTaskItems provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) ListTaskItems(ctx context.Context, taskIDs []int64) (map[int64][]usecases.TaskItemResult, error) {
+	ret := _mock.Called(ctx, taskIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTaskItems")
+	}
+
+	var r0 map[int64][]usecases.TaskItemResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int64) (map[int64][]usecases.TaskItemResult, error)); ok {
+		return returnFunc(ctx, taskIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []int64) map[int64][]usecases.TaskItemResult); ok {
+		r0 = returnFunc(ctx, taskIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[int64][]usecases.TaskItemResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []int64) error); ok {
+		r1 = returnFunc(ctx, taskIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_ListTaskItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTaskItems'
+type TaskUsecase_ListTaskItems_Call struct {
+	*mock.Call
+}
+
+// ListTaskItems is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskIDs []int64
+func (_e *TaskUsecase_Expecter) ListTaskItems(ctx interface{}, taskIDs interface{}) *TaskUsecase_ListTaskItems_Call {
+	return &TaskUsecase_ListTaskItems_Call{Call: _e.mock.On("ListTaskItems", ctx, taskIDs)}
+}
+
+func (_c *TaskUsecase_ListTaskItems_Call) Run(run func(ctx context.Context, taskIDs []int64)) *TaskUsecase_ListTaskItems_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []int64
+		if args[1] != nil {
+			arg1 = args[1].([]int64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_ListTaskItems_Call) Return(int64ToTaskItemResults map[int64][]usecases.TaskItemResult, err error) *TaskUsecase_ListTaskItems_Call {
+	_c.Call.Return(int64ToTaskItemResults, err)
+	return _c
+}
+
+func (_c *TaskUsecase_ListTaskItems_Call) RunAndReturn(run func(ctx context.Context, taskIDs []int64) (map[int64][]usecases.TaskItemResult, error)) *TaskUsecase_ListTaskItems_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTasks provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) ListTasks(ctx context.Context) (*usecases.TaskListResult, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTasks")
+	}
+
+	var r0 *usecases.TaskListResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (*usecases.TaskListResult, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) *usecases.TaskListResult); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.TaskListResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_ListTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTasks'
+type TaskUsecase_ListTasks_Call struct {
+	*mock.Call
+}
+
+// ListTasks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TaskUsecase_Expecter) ListTasks(ctx interface{}) *TaskUsecase_ListTasks_Call {
+	return &TaskUsecase_ListTasks_Call{Call: _e.mock.On("ListTasks", ctx)}
+}
+
+func (_c *TaskUsecase_ListTasks_Call) Run(run func(ctx context.Context)) *TaskUsecase_ListTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_ListTasks_Call) Return(taskListResult *usecases.TaskListResult, err error) *TaskUsecase_ListTasks_Call {
+	_c.Call.Return(taskListResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_ListTasks_Call) RunAndReturn(run func(ctx context.Context) (*usecases.TaskListResult, error)) *TaskUsecase_ListTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SearchTasks provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) SearchTasks(ctx context.Context, params usecases.SearchTasksParams) (*usecases.TaskPageResult, error) {
+	ret := _mock.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchTasks")
+	}
+
+	var r0 *usecases.TaskPageResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.SearchTasksParams) (*usecases.TaskPageResult, error)); ok {
+		return returnFunc(ctx, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, usecases.SearchTasksParams) *usecases.TaskPageResult); ok {
+		r0 = returnFunc(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.TaskPageResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, usecases.SearchTasksParams) error); ok {
+		r1 = returnFunc(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_SearchTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchTasks'
+type TaskUsecase_SearchTasks_Call struct {
+	*mock.Call
+}
+
+// SearchTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - params usecases.SearchTasksParams
+func (_e *TaskUsecase_Expecter) SearchTasks(ctx interface{}, params interface{}) *TaskUsecase_SearchTasks_Call {
+	return &TaskUsecase_SearchTasks_Call{Call: _e.mock.On("SearchTasks", ctx, params)}
+}
+
+func (_c *TaskUsecase_SearchTasks_Call) Run(run func(ctx context.Context, params usecases.SearchTasksParams)) *TaskUsecase_SearchTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 usecases.SearchTasksParams
+		if args[1] != nil {
+			arg1 = args[1].(usecases.SearchTasksParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_SearchTasks_Call) Return(taskPageResult *usecases.TaskPageResult, err error) *TaskUsecase_SearchTasks_Call {
+	_c.Call.Return(taskPageResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_SearchTasks_Call) RunAndReturn(run func(ctx context.Context, params usecases.SearchTasksParams) (*usecases.TaskPageResult, error)) *TaskUsecase_SearchTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StreamTasks provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) StreamTasks(ctx context.Context, fn func(task *usecases.TaskResult) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StreamTasks")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(task *usecases.TaskResult) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// TaskUsecase_StreamTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamTasks'
+type TaskUsecase_StreamTasks_Call struct {
+	*mock.Call
+}
+
+// StreamTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(task *usecases.TaskResult) error
+func (_e *TaskUsecase_Expecter) StreamTasks(ctx interface{}, fn interface{}) *TaskUsecase_StreamTasks_Call {
+	return &TaskUsecase_StreamTasks_Call{Call: _e.mock.On("StreamTasks", ctx, fn)}
+}
+
+func (_c *TaskUsecase_StreamTasks_Call) Run(run func(ctx context.Context, fn func(task *usecases.TaskResult) error)) *TaskUsecase_StreamTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(task *usecases.TaskResult) error
+		if args[1] != nil {
+			arg1 = args[1].(func(task *usecases.TaskResult) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_StreamTasks_Call) Return(err error) *TaskUsecase_StreamTasks_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *TaskUsecase_StreamTasks_Call) RunAndReturn(run func(ctx context.Context, fn func(task *usecases.TaskResult) error) error) *TaskUsecase_StreamTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTask provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) UpdateTask(ctx context.Context, taskID int64, params usecases.UpdateTaskParams) (*usecases.TaskResult, error) {
+	ret := _mock.Called(ctx, taskID, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTask")
+	}
+
+	var r0 *usecases.TaskResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, usecases.UpdateTaskParams) (*usecases.TaskResult, error)); ok {
+		return returnFunc(ctx, taskID, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, usecases.UpdateTaskParams) *usecases.TaskResult); ok {
+		r0 = returnFunc(ctx, taskID, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.TaskResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, usecases.UpdateTaskParams) error); ok {
+		r1 = returnFunc(ctx, taskID, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_UpdateTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTask'
+type TaskUsecase_UpdateTask_Call struct {
+	*mock.Call
+}
+
+// UpdateTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID int64
+//   - params usecases.UpdateTaskParams
+func (_e *TaskUsecase_Expecter) UpdateTask(ctx interface{}, taskID interface{}, params interface{}) *TaskUsecase_UpdateTask_Call {
+	return &TaskUsecase_UpdateTask_Call{Call: _e.mock.On("UpdateTask", ctx, taskID, params)}
+}
+
+func (_c *TaskUsecase_UpdateTask_Call) Run(run func(ctx context.Context, taskID int64, params usecases.UpdateTaskParams)) *TaskUsecase_UpdateTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 usecases.UpdateTaskParams
+		if args[2] != nil {
+			arg2 = args[2].(usecases.UpdateTaskParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_UpdateTask_Call) Return(taskResult *usecases.TaskResult, err error) *TaskUsecase_UpdateTask_Call {
+	_c.Call.Return(taskResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_UpdateTask_Call) RunAndReturn(run func(ctx context.Context, taskID int64, params usecases.UpdateTaskParams) (*usecases.TaskResult, error)) *TaskUsecase_UpdateTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTaskItem provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) UpdateTaskItem(ctx context.Context, taskID int64, itemID int64, params usecases.UpdateTaskItemParams) (*usecases.TaskItemResult, error) {
+	ret := _mock.Called(ctx, taskID, itemID, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTaskItem")
+	}
+
+	var r0 *usecases.TaskItemResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, usecases.UpdateTaskItemParams) (*usecases.TaskItemResult, error)); ok {
+		return returnFunc(ctx, taskID, itemID, params)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int64, usecases.UpdateTaskItemParams) *usecases.TaskItemResult); ok {
+		r0 = returnFunc(ctx, taskID, itemID, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*usecases.TaskItemResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int64, usecases.UpdateTaskItemParams) error); ok {
+		r1 = returnFunc(ctx, taskID, itemID, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_UpdateTaskItem_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateTaskItem'
+type TaskUsecase_UpdateTaskItem_Call struct {
+	*mock.Call
+}
+
+// UpdateTaskItem is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID int64
+//   - itemID int64
+//   - params usecases.UpdateTaskItemParams
+func (_e *TaskUsecase_Expecter) UpdateTaskItem(ctx interface{}, taskID interface{}, itemID interface{}, params interface{}) *TaskUsecase_UpdateTaskItem_Call {
+	return &TaskUsecase_UpdateTaskItem_Call{Call: _e.mock.On("UpdateTaskItem", ctx, taskID, itemID, params)}
+}
+
+func (_c *TaskUsecase_UpdateTaskItem_Call) Run(run func(ctx context.Context, taskID int64, itemID int64, params usecases.UpdateTaskItemParams)) *TaskUsecase_UpdateTaskItem_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 usecases.UpdateTaskItemParams
+		if args[3] != nil {
+			arg3 = args[3].(usecases.UpdateTaskItemParams)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_UpdateTaskItem_Call) Return(taskItemResult *usecases.TaskItemResult, err error) *TaskUsecase_UpdateTaskItem_Call {
+	_c.Call.Return(taskItemResult, err)
+	return _c
+}
+
+func (_c *TaskUsecase_UpdateTaskItem_Call) RunAndReturn(run func(ctx context.Context, taskID int64, itemID int64, params usecases.UpdateTaskItemParams) (*usecases.TaskItemResult, error)) *TaskUsecase_UpdateTaskItem_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WatchTasks provides a mock function for the type TaskUsecase
+func (_mock *TaskUsecase) WatchTasks(ctx context.Context) (<-chan usecases.TaskEvent, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WatchTasks")
+	}
+
+	var r0 <-chan usecases.TaskEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (<-chan usecases.TaskEvent, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) <-chan usecases.TaskEvent); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan usecases.TaskEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// TaskUsecase_WatchTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WatchTasks'
+type TaskUsecase_WatchTasks_Call struct {
+	*mock.Call
+}
+
+// WatchTasks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *TaskUsecase_Expecter) WatchTasks(ctx interface{}) *TaskUsecase_WatchTasks_Call {
+	return &TaskUsecase_WatchTasks_Call{Call: _e.mock.On("WatchTasks", ctx)}
+}
+
+func (_c *TaskUsecase_WatchTasks_Call) Run(run func(ctx context.Context)) *TaskUsecase_WatchTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *TaskUsecase_WatchTasks_Call) Return(taskEventCh <-chan usecases.TaskEvent, err error) *TaskUsecase_WatchTasks_Call {
+	_c.Call.Return(taskEventCh, err)
+	return _c
+}
+
+func (_c *TaskUsecase_WatchTasks_Call) RunAndReturn(run func(ctx context.Context) (<-chan usecases.TaskEvent, error)) *TaskUsecase_WatchTasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
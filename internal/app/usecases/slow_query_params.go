@@ -0,0 +1,10 @@
+package usecases
+
+import "time"
+
+// CaptureSlowQueryParams represents a slow statement whose plan may be captured
+type CaptureSlowQueryParams struct {
+	Query     string // As run, with its values
+	Operation string // e.g. SELECT
+	Duration  time.Duration
+}
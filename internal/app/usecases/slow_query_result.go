@@ -0,0 +1,18 @@
+package usecases
+
+import "time"
+
+// SlowQueryPlanResult represents a captured plan in the output
+type SlowQueryPlanResult struct {
+	ID         int64
+	Query      string // Values redacted
+	Operation  string
+	Duration   time.Duration
+	Plan       string
+	CapturedAt time.Time
+}
+
+// SlowQueryPlanListResult represents a list of captured plans, newest first
+type SlowQueryPlanListResult struct {
+	Plans []SlowQueryPlanResult
+}
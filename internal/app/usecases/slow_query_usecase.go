@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"math/rand/v2"
+	"regexp"
+	"time"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+)
+
+const (
+	// DefaultSlowQueryPlanLimit is the number of plans listed when no limit is provided
+	DefaultSlowQueryPlanLimit = 50
+	// MaxSlowQueryPlanLimit is the largest number of plans listed at once
+	MaxSlowQueryPlanLimit = 500
+	// explainTimeout bounds the capture of a plan, the statement being run once more
+	explainTimeout = 30 * time.Second
+)
+
+// lockingStatement matches the statements taking row or advisory locks, which would be taken once more by the
+// capture, outside the transaction of the caller
+var lockingStatement = regexp.MustCompile(`(?i)\bFOR\s+(?:NO\s+KEY\s+)?UPDATE\b|\bFOR\s+(?:KEY\s+)?SHARE\b|\bpg_(?:try_)?advisory`)
+
+// explainingKey marks the context of the capture, whose own statements are not captured
+type explainingKey struct{}
+
+// SlowQueryUsecase defines the interface for the plans of slow statements
+type SlowQueryUsecase interface {
+	// SampleSlowQuery decides right away whether the plan of a slow statement is captured, returning the capture,
+	// to be run in the background, or nil when the statement is skipped
+	// Statements are skipped while another one is explained, so that a burst does not load the database further;
+	// the returned capture must be run for the next one to be explained
+	SampleSlowQuery(ctx context.Context, params CaptureSlowQueryParams) func() error
+	ListSlowQueryPlans(ctx context.Context, limit int) (*SlowQueryPlanListResult, error)
+}
+
+// slowQueryUsecase implements SlowQueryUsecase
+type slowQueryUsecase struct {
+	planRepo    db.SlowQueryPlanRepository
+	sampleRatio float64
+	explaining  chan struct{}
+}
+
+// NewSlowQueryUsecase creates a new instance of SlowQueryUsecase explaining sampleRatio of the slow statements
+func NewSlowQueryUsecase(planRepo db.SlowQueryPlanRepository, sampleRatio float64) SlowQueryUsecase {
+	return &slowQueryUsecase{
+		planRepo:    planRepo,
+		sampleRatio: sampleRatio,
+		explaining:  make(chan struct{}, 1),
+	}
+}
+
+// SampleSlowQuery only explains the SELECT statements taking no lock, EXPLAIN ANALYZE running the statement again
+func (u *slowQueryUsecase) SampleSlowQuery(ctx context.Context, params CaptureSlowQueryParams) func() error {
+	if params.Operation != "SELECT" || lockingStatement.MatchString(params.Query) ||
+		ctx.Value(explainingKey{}) != nil || rand.Float64() >= u.sampleRatio {
+		return nil
+	}
+
+	select {
+	case u.explaining <- struct{}{}:
+	default:
+		return nil
+	}
+
+	return func() error {
+		defer func() { <-u.explaining }()
+		return u.captureSlowQuery(ctx, params)
+	}
+}
+
+// captureSlowQuery explains the statement and stores its plan, with the values of both redacted
+func (u *slowQueryUsecase) captureSlowQuery(ctx context.Context, params CaptureSlowQueryParams) error {
+	// The plan outlives the request that ran the statement
+	ctx, cancel := context.WithTimeout(context.WithValue(context.WithoutCancel(ctx), explainingKey{}, true), explainTimeout)
+	defer cancel()
+
+	plan, err := u.planRepo.Explain(ctx, params.Query)
+	if err != nil {
+		return err
+	}
+
+	if err = u.planRepo.Create(ctx, &models.SlowQueryPlan{
+		Query:      logger.RedactQuery(params.Query),
+		Operation:  params.Operation,
+		DurationMS: float64(params.Duration) / float64(time.Millisecond),
+		Plan:       logger.RedactPlan(plan),
+	}); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx).Info().Dur("duration", params.Duration).Msg("Captured slow query plan")
+	return nil
+}
+
+// ListSlowQueryPlans retrieves the last limit plans, DefaultSlowQueryPlanLimit if limit is 0
+func (u *slowQueryUsecase) ListSlowQueryPlans(ctx context.Context, limit int) (*SlowQueryPlanListResult, error) {
+	if limit == 0 {
+		limit = DefaultSlowQueryPlanLimit
+	}
+	if limit < 0 || limit > MaxSlowQueryPlanLimit {
+		return nil, ErrInvalidPagination
+	}
+
+	plans, err := u.planRepo.List(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]SlowQueryPlanResult, 0, len(plans))
+	for _, plan := range plans {
+		results = append(results, SlowQueryPlanResult{
+			ID:         plan.ID,
+			Query:      plan.Query,
+			Operation:  plan.Operation,
+			Duration:   time.Duration(plan.DurationMS * float64(time.Millisecond)),
+			Plan:       plan.Plan,
+			CapturedAt: plan.CapturedAt,
+		})
+	}
+
+	return &SlowQueryPlanListResult{Plans: results}, nil
+}
@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func TestSlowQueryUsecase_SampleSlowQuery(t *testing.T) {
+	t.Parallel()
+
+	query := `SELECT * FROM "task_items" WHERE (task_id = 42) AND (title = 'Buy milk')`
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		params      CaptureSlowQueryParams
+		sampleRatio float64
+		planRepo    func(t *testing.T) db.SlowQueryPlanRepository
+		wantCapture bool
+		wantErr     assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "should store the plan with the values redacted",
+			ctx:         context.Background(),
+			params:      CaptureSlowQueryParams{Query: query, Operation: "SELECT", Duration: 1500 * time.Millisecond},
+			sampleRatio: 1,
+			planRepo: func(t *testing.T) db.SlowQueryPlanRepository {
+				m := mocks.NewSlowQueryPlanRepository(t)
+				m.On("Explain", mock.Anything, query).Return(
+					"Seq Scan on task_items  (cost=0.00..35.50 rows=1 width=40)\n"+
+						"  Filter: ((task_id = 42) AND (title = 'Buy milk'::text))\n"+
+						"  Rows Removed by Filter: 12", nil).Once()
+				m.On("Create", mock.Anything, &models.SlowQueryPlan{
+					Query:      `SELECT * FROM "task_items" WHERE (task_id = ?) AND (title = ?)`,
+					Operation:  "SELECT",
+					DurationMS: 1500,
+					Plan: "Seq Scan on task_items  (cost=0.00..35.50 rows=1 width=40)\n" +
+						"  Filter: ((task_id = ?) AND (title = ?::text))\n" +
+						"  Rows Removed by Filter: 12",
+				}).Return(nil).Once()
+				return m
+			},
+			wantCapture: true,
+			wantErr:     assert.NoError,
+		},
+		{
+			name:        "should not explain statements other than SELECT, they would run again",
+			ctx:         context.Background(),
+			params:      CaptureSlowQueryParams{Query: `DELETE FROM "tasks"`, Operation: "DELETE", Duration: time.Second},
+			sampleRatio: 1,
+			planRepo: func(t *testing.T) db.SlowQueryPlanRepository {
+				return mocks.NewSlowQueryPlanRepository(t)
+			},
+		},
+		{
+			name: "should not explain statements locking rows",
+			ctx:  context.Background(),
+			params: CaptureSlowQueryParams{
+				Query:     `SELECT * FROM "idempotency_keys" WHERE (key = 'order-7') FOR UPDATE`,
+				Operation: "SELECT",
+				Duration:  time.Second,
+			},
+			sampleRatio: 1,
+			planRepo: func(t *testing.T) db.SlowQueryPlanRepository {
+				return mocks.NewSlowQueryPlanRepository(t)
+			},
+		},
+		{
+			name:        "should not explain statements taking an advisory lock",
+			ctx:         context.Background(),
+			params:      CaptureSlowQueryParams{Query: `SELECT pg_advisory_xact_lock(42)`, Operation: "SELECT", Duration: time.Second},
+			sampleRatio: 1,
+			planRepo: func(t *testing.T) db.SlowQueryPlanRepository {
+				return mocks.NewSlowQueryPlanRepository(t)
+			},
+		},
+		{
+			name:        "should not explain statements left out of the sample",
+			ctx:         context.Background(),
+			params:      CaptureSlowQueryParams{Query: query, Operation: "SELECT", Duration: time.Second},
+			sampleRatio: 0,
+			planRepo: func(t *testing.T) db.SlowQueryPlanRepository {
+				return mocks.NewSlowQueryPlanRepository(t)
+			},
+		},
+		{
+			name:        "should not explain the statements of a capture",
+			ctx:         context.WithValue(context.Background(), explainingKey{}, true),
+			params:      CaptureSlowQueryParams{Query: query, Operation: "SELECT", Duration: time.Second},
+			sampleRatio: 1,
+			planRepo: func(t *testing.T) db.SlowQueryPlanRepository {
+				return mocks.NewSlowQueryPlanRepository(t)
+			},
+		},
+		{
+			name:        "should return error when the statement cannot be explained",
+			ctx:         context.Background(),
+			params:      CaptureSlowQueryParams{Query: query, Operation: "SELECT", Duration: time.Second},
+			sampleRatio: 1,
+			planRepo: func(t *testing.T) db.SlowQueryPlanRepository {
+				m := mocks.NewSlowQueryPlanRepository(t)
+				m.On("Explain", mock.Anything, query).Return("", errors.New("canceling statement")).Once()
+				return m
+			},
+			wantCapture: true,
+			wantErr:     assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := NewSlowQueryUsecase(tt.planRepo(t), tt.sampleRatio)
+
+			capture := u.SampleSlowQuery(tt.ctx, tt.params)
+			if !tt.wantCapture {
+				assert.Nil(t, capture)
+				return
+			}
+			require.NotNil(t, capture)
+			tt.wantErr(t, capture())
+		})
+	}
+
+	t.Run("should skip statements while another one is explained", func(t *testing.T) {
+		t.Parallel()
+
+		params := CaptureSlowQueryParams{Query: query, Operation: "SELECT", Duration: time.Second}
+		planRepo := mocks.NewSlowQueryPlanRepository(t)
+		planRepo.On("Explain", mock.Anything, query).Return("Result", nil).Twice()
+		planRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Twice()
+		u := NewSlowQueryUsecase(planRepo, 1)
+
+		capture := u.SampleSlowQuery(context.Background(), params)
+		require.NotNil(t, capture)
+		assert.Nil(t, u.SampleSlowQuery(context.Background(), params))
+
+		require.NoError(t, capture())
+		capture = u.SampleSlowQuery(context.Background(), params)
+		require.NotNil(t, capture)
+		assert.NoError(t, capture())
+	})
+}
+
+func TestSlowQueryUsecase_ListSlowQueryPlans(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		limit    int
+		planRepo func(t *testing.T) db.SlowQueryPlanRepository
+		want     *SlowQueryPlanListResult
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:  "should list the plans with the default limit",
+			limit: 0,
+			planRepo: func(t *testing.T) db.SlowQueryPlanRepository {
+				m := mocks.NewSlowQueryPlanRepository(t)
+				m.On("List", mock.Anything, DefaultSlowQueryPlanLimit).Return([]*models.SlowQueryPlan{
+					{ID: 1, Query: "SELECT ?", Operation: "SELECT", DurationMS: 250.5, Plan: "Result"},
+				}, nil).Once()
+				return m
+			},
+			want: &SlowQueryPlanListResult{Plans: []SlowQueryPlanResult{
+				{ID: 1, Query: "SELECT ?", Operation: "SELECT", Duration: 250500 * time.Microsecond, Plan: "Result"},
+			}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:  "should reject a limit above the maximum",
+			limit: MaxSlowQueryPlanLimit + 1,
+			planRepo: func(t *testing.T) db.SlowQueryPlanRepository {
+				return mocks.NewSlowQueryPlanRepository(t)
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidPagination)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := NewSlowQueryUsecase(tt.planRepo(t), 1)
+
+			got, err := u.ListSlowQueryPlans(context.Background(), tt.limit)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
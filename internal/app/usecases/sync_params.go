@@ -0,0 +1,43 @@
+package usecases
+
+import "time"
+
+// SyncMutationType identifies the kind of an offline client mutation
+type SyncMutationType string
+
+const (
+	SyncCreateTask SyncMutationType = "create_task"
+	SyncUpdateTask SyncMutationType = "update_task"
+	SyncDeleteTask SyncMutationType = "delete_task"
+	SyncCreateItem SyncMutationType = "create_item"
+	SyncUpdateItem SyncMutationType = "update_item"
+	SyncDeleteItem SyncMutationType = "delete_item"
+)
+
+// GetChangesParams represents the input for pulling changes
+type GetChangesParams struct {
+	// Since is the token returned by the previous pull, empty for a full sync
+	Since string
+}
+
+// SyncMutationParams represents a change made by a client while offline
+// The target task is given either by TaskID or by TaskRef, and the target item by ItemID or ItemRef,
+// the Ref of a task or item created earlier in the same push
+type SyncMutationParams struct {
+	Type    SyncMutationType
+	Ref     string // create_task and create_item: client reference of the created row
+	TaskID  int64
+	TaskRef string
+	ItemID  int64
+	ItemRef string
+	// ModifiedAt is when the client made the change, compared per field with the server version
+	ModifiedAt  time.Time
+	Title       *string
+	Description *string // tasks only
+	Completed   *bool   // items only
+}
+
+// ApplyChangesParams represents an ordered list of offline mutations applied atomically
+type ApplyChangesParams struct {
+	Mutations []SyncMutationParams
+}
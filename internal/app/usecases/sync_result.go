@@ -0,0 +1,57 @@
+package usecases
+
+import "time"
+
+// TombstoneResult represents a deleted task or item
+type TombstoneResult struct {
+	Type      string
+	ID        int64
+	TaskID    int64
+	ICalUID   string
+	DeletedAt time.Time
+}
+
+// ChangesResult represents the changes made since a sync token
+type ChangesResult struct {
+	// Token is passed as Since on the next pull
+	Token   string
+	Tasks   []TaskResult // without their items
+	Items   []TaskItemResult
+	Deleted []TombstoneResult
+}
+
+// SyncConflictReason describes why a mutation, or one of its fields, was not applied
+type SyncConflictReason string
+
+const (
+	// SyncConflictStale means the server value was modified after the client one
+	SyncConflictStale SyncConflictReason = "stale"
+	// SyncConflictDeleted means the task or item was deleted on the server
+	SyncConflictDeleted SyncConflictReason = "deleted"
+)
+
+// SyncConflictResult reports a field kept at its server value, or a whole mutation when Field is empty
+type SyncConflictResult struct {
+	Index            int
+	Field            string
+	Reason           SyncConflictReason
+	ServerValue      any
+	ServerModifiedAt *time.Time
+}
+
+// SyncMutationResult represents the outcome of a single mutation
+type SyncMutationResult struct {
+	Index  int
+	Type   SyncMutationType
+	Ref    string
+	TaskID int64
+	ItemID int64
+	// Applied is false when the mutation changed nothing, because of conflicts or an already deleted row
+	Applied bool
+}
+
+// ApplyChangesResult represents the outcome of a committed push, one result per mutation
+type ApplyChangesResult struct {
+	Results   []SyncMutationResult
+	Conflicts []SyncConflictResult
+}
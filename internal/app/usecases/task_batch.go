@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+)
+
+// ExecuteBatch applies an ordered list of operations in a single transaction
+// Either every operation succeeds and one result per operation is returned,
+// or nothing is applied and a *BatchOperationError identifies the failed operation
+func (u *taskUsecase) ExecuteBatch(ctx context.Context, params BatchParams) (*BatchResult, error) {
+	if len(params.Operations) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if len(params.Operations) > MaxBatchOperations {
+		return nil, ErrBatchTooLarge
+	}
+
+	var (
+		results []BatchOperationResult
+		events  []TaskEvent
+	)
+	err := u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		// Events are only published once the transaction is committed
+		txUsecase := u.withoutEvents()
+		refs := make(map[string]int64)
+		results = make([]BatchOperationResult, 0, len(params.Operations))
+		events = events[:0]
+
+		for i, op := range params.Operations {
+			result, event, err := txUsecase.executeBatchOperation(ctx, op, refs)
+			if err != nil {
+				return &BatchOperationError{Index: i, Err: err}
+			}
+
+			result.Index = i
+			results = append(results, *result)
+			events = append(events, event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		u.publish(ctx, event)
+	}
+
+	return &BatchResult{Results: results}, nil
+}
+
+// executeBatchOperation applies one operation, recording the ID of created tasks in refs
+func (u *taskUsecase) executeBatchOperation(ctx context.Context, op BatchOperationParams, refs map[string]int64) (*BatchOperationResult, TaskEvent, error) {
+	result := &BatchOperationResult{Type: op.Type, Ref: op.Ref}
+
+	if op.Type == BatchCreateTask {
+		if op.Ref != "" {
+			if _, exists := refs[op.Ref]; exists {
+				return nil, TaskEvent{}, ErrDuplicateBatchRef
+			}
+		}
+
+		task, err := u.CreateTask(ctx, op.Task)
+		if err != nil {
+			return nil, TaskEvent{}, err
+		}
+		if op.Ref != "" {
+			refs[op.Ref] = task.ID
+		}
+
+		result.TaskID = task.ID
+		result.Task = task
+		return result, TaskEvent{Type: TaskEventCreated, TaskID: task.ID, Task: task}, nil
+	}
+
+	taskID := op.TaskID
+	if op.TaskRef != "" {
+		id, ok := refs[op.TaskRef]
+		if !ok {
+			return nil, TaskEvent{}, ErrUnknownBatchRef
+		}
+		taskID = id
+	}
+	result.TaskID = taskID
+
+	switch op.Type {
+	case BatchUpdateTask:
+		task, err := u.UpdateTask(ctx, taskID, op.Update)
+		if err != nil {
+			return nil, TaskEvent{}, err
+		}
+		result.Task = task
+		return result, TaskEvent{Type: TaskEventUpdated, TaskID: taskID}, nil
+
+	case BatchToggleItem:
+		completed := op.Completed
+		if completed == nil {
+			if op.ItemID <= 0 {
+				return nil, TaskEvent{}, ErrInvalidTaskItemID
+			}
+			current, err := u.taskRepo.GetItemForUpdate(ctx, taskID, op.ItemID)
+			if err != nil {
+				return nil, TaskEvent{}, err
+			}
+			flipped := !current.Completed
+			completed = &flipped
+		}
+
+		item, err := u.UpdateTaskItem(ctx, taskID, op.ItemID, UpdateTaskItemParams{Completed: completed})
+		if err != nil {
+			return nil, TaskEvent{}, err
+		}
+		result.Item = item
+		return result, TaskEvent{Type: TaskEventUpdated, TaskID: taskID}, nil
+
+	case BatchDeleteTask:
+		if err := u.DeleteTask(ctx, taskID); err != nil {
+			return nil, TaskEvent{}, err
+		}
+		return result, TaskEvent{Type: TaskEventDeleted, TaskID: taskID}, nil
+
+	default:
+		return nil, TaskEvent{}, ErrUnknownBatchOperation
+	}
+}
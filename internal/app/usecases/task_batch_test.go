@@ -0,0 +1,279 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// newTestTransactor runs the transaction function directly, reporting the error it returned
+func newTestTransactor(t *testing.T, workErr *error) *mocks.Transactor {
+	m := mocks.NewTransactor(t)
+	m.On("WithinTx", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error {
+			err := fn(ctx)
+			if workErr != nil {
+				*workErr = err
+			}
+			return err
+		}).Maybe()
+	return m
+}
+
+func TestTaskUsecase_ExecuteBatch(t *testing.T) {
+	t.Parallel()
+
+	completed := true
+	newTitle := "Groceries"
+
+	tests := []struct {
+		name     string
+		taskRepo func(t *testing.T) db.TaskRepository
+		params   BatchParams
+		want     func(t *testing.T, got *BatchResult)
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should resolve references to tasks created earlier in the batch",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("Create", mock.Anything, mock.Anything).
+					Run(func(args mock.Arguments) {
+						args.Get(1).(*models.Task).ID = 42
+					}).Return(nil).Once()
+				m.On("GetForUpdate", mock.Anything, int64(42)).
+					Return(&models.Task{ID: 42, Title: "Shopping"}, nil).Once()
+				m.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.ID == 42 && task.Title == newTitle
+				})).Return(nil).Once()
+				m.On("GetItemForUpdate", mock.Anything, int64(7), int64(3)).
+					Return(&models.TaskItem{ID: 3, TaskID: 7, Title: "Buy milk"}, nil).Once()
+				m.On("UpdateItem", mock.Anything, mock.MatchedBy(func(item *models.TaskItem) bool {
+					return item.ID == 3 && item.TaskID == 7 && item.Completed
+				})).Return(nil).Once()
+				m.On("Delete", mock.Anything, int64(8)).Return(nil).Once()
+				return m
+			},
+			params: BatchParams{Operations: []BatchOperationParams{
+				{Type: BatchCreateTask, Ref: "new", Task: CreateTaskParams{Title: "Shopping"}},
+				{Type: BatchUpdateTask, TaskRef: "new", Update: UpdateTaskParams{Title: &newTitle}},
+				{Type: BatchToggleItem, TaskID: 7, ItemID: 3, Completed: &completed},
+				{Type: BatchDeleteTask, TaskID: 8},
+			}},
+			want: func(t *testing.T, got *BatchResult) {
+				require.Len(t, got.Results, 4)
+				assert.Equal(t, "new", got.Results[0].Ref)
+				assert.Equal(t, int64(42), got.Results[0].TaskID)
+				assert.Equal(t, int64(42), got.Results[1].TaskID)
+				assert.Equal(t, newTitle, got.Results[1].Task.Title)
+				assert.True(t, got.Results[2].Item.Completed)
+				assert.Equal(t, 3, got.Results[3].Index)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should flip the item when no completion status is given",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetItemForUpdate", mock.Anything, int64(7), int64(3)).
+					Return(func(context.Context, int64, int64) (*models.TaskItem, error) {
+						return &models.TaskItem{ID: 3, TaskID: 7, Title: "Buy milk", Completed: true}, nil
+					}).Twice()
+				m.On("UpdateItem", mock.Anything, mock.MatchedBy(func(item *models.TaskItem) bool {
+					return item.ID == 3 && item.TaskID == 7 && !item.Completed
+				})).Return(nil).Once()
+				return m
+			},
+			params: BatchParams{Operations: []BatchOperationParams{
+				{Type: BatchToggleItem, TaskID: 7, ItemID: 3},
+			}},
+			want: func(t *testing.T, got *BatchResult) {
+				require.Len(t, got.Results, 1)
+				assert.False(t, got.Results[0].Item.Completed)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should report the failed operation",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("Delete", mock.Anything, int64(1)).Return(nil).Once()
+				m.On("Delete", mock.Anything, int64(2)).Return(db.ErrTaskNotFound).Once()
+				return m
+			},
+			params: BatchParams{Operations: []BatchOperationParams{
+				{Type: BatchDeleteTask, TaskID: 1},
+				{Type: BatchDeleteTask, TaskID: 2},
+			}},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				var opErr *BatchOperationError
+				return assert.ErrorAs(t, err, &opErr) &&
+					assert.Equal(t, 1, opErr.Index) &&
+					assert.ErrorIs(t, err, db.ErrTaskNotFound)
+			},
+		},
+		{
+			name: "should reject a reference that was not created earlier",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				return mocks.NewTaskRepository(t)
+			},
+			params: BatchParams{Operations: []BatchOperationParams{
+				{Type: BatchDeleteTask, TaskRef: "later"},
+				{Type: BatchCreateTask, Ref: "later", Task: CreateTaskParams{Title: "Shopping"}},
+			}},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrUnknownBatchRef)
+			},
+		},
+		{
+			name: "should reject a duplicate reference",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				return m
+			},
+			params: BatchParams{Operations: []BatchOperationParams{
+				{Type: BatchCreateTask, Ref: "a", Task: CreateTaskParams{Title: "Shopping"}},
+				{Type: BatchCreateTask, Ref: "a", Task: CreateTaskParams{Title: "Work"}},
+			}},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrDuplicateBatchRef)
+			},
+		},
+		{
+			name: "should reject an unknown operation",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				return mocks.NewTaskRepository(t)
+			},
+			params: BatchParams{Operations: []BatchOperationParams{
+				{Type: "rename_item", TaskID: 1},
+			}},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrUnknownBatchOperation)
+			},
+		},
+		{
+			name: "should reject an empty batch",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				return mocks.NewTaskRepository(t)
+			},
+			params: BatchParams{},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrEmptyBatch)
+			},
+		},
+		{
+			name: "should reject a batch that is too large",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				return mocks.NewTaskRepository(t)
+			},
+			params: BatchParams{Operations: make([]BatchOperationParams, MaxBatchOperations+1)},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrBatchTooLarge)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repo := tt.taskRepo(t)
+			u := &taskUsecase{
+				taskRepo:   repo,
+				transactor: newTestTransactor(t, nil),
+			}
+
+			got, err := u.ExecuteBatch(context.Background(), tt.params)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			if tt.want != nil {
+				tt.want(t, got)
+			}
+		})
+	}
+}
+
+func TestTaskUsecase_ExecuteBatch_PublishesAfterCommit(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should publish one event per operation once committed", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewTaskRepository(t)
+		repo.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		u := NewTaskUsecase(repo, nil, nil, newTestTransactor(t, nil)).(*taskUsecase)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, err := u.WatchTasks(ctx)
+		require.NoError(t, err)
+
+		_, err = u.ExecuteBatch(context.Background(), BatchParams{Operations: []BatchOperationParams{
+			{Type: BatchDeleteTask, TaskID: 1},
+			{Type: BatchDeleteTask, TaskID: 2},
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, TaskEvent{Type: TaskEventDeleted, TaskID: 1}, <-events)
+		assert.Equal(t, TaskEvent{Type: TaskEventDeleted, TaskID: 2}, <-events)
+	})
+
+	t.Run("should not publish anything when rolled back", func(t *testing.T) {
+		t.Parallel()
+
+		repo := mocks.NewTaskRepository(t)
+		repo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		repo.On("Delete", mock.Anything, int64(2)).Return(errors.New("database error"))
+		var workErr error
+		u := NewTaskUsecase(repo, nil, nil, newTestTransactor(t, &workErr)).(*taskUsecase)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, err := u.WatchTasks(ctx)
+		require.NoError(t, err)
+
+		_, err = u.ExecuteBatch(context.Background(), BatchParams{Operations: []BatchOperationParams{
+			{Type: BatchDeleteTask, TaskID: 1},
+			{Type: BatchDeleteTask, TaskID: 2},
+		}})
+		require.Error(t, err)
+		require.Error(t, workErr, "the transaction must be rolled back")
+
+		select {
+		case event := <-events:
+			t.Fatalf("unexpected event %+v", event)
+		default:
+		}
+	})
+}
+
+func TestTaskUsecase_withoutEvents(t *testing.T) {
+	t.Parallel()
+
+	u := NewTaskUsecase(
+		mocks.NewTaskRepository(t),
+		mocks.NewSyncRepository(t),
+		mocks.NewBulkRepository(t),
+		newTestTransactor(t, nil),
+	).(*taskUsecase)
+
+	txUsecase := u.withoutEvents()
+
+	assert.Nil(t, txUsecase.events)
+	assert.NotNil(t, u.events)
+	assert.Equal(t, u.taskRepo, txUsecase.taskRepo)
+	assert.Equal(t, u.syncRepo, txUsecase.syncRepo)
+	assert.Equal(t, u.bulkRepo, txUsecase.bulkRepo)
+	assert.Equal(t, u.transactor, txUsecase.transactor)
+}
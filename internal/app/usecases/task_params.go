@@ -1,14 +1,47 @@
 package usecases
 
+import "time"
+
 // CreateTaskItemParams represents the input for creating a task item
 type CreateTaskItemParams struct {
 	Title     string
 	Completed bool
+	ICalUID   string // UID of the VTODO of the item, derived from its ID when empty
 }
 
 // CreateTaskParams represents the input for creating a task
 type CreateTaskParams struct {
 	Title       string
 	Description string
+	DueAt       *time.Time
+	ICalUID     string // UID of the VTODO of the task, derived from its ID when empty
 	Items       []CreateTaskItemParams
 }
+
+// UpdateTaskParams represents the input for updating a task
+// Nil fields are left unchanged
+type UpdateTaskParams struct {
+	Title       *string
+	Description *string
+	DueAt       *time.Time
+	// ClearDueAt removes the due date, DueAt being ignored
+	ClearDueAt bool
+}
+
+// UpdateTaskItemParams represents the input for updating a task item
+// Nil fields are left unchanged
+type UpdateTaskItemParams struct {
+	Title     *string
+	Completed *bool
+}
+
+// SearchTasksParams represents the input for searching tasks
+type SearchTasksParams struct {
+	TitleContains string
+	HasOpenItems  *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Limit         int
+	Offset        int
+	IncludeItems  bool // Load the items of the returned tasks
+}
@@ -8,6 +8,7 @@ type TaskItemResult struct {
 	TaskID    int64
 	Title     string
 	Completed bool
+	ICalUID   string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -17,6 +18,8 @@ type TaskResult struct {
 	ID          int64
 	Title       string
 	Description string
+	DueAt       *time.Time
+	ICalUID     string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 	Items       []TaskItemResult
@@ -26,3 +29,33 @@ type TaskResult struct {
 type TaskListResult struct {
 	Tasks []TaskResult
 }
+
+// TaskPageResult represents a page of tasks, with their items only when requested
+type TaskPageResult struct {
+	Tasks      []TaskResult
+	TotalCount int
+}
+
+// TaskStatsResult represents aggregated counters over tasks and items
+type TaskStatsResult struct {
+	TaskCount          int
+	ItemCount          int
+	CompletedItemCount int
+	OpenItemCount      int
+}
+
+// TaskEventType describes the kind of change carried by a TaskEvent
+type TaskEventType string
+
+const (
+	TaskEventCreated TaskEventType = "created"
+	TaskEventUpdated TaskEventType = "updated"
+	TaskEventDeleted TaskEventType = "deleted"
+)
+
+// TaskEvent represents a change on a task, published on the change feed
+type TaskEvent struct {
+	Type   TaskEventType
+	TaskID int64
+	Task   *TaskResult // nil unless the event carries the full task (creations)
+}
@@ -0,0 +1,392 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+// syncTokenPrefix versions the format of sync tokens
+const syncTokenPrefix = "v1:"
+
+// GetChanges returns the tasks and items changed or deleted since params.Since, along with the next token
+// Tasks are returned without their items: changed items are listed separately
+func (u *taskUsecase) GetChanges(ctx context.Context, params GetChangesParams) (*ChangesResult, error) {
+	since, err := decodeSyncToken(params.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	until, err := u.syncRepo.CurrentChangeSeq(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if since > until {
+		// Tokens only move forward, this one was not issued by this database
+		return nil, ErrInvalidSyncToken
+	}
+
+	changes, err := u.syncRepo.ListChanges(ctx, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ChangesResult{
+		Token:   encodeSyncToken(until),
+		Tasks:   make([]TaskResult, 0, len(changes.Tasks)),
+		Items:   make([]TaskItemResult, 0, len(changes.Items)),
+		Deleted: make([]TombstoneResult, 0, len(changes.Tombstones)),
+	}
+	for _, task := range changes.Tasks {
+		result.Tasks = append(result.Tasks, *u.modelToResult(task))
+	}
+	for _, item := range changes.Items {
+		result.Items = append(result.Items, itemModelToResult(item))
+	}
+	for _, tombstone := range changes.Tombstones {
+		result.Deleted = append(result.Deleted, TombstoneResult{
+			Type:      tombstone.EntityType,
+			ID:        tombstone.EntityID,
+			TaskID:    tombstone.TaskID,
+			ICalUID:   tombstone.ICalUID,
+			DeletedAt: tombstone.DeletedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// ApplyChanges applies offline client mutations in a single transaction
+// Updated fields follow last-writer-wins: a field is only overwritten when the client modified it after
+// the server did, otherwise it is reported as a conflict. Deletions always win over concurrent updates,
+// and deleting a row that is already gone is a no-op
+func (u *taskUsecase) ApplyChanges(ctx context.Context, params ApplyChangesParams) (*ApplyChangesResult, error) {
+	if len(params.Mutations) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if len(params.Mutations) > MaxSyncMutations {
+		return nil, ErrBatchTooLarge
+	}
+
+	var (
+		result *ApplyChangesResult
+		events []TaskEvent
+	)
+	err := u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		s := &syncSession{
+			usecase:  u,
+			now:      time.Now(),
+			taskRefs: make(map[string]int64),
+			itemRefs: make(map[string]int64),
+			result: &ApplyChangesResult{
+				Results:   make([]SyncMutationResult, 0, len(params.Mutations)),
+				Conflicts: make([]SyncConflictResult, 0),
+			},
+		}
+
+		for i, mutation := range params.Mutations {
+			if err := s.apply(ctx, i, mutation); err != nil {
+				return &BatchOperationError{Index: i, Err: err}
+			}
+		}
+
+		// Events are only published once the transaction is committed
+		result, events = s.result, s.events
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		u.publish(ctx, event)
+	}
+
+	return result, nil
+}
+
+// syncSession holds the state of a push while its mutations are applied
+type syncSession struct {
+	usecase  *taskUsecase
+	now      time.Time
+	taskRefs map[string]int64
+	itemRefs map[string]int64
+	result   *ApplyChangesResult
+	events   []TaskEvent
+}
+
+// apply applies a single mutation, recording its result, conflicts and events
+func (s *syncSession) apply(ctx context.Context, index int, m SyncMutationParams) error {
+	if m.ModifiedAt.IsZero() {
+		return ErrSyncModifiedAtRequired
+	}
+	// A client clock running ahead must not win every future conflict
+	if m.ModifiedAt.After(s.now) {
+		m.ModifiedAt = s.now
+	}
+
+	res := SyncMutationResult{Index: index, Type: m.Type, Ref: m.Ref}
+	if m.Type != SyncCreateTask {
+		taskID, err := resolveRef(m.TaskID, m.TaskRef, s.taskRefs)
+		if err != nil {
+			return err
+		}
+		res.TaskID = taskID
+	}
+	if m.Type == SyncUpdateItem || m.Type == SyncDeleteItem {
+		itemID, err := resolveRef(m.ItemID, m.ItemRef, s.itemRefs)
+		if err != nil {
+			return err
+		}
+		res.ItemID = itemID
+	}
+	if m.Ref != "" {
+		if _, exists := s.refs(m.Type)[m.Ref]; exists {
+			return ErrDuplicateBatchRef
+		}
+	}
+
+	var err error
+	switch m.Type {
+	case SyncCreateTask:
+		err = s.createTask(ctx, m, &res)
+	case SyncUpdateTask:
+		err = s.updateTask(ctx, m, &res)
+	case SyncDeleteTask:
+		err = s.deleteTask(ctx, &res)
+	case SyncCreateItem:
+		err = s.createItem(ctx, m, &res)
+	case SyncUpdateItem:
+		err = s.updateItem(ctx, m, &res)
+	case SyncDeleteItem:
+		err = s.deleteItem(ctx, &res)
+	default:
+		err = ErrUnknownBatchOperation
+	}
+	if err != nil {
+		return err
+	}
+
+	s.result.Results = append(s.result.Results, res)
+	return nil
+}
+
+func (s *syncSession) createTask(ctx context.Context, m SyncMutationParams, res *SyncMutationResult) error {
+	if m.Title == nil || *m.Title == "" {
+		return ErrTaskTitleRequired
+	}
+
+	task := &models.Task{Title: *m.Title}
+	if m.Description != nil {
+		task.Description = *m.Description
+	}
+	task.FieldVersions.Touch(m.ModifiedAt, models.FieldTitle, models.FieldDescription)
+
+	if err := s.usecase.taskRepo.Create(ctx, task); err != nil {
+		return err
+	}
+	if m.Ref != "" {
+		s.taskRefs[m.Ref] = task.ID
+	}
+
+	res.TaskID = task.ID
+	res.Applied = true
+	s.events = append(s.events, TaskEvent{Type: TaskEventCreated, TaskID: task.ID, Task: s.usecase.modelToResult(task)})
+	return nil
+}
+
+func (s *syncSession) updateTask(ctx context.Context, m SyncMutationParams, res *SyncMutationResult) error {
+	if m.Title != nil && *m.Title == "" {
+		return ErrTaskTitleRequired
+	}
+
+	task, err := s.usecase.taskRepo.GetForUpdate(ctx, res.TaskID)
+	if errors.Is(err, db.ErrTaskNotFound) {
+		s.conflict(res.Index, "", SyncConflictDeleted, nil, nil)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	titleChanged := merge(s, res.Index, m, &task.FieldVersions, task.UpdatedAt, models.FieldTitle, m.Title, &task.Title)
+	descriptionChanged := merge(s, res.Index, m, &task.FieldVersions, task.UpdatedAt, models.FieldDescription, m.Description, &task.Description)
+	res.Applied = titleChanged || descriptionChanged
+	if !res.Applied {
+		return nil
+	}
+
+	if err = s.usecase.taskRepo.Update(ctx, task); err != nil {
+		return err
+	}
+
+	s.events = append(s.events, TaskEvent{Type: TaskEventUpdated, TaskID: task.ID})
+	return nil
+}
+
+func (s *syncSession) deleteTask(ctx context.Context, res *SyncMutationResult) error {
+	err := s.usecase.taskRepo.Delete(ctx, res.TaskID)
+	if errors.Is(err, db.ErrTaskNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	res.Applied = true
+	s.events = append(s.events, TaskEvent{Type: TaskEventDeleted, TaskID: res.TaskID})
+	return nil
+}
+
+func (s *syncSession) createItem(ctx context.Context, m SyncMutationParams, res *SyncMutationResult) error {
+	if m.Title == nil || *m.Title == "" {
+		return ErrTaskItemTitleRequired
+	}
+
+	item := &models.TaskItem{TaskID: res.TaskID, Title: *m.Title}
+	if m.Completed != nil {
+		item.Completed = *m.Completed
+	}
+	item.FieldVersions.Touch(m.ModifiedAt, models.FieldTitle, models.FieldCompleted)
+
+	err := s.usecase.taskRepo.CreateItem(ctx, item)
+	if errors.Is(err, db.ErrTaskNotFound) {
+		s.conflict(res.Index, "", SyncConflictDeleted, nil, nil)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if m.Ref != "" {
+		s.itemRefs[m.Ref] = item.ID
+	}
+
+	res.ItemID = item.ID
+	res.Applied = true
+	s.events = append(s.events, TaskEvent{Type: TaskEventUpdated, TaskID: res.TaskID})
+	return nil
+}
+
+func (s *syncSession) updateItem(ctx context.Context, m SyncMutationParams, res *SyncMutationResult) error {
+	if m.Title != nil && *m.Title == "" {
+		return ErrTaskItemTitleRequired
+	}
+
+	item, err := s.usecase.taskRepo.GetItemForUpdate(ctx, res.TaskID, res.ItemID)
+	if errors.Is(err, db.ErrTaskItemNotFound) {
+		s.conflict(res.Index, "", SyncConflictDeleted, nil, nil)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	titleChanged := merge(s, res.Index, m, &item.FieldVersions, item.UpdatedAt, models.FieldTitle, m.Title, &item.Title)
+	completedChanged := merge(s, res.Index, m, &item.FieldVersions, item.UpdatedAt, models.FieldCompleted, m.Completed, &item.Completed)
+	res.Applied = titleChanged || completedChanged
+	if !res.Applied {
+		return nil
+	}
+
+	if err = s.usecase.taskRepo.UpdateItem(ctx, item); err != nil {
+		return err
+	}
+
+	s.events = append(s.events, TaskEvent{Type: TaskEventUpdated, TaskID: res.TaskID})
+	return nil
+}
+
+func (s *syncSession) deleteItem(ctx context.Context, res *SyncMutationResult) error {
+	err := s.usecase.taskRepo.DeleteItem(ctx, res.TaskID, res.ItemID)
+	if errors.Is(err, db.ErrTaskItemNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	res.Applied = true
+	s.events = append(s.events, TaskEvent{Type: TaskEventUpdated, TaskID: res.TaskID})
+	return nil
+}
+
+// refs returns the references declared by mutations of the given type
+func (s *syncSession) refs(mutationType SyncMutationType) map[string]int64 {
+	if mutationType == SyncCreateItem {
+		return s.itemRefs
+	}
+
+	return s.taskRefs
+}
+
+// conflict records a field, or a whole mutation when field is empty, that was not applied
+func (s *syncSession) conflict(index int, field string, reason SyncConflictReason, serverValue any, serverModifiedAt *time.Time) {
+	s.result.Conflicts = append(s.result.Conflicts, SyncConflictResult{
+		Index:            index,
+		Field:            field,
+		Reason:           reason,
+		ServerValue:      serverValue,
+		ServerModifiedAt: serverModifiedAt,
+	})
+}
+
+// merge writes the client value of a field into current when the client modified it last,
+// and records a conflict otherwise; it reports whether current changed
+func merge[T any](s *syncSession, index int, m SyncMutationParams, versions *models.FieldVersions, fallback time.Time, field string, value *T, current *T) bool {
+	if value == nil {
+		return false
+	}
+
+	serverModifiedAt := versions.ModifiedAt(field, fallback)
+	if !m.ModifiedAt.After(serverModifiedAt) {
+		s.conflict(index, field, SyncConflictStale, *current, &serverModifiedAt)
+		return false
+	}
+
+	*current = *value
+	versions.Touch(m.ModifiedAt, field)
+	return true
+}
+
+// resolveRef returns id, or the ID created earlier in the push for ref
+func resolveRef(id int64, ref string, refs map[string]int64) (int64, error) {
+	if ref == "" {
+		return id, nil
+	}
+
+	resolved, ok := refs[ref]
+	if !ok {
+		return 0, ErrUnknownBatchRef
+	}
+
+	return resolved, nil
+}
+
+// encodeSyncToken turns a change sequence value into an opaque token
+func encodeSyncToken(seq int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(syncTokenPrefix + strconv.FormatInt(seq, 10)))
+}
+
+// decodeSyncToken extracts the change sequence value of a token, an empty token meaning the beginning
+func decodeSyncToken(token string) (int64, error) {
+	if token == "" {
+		return 0, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(data) <= len(syncTokenPrefix) || string(data[:len(syncTokenPrefix)]) != syncTokenPrefix {
+		return 0, ErrInvalidSyncToken
+	}
+
+	seq, err := strconv.ParseInt(string(data[len(syncTokenPrefix):]), 10, 64)
+	if err != nil || seq < 0 {
+		return 0, ErrInvalidSyncToken
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,299 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func TestTaskUsecase_GetChanges(t *testing.T) {
+	t.Parallel()
+
+	deletedAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		syncRepo func(t *testing.T) db.SyncRepository
+		params   GetChangesParams
+		want     *ChangesResult
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should return every change on a full sync",
+			syncRepo: func(t *testing.T) db.SyncRepository {
+				m := mocks.NewSyncRepository(t)
+				m.On("CurrentChangeSeq", mock.Anything).Return(int64(12), nil)
+				m.On("ListChanges", mock.Anything, int64(0), int64(12)).Return(&db.Changes{
+					Tasks:      []*models.Task{{ID: 1, Title: "Shopping"}},
+					Items:      []*models.TaskItem{{ID: 2, TaskID: 1, Title: "Buy milk"}},
+					Tombstones: []*models.Tombstone{{EntityType: models.TombstoneTask, EntityID: 3, TaskID: 3, DeletedAt: deletedAt}},
+				}, nil)
+				return m
+			},
+			params: GetChangesParams{},
+			want: &ChangesResult{
+				Token:   encodeSyncToken(12),
+				Tasks:   []TaskResult{{ID: 1, Title: "Shopping", Items: []TaskItemResult{}}},
+				Items:   []TaskItemResult{{ID: 2, TaskID: 1, Title: "Buy milk"}},
+				Deleted: []TombstoneResult{{Type: models.TombstoneTask, ID: 3, TaskID: 3, DeletedAt: deletedAt}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should return the changes made since the token",
+			syncRepo: func(t *testing.T) db.SyncRepository {
+				m := mocks.NewSyncRepository(t)
+				m.On("CurrentChangeSeq", mock.Anything).Return(int64(12), nil)
+				m.On("ListChanges", mock.Anything, int64(7), int64(12)).Return(&db.Changes{}, nil)
+				return m
+			},
+			params: GetChangesParams{Since: encodeSyncToken(7)},
+			want: &ChangesResult{
+				Token:   encodeSyncToken(12),
+				Tasks:   []TaskResult{},
+				Items:   []TaskItemResult{},
+				Deleted: []TombstoneResult{},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should reject a malformed token",
+			syncRepo: func(t *testing.T) db.SyncRepository {
+				// Repository should not be called
+				return mocks.NewSyncRepository(t)
+			},
+			params: GetChangesParams{Since: "not-a-token"},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidSyncToken)
+			},
+		},
+		{
+			name: "should reject a token ahead of the server",
+			syncRepo: func(t *testing.T) db.SyncRepository {
+				m := mocks.NewSyncRepository(t)
+				m.On("CurrentChangeSeq", mock.Anything).Return(int64(12), nil)
+				return m
+			},
+			params: GetChangesParams{Since: encodeSyncToken(40)},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidSyncToken)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &taskUsecase{
+				syncRepo: tt.syncRepo(t),
+			}
+
+			got, err := u.GetChanges(context.Background(), tt.params)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaskUsecase_ApplyChanges(t *testing.T) {
+	t.Parallel()
+
+	serverModifiedAt := time.Now().Add(-time.Hour)
+	before := serverModifiedAt.Add(-time.Minute)
+	after := serverModifiedAt.Add(time.Minute)
+	title := "Groceries"
+	description := "Saturday"
+	completed := true
+	itemTitle := "Buy milk"
+
+	tests := []struct {
+		name     string
+		taskRepo func(t *testing.T) db.TaskRepository
+		params   ApplyChangesParams
+		want     func(t *testing.T, got *ApplyChangesResult)
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should keep the fields modified last on the server",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Task{
+					ID:            1,
+					Title:         "Shopping",
+					Description:   "Weekly",
+					UpdatedAt:     serverModifiedAt,
+					FieldVersions: models.FieldVersions{models.FieldTitle: after.Add(time.Minute)},
+				}, nil)
+				m.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.Title == "Shopping" &&
+						task.Description == description &&
+						task.FieldVersions[models.FieldDescription].Equal(after)
+				})).Return(nil).Once()
+				return m
+			},
+			params: ApplyChangesParams{Mutations: []SyncMutationParams{
+				{Type: SyncUpdateTask, TaskID: 1, ModifiedAt: after, Title: &title, Description: &description},
+			}},
+			want: func(t *testing.T, got *ApplyChangesResult) {
+				require.Len(t, got.Results, 1)
+				assert.True(t, got.Results[0].Applied)
+				require.Len(t, got.Conflicts, 1)
+				assert.Equal(t, models.FieldTitle, got.Conflicts[0].Field)
+				assert.Equal(t, SyncConflictStale, got.Conflicts[0].Reason)
+				assert.Equal(t, "Shopping", got.Conflicts[0].ServerValue)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should not update anything older than the server",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetItemForUpdate", mock.Anything, int64(1), int64(2)).Return(
+					&models.TaskItem{ID: 2, TaskID: 1, Title: "Buy milk", UpdatedAt: serverModifiedAt}, nil)
+				return m
+			},
+			params: ApplyChangesParams{Mutations: []SyncMutationParams{
+				{Type: SyncUpdateItem, TaskID: 1, ItemID: 2, ModifiedAt: before, Completed: &completed},
+			}},
+			want: func(t *testing.T, got *ApplyChangesResult) {
+				require.Len(t, got.Results, 1)
+				assert.False(t, got.Results[0].Applied)
+				require.Len(t, got.Conflicts, 1)
+				assert.Equal(t, models.FieldCompleted, got.Conflicts[0].Field)
+				assert.Equal(t, false, got.Conflicts[0].ServerValue)
+				assert.Equal(t, serverModifiedAt, *got.Conflicts[0].ServerModifiedAt)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should report updates of deleted tasks and ignore deletions of deleted items",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetForUpdate", mock.Anything, int64(1)).Return(nil, db.ErrTaskNotFound)
+				m.On("DeleteItem", mock.Anything, int64(1), int64(2)).Return(db.ErrTaskItemNotFound)
+				return m
+			},
+			params: ApplyChangesParams{Mutations: []SyncMutationParams{
+				{Type: SyncUpdateTask, TaskID: 1, ModifiedAt: after, Title: &title},
+				{Type: SyncDeleteItem, TaskID: 1, ItemID: 2, ModifiedAt: after},
+			}},
+			want: func(t *testing.T, got *ApplyChangesResult) {
+				require.Len(t, got.Results, 2)
+				assert.False(t, got.Results[0].Applied)
+				assert.False(t, got.Results[1].Applied)
+				assert.Equal(t, []SyncConflictResult{{Index: 0, Reason: SyncConflictDeleted}}, got.Conflicts)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should resolve references to tasks and items created earlier in the push",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.Title == title && task.FieldVersions[models.FieldTitle].Equal(before)
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.Task).ID = 42
+				}).Return(nil).Once()
+				m.On("CreateItem", mock.Anything, mock.MatchedBy(func(item *models.TaskItem) bool {
+					return item.TaskID == 42 && item.Title == "Buy milk"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.TaskItem).ID = 43
+				}).Return(nil).Once()
+				m.On("GetItemForUpdate", mock.Anything, int64(42), int64(43)).Return(
+					&models.TaskItem{ID: 43, TaskID: 42, Title: "Buy milk", FieldVersions: models.FieldVersions{models.FieldCompleted: before}}, nil)
+				m.On("UpdateItem", mock.Anything, mock.MatchedBy(func(item *models.TaskItem) bool {
+					return item.ID == 43 && item.Completed
+				})).Return(nil).Once()
+				return m
+			},
+			params: ApplyChangesParams{Mutations: []SyncMutationParams{
+				{Type: SyncCreateTask, Ref: "list", ModifiedAt: before, Title: &title},
+				{Type: SyncCreateItem, Ref: "milk", TaskRef: "list", ModifiedAt: before, Title: &itemTitle},
+				{Type: SyncUpdateItem, TaskRef: "list", ItemRef: "milk", ModifiedAt: after, Completed: &completed},
+			}},
+			want: func(t *testing.T, got *ApplyChangesResult) {
+				require.Len(t, got.Results, 3)
+				assert.Equal(t, SyncMutationResult{Index: 0, Type: SyncCreateTask, Ref: "list", TaskID: 42, Applied: true}, got.Results[0])
+				assert.Equal(t, SyncMutationResult{Index: 1, Type: SyncCreateItem, Ref: "milk", TaskID: 42, ItemID: 43, Applied: true}, got.Results[1])
+				assert.Equal(t, SyncMutationResult{Index: 2, Type: SyncUpdateItem, TaskID: 42, ItemID: 43, Applied: true}, got.Results[2])
+				assert.Empty(t, got.Conflicts)
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should reject mutations without a modification time",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				return mocks.NewTaskRepository(t)
+			},
+			params: ApplyChangesParams{Mutations: []SyncMutationParams{
+				{Type: SyncDeleteTask, TaskID: 1},
+			}},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				var opErr *BatchOperationError
+				return assert.ErrorAs(t, err, &opErr) &&
+					assert.Equal(t, 0, opErr.Index) &&
+					assert.ErrorIs(t, err, ErrSyncModifiedAtRequired)
+			},
+		},
+		{
+			name: "should reject an empty push",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				return mocks.NewTaskRepository(t)
+			},
+			params: ApplyChangesParams{},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrEmptyBatch)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &taskUsecase{
+				taskRepo:   tt.taskRepo(t),
+				transactor: newTestTransactor(t, nil),
+			}
+
+			got, err := u.ApplyChanges(context.Background(), tt.params)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			if tt.want != nil {
+				tt.want(t, got)
+			}
+		})
+	}
+}
+
+func TestTaskUsecase_ApplyChanges_ClampsClientClock(t *testing.T) {
+	t.Parallel()
+
+	repo := mocks.NewTaskRepository(t)
+	repo.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Task{ID: 1, Title: "Shopping", UpdatedAt: time.Now().Add(-time.Hour)}, nil)
+	repo.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+		return !task.FieldVersions[models.FieldTitle].After(time.Now())
+	})).Return(nil).Once()
+	u := &taskUsecase{taskRepo: repo, transactor: newTestTransactor(t, nil)}
+	title := "Groceries"
+
+	_, err := u.ApplyChanges(context.Background(), ApplyChangesParams{Mutations: []SyncMutationParams{
+		{Type: SyncUpdateTask, TaskID: 1, ModifiedAt: time.Now().Add(24 * time.Hour), Title: &title},
+	}})
+
+	require.NoError(t, err)
+}
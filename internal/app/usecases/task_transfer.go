@@ -0,0 +1,180 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+)
+
+// ExportTasks writes every task with its items to w in the given format
+func (u *taskUsecase) ExportTasks(ctx context.Context, format TransferFormat, w io.Writer) error {
+	list, err := u.ListTasks(ctx)
+	if err != nil {
+		return err
+	}
+
+	return encodeTasks(w, format, list.Tasks)
+}
+
+// ImportTasks creates the tasks of a file in a single transaction, through CreateTask
+// Tasks whose title matches an existing task, or a task earlier in the file, are reported as duplicates
+// and skipped unless params.AllowDuplicates is set. Nothing is created on a dry run
+func (u *taskUsecase) ImportTasks(ctx context.Context, params ImportTasksParams) (*ImportResult, error) {
+	tasks, err := decodeTasks(params.Data, params.Format)
+	if err != nil {
+		return nil, err
+	}
+	if params.Bulk {
+		return u.bulkImportTasks(ctx, tasks, params)
+	}
+
+	titles := make([]string, 0, len(tasks))
+	for i, task := range tasks {
+		if strings.TrimSpace(task.Title) == "" {
+			return nil, invalidImportf("task %d: %v", i+1, ErrTaskTitleRequired)
+		}
+		for _, item := range task.Items {
+			if strings.TrimSpace(item.Title) == "" {
+				return nil, invalidImportf("task %q: %v", task.Title, ErrTaskItemTitleRequired)
+			}
+		}
+		titles = append(titles, task.Title)
+	}
+
+	existing, err := u.taskRepo.ListTitles(ctx, titles)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(existing)+len(tasks))
+	for _, title := range existing {
+		seen[titleKey(title)] = true
+	}
+
+	result := &ImportResult{
+		DryRun: params.DryRun,
+		Tasks:  make([]ImportedTaskResult, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		key := titleKey(task.Title)
+		imported := ImportedTaskResult{Title: task.Title, ItemCount: len(task.Items), Duplicate: seen[key]}
+		if imported.Duplicate {
+			result.DuplicateCount++
+		}
+		seen[key] = true
+		result.Tasks = append(result.Tasks, imported)
+	}
+	if params.DryRun {
+		return result, nil
+	}
+
+	var events []TaskEvent
+	err = u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		// Events are only published once the transaction is committed
+		txUsecase := u.withoutEvents()
+		events = events[:0]
+		result.CreatedCount = 0
+
+		for i, task := range tasks {
+			if result.Tasks[i].Duplicate && !params.AllowDuplicates {
+				continue
+			}
+
+			created, err := txUsecase.CreateTask(ctx, task)
+			if err != nil {
+				return fmt.Errorf("task %q: %w", task.Title, err)
+			}
+
+			result.Tasks[i].ID = created.ID
+			result.CreatedCount++
+			events = append(events, TaskEvent{Type: TaskEventCreated, TaskID: created.ID, Task: created})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, event := range events {
+		u.publish(ctx, event)
+	}
+
+	return result, nil
+}
+
+// bulkImportTasks imports the tasks through the bulk repository, reporting the invalid tasks and items as
+// rejects; duplicates are detected as by ImportTasks. No event is published on the change feed, delta sync
+// clients still seeing the tasks
+func (u *taskUsecase) bulkImportTasks(ctx context.Context, tasks []CreateTaskParams, params ImportTasksParams) (*ImportResult, error) {
+	if u.bulkRepo == nil {
+		return nil, ErrBulkImportUnavailable
+	}
+
+	bulkTasks := make([]db.BulkTask, 0, len(tasks))
+	for _, task := range tasks {
+		items := make([]db.BulkTaskItem, 0, len(task.Items))
+		for _, item := range task.Items {
+			items = append(items, db.BulkTaskItem{Title: item.Title, Completed: item.Completed, ICalUID: item.ICalUID})
+		}
+		bulkTasks = append(bulkTasks, db.BulkTask{
+			Title:       task.Title,
+			Description: task.Description,
+			DueAt:       task.DueAt,
+			ICalUID:     task.ICalUID,
+			Items:       items,
+		})
+	}
+
+	bulk, err := u.bulkRepo.ImportTasks(ctx, bulkTasks, db.BulkImportOptions{
+		AllowDuplicates: params.AllowDuplicates,
+		DryRun:          params.DryRun,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{
+		DryRun:  params.DryRun,
+		Tasks:   make([]ImportedTaskResult, 0, len(tasks)),
+		Rejects: make([]ImportRejectResult, 0, len(bulk.Rejects)),
+	}
+	for i, task := range tasks {
+		imported := ImportedTaskResult{Title: task.Title, ItemCount: len(task.Items)}
+		// The IDs drawn on a dry run were rolled back
+		if !params.DryRun && bulk.TaskIDs[i] != 0 {
+			imported.ID = bulk.TaskIDs[i]
+			result.CreatedCount++
+		}
+		result.Tasks = append(result.Tasks, imported)
+	}
+
+	for _, reject := range bulk.Rejects {
+		task := &result.Tasks[reject.TaskRef-1]
+		if reject.Duplicate {
+			task.Duplicate = true
+			result.DuplicateCount++
+			continue
+		}
+
+		title := task.Title
+		if reject.ItemRef > 0 {
+			title = tasks[reject.TaskRef-1].Items[reject.ItemRef-1].Title
+		}
+		result.Rejects = append(result.Rejects, ImportRejectResult{
+			Task:   int(reject.TaskRef),
+			Item:   int(reject.ItemRef),
+			Title:  title,
+			Reason: reject.Reason,
+		})
+	}
+
+	return result, nil
+}
+
+// titleKey normalises a task title for duplicate detection
+func titleKey(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
@@ -0,0 +1,457 @@
+package usecases
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func TestTransferFormats_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	tasks := []TaskResult{
+		{
+			Title:     "Shopping list",
+			CreatedAt: createdAt,
+			Items: []TaskItemResult{
+				{Title: "Buy milk", Completed: true, CreatedAt: createdAt, UpdatedAt: createdAt},
+				{Title: "Buy bread @bakery", CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+		},
+		{Title: "Call plumber", CreatedAt: createdAt},
+	}
+	want := []CreateTaskParams{
+		{
+			Title: "Shopping list",
+			Items: []CreateTaskItemParams{
+				{Title: "Buy milk", Completed: true},
+				{Title: "Buy bread @bakery"},
+			},
+		},
+		{Title: "Call plumber", Items: []CreateTaskItemParams{}},
+	}
+
+	for _, format := range TransferFormats {
+		if format == TransferICal {
+			// Only tasks with a due date are exported, see TestICalTasks
+			continue
+		}
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, encodeTasks(&buf, format, tasks))
+
+			got, err := decodeTasks(&buf, format)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestDecodeTasks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  TransferFormat
+		input   string
+		want    []CreateTaskParams
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "should read a bare JSON array",
+			format: TransferJSON,
+			input:  `[{"title": "Shopping", "description": "Weekly", "items": [{"title": "Buy milk", "completed": true}]}]`,
+			want: []CreateTaskParams{
+				{Title: "Shopping", Description: "Weekly", Items: []CreateTaskItemParams{{Title: "Buy milk", Completed: true}}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "should read CSV columns in any order",
+			format: TransferCSV,
+			input:  "Completed,Item,Task\nyes,Buy milk,Shopping\n,Buy bread,Shopping\n,,Work\n",
+			want: []CreateTaskParams{
+				{Title: "Shopping", Items: []CreateTaskItemParams{{Title: "Buy milk", Completed: true}, {Title: "Buy bread"}}},
+				{Title: "Work", Items: []CreateTaskItemParams{}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "should reject CSV without a task column",
+			format: TransferCSV,
+			input:  "item,completed\nBuy milk,true\n",
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidImportFile)
+			},
+		},
+		{
+			name:   "should read Markdown headings, descriptions and checklists",
+			format: TransferMarkdown,
+			input:  "# Shopping\n\nWeekly groceries\nat the market\n\n* [X] Buy milk\n- [ ] Buy bread\n\n### Work\n- [ ] Send report\n",
+			want: []CreateTaskParams{
+				{Title: "Shopping", Description: "Weekly groceries\nat the market", Items: []CreateTaskItemParams{{Title: "Buy milk", Completed: true}, {Title: "Buy bread"}}},
+				{Title: "Work", Items: []CreateTaskItemParams{{Title: "Send report"}}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "should reject a Markdown checklist before any heading",
+			format: TransferMarkdown,
+			input:  "- [ ] Buy milk\n",
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidImportFile)
+			},
+		},
+		{
+			name:   "should read iCalendar to-dos, sub-to-dos and events",
+			format: TransferICal,
+			input: "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//Test//EN\r\n" +
+				"BEGIN:VTODO\r\nUID:a\r\nDTSTAMP:20250301T100000Z\r\nSUMMARY:Taxes\r\nDUE;VALUE=DATE:20250415\r\nEND:VTODO\r\n" +
+				"BEGIN:VTODO\r\nUID:b\r\nDTSTAMP:20250301T100000Z\r\nSUMMARY:Collect receipts\r\nRELATED-TO:a\r\nSTATUS:COMPLETED\r\nEND:VTODO\r\n" +
+				"BEGIN:VEVENT\r\nUID:c\r\nDTSTAMP:20250301T100000Z\r\nSUMMARY:Dentist\r\nDTSTART:20250310T083000Z\r\nEND:VEVENT\r\n" +
+				"BEGIN:VTODO\r\nUID:d\r\nDTSTAMP:20250301T100000Z\r\nSUMMARY:Call bank\r\nRELATED-TO:unknown\r\nEND:VTODO\r\n" +
+				"END:VCALENDAR\r\n",
+			want: []CreateTaskParams{
+				{Title: "Taxes", DueAt: ptrTime(time.Date(2025, 4, 15, 0, 0, 0, 0, time.UTC)), Items: []CreateTaskItemParams{{Title: "Collect receipts", Completed: true}}},
+				{Title: "Dentist", DueAt: ptrTime(time.Date(2025, 3, 10, 8, 30, 0, 0, time.UTC)), Items: []CreateTaskItemParams{}},
+				{Title: "Call bank", Items: []CreateTaskItemParams{}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:   "should reject a malformed calendar",
+			format: TransferICal,
+			input:  "BEGIN:VCALENDAR\r\nSUMMARY\r\n",
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidImportFile)
+			},
+		},
+		{
+			name:   "should read todo.txt priorities, dates, projects and contexts",
+			format: TransferTodoTxt,
+			input:  "(A) 2025-03-01 Call mom @phone\nx 2025-03-02 2025-03-01 Buy milk +Shopping_list\n2025-03-01 Buy bread +Shopping_list +Errands\n",
+			want: []CreateTaskParams{
+				{Title: todoTxtInbox, Items: []CreateTaskItemParams{{Title: "Call mom @phone"}}},
+				{Title: "Shopping list", Items: []CreateTaskItemParams{{Title: "Buy milk", Completed: true}, {Title: "Buy bread"}}},
+			},
+			wantErr: assert.NoError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := decodeTasks(strings.NewReader(tt.input), tt.format)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestICalTasks(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2025, 3, 7, 18, 0, 0, 0, time.UTC)
+	tasks := []TaskResult{
+		{
+			ID:          1,
+			Title:       "Shopping list",
+			Description: "Weekly groceries\r\nat the market",
+			DueAt:       &dueAt,
+			CreatedAt:   createdAt,
+			UpdatedAt:   createdAt,
+			Items: []TaskItemResult{
+				{ID: 10, TaskID: 1, Title: "Buy milk, eggs", Completed: true, CreatedAt: createdAt, UpdatedAt: createdAt},
+				{ID: 11, TaskID: 1, Title: "Buy bread", CreatedAt: createdAt, UpdatedAt: createdAt},
+			},
+		},
+		{ID: 2, Title: "Someday", CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encodeTasks(&buf, TransferICal, tasks))
+
+	exported := buf.String()
+	assert.Contains(t, exported, "UID:task-1@todo-bun-app\r\n")
+	assert.Contains(t, exported, "DUE:20250307T180000Z\r\n")
+	assert.Contains(t, exported, "STATUS:IN-PROCESS\r\n")
+	assert.Contains(t, exported, "PERCENT-COMPLETE:50\r\n")
+	assert.Contains(t, exported, "RELATED-TO:task-1@todo-bun-app\r\n")
+	assert.Contains(t, exported, "STATUS:COMPLETED\r\n")
+	assert.NotContains(t, exported, "Someday")
+
+	got, err := decodeTasks(&buf, TransferICal)
+	require.NoError(t, err)
+	assert.Equal(t, []CreateTaskParams{
+		{
+			Title:       "Shopping list",
+			Description: "Weekly groceries\nat the market",
+			DueAt:       &dueAt,
+			Items: []CreateTaskItemParams{
+				{Title: "Buy milk, eggs", Completed: true},
+				{Title: "Buy bread"},
+			},
+		},
+	}, got)
+
+	t.Run("should write an empty calendar without due tasks", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		require.NoError(t, encodeTasks(&buf, TransferICal, tasks[1:]))
+
+		assert.Equal(t, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:-//todo-bun-app//TODO API//EN\r\nEND:VCALENDAR\r\n", buf.String())
+		got, err := decodeTasks(&buf, TransferICal)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+	})
+}
+
+func TestTaskUsecase_ImportTasks(t *testing.T) {
+	t.Parallel()
+
+	const input = "## Shopping\n- [ ] Buy milk\n\n## Work\n\n## work\n"
+
+	tests := []struct {
+		name     string
+		taskRepo func(t *testing.T) db.TaskRepository
+		params   ImportTasksParams
+		want     *ImportResult
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should preview the import without creating anything",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("ListTitles", mock.Anything, []string{"Shopping", "Work", "work"}).Return([]string{"SHOPPING"}, nil)
+				return m
+			},
+			params: ImportTasksParams{Format: TransferMarkdown, DryRun: true},
+			want: &ImportResult{
+				DryRun: true,
+				Tasks: []ImportedTaskResult{
+					{Title: "Shopping", ItemCount: 1, Duplicate: true},
+					{Title: "Work"},
+					{Title: "work", Duplicate: true},
+				},
+				DuplicateCount: 2,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should create the tasks that are not duplicates",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("ListTitles", mock.Anything, mock.Anything).Return([]string{"Shopping"}, nil)
+				m.On("Create", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+					return task.Title == "Work"
+				})).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.Task).ID = 7
+				}).Return(nil).Once()
+				return m
+			},
+			params: ImportTasksParams{Format: TransferMarkdown},
+			want: &ImportResult{
+				Tasks: []ImportedTaskResult{
+					{Title: "Shopping", ItemCount: 1, Duplicate: true},
+					{Title: "Work", ID: 7},
+					{Title: "work", Duplicate: true},
+				},
+				CreatedCount:   1,
+				DuplicateCount: 2,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should create duplicates when allowed",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("ListTitles", mock.Anything, mock.Anything).Return([]string{}, nil)
+				m.On("Create", mock.Anything, mock.Anything).Return(nil).Times(3)
+				return m
+			},
+			params: ImportTasksParams{Format: TransferMarkdown, AllowDuplicates: true},
+			want: &ImportResult{
+				Tasks: []ImportedTaskResult{
+					{Title: "Shopping", ItemCount: 1},
+					{Title: "Work"},
+					{Title: "work", Duplicate: true},
+				},
+				CreatedCount:   3,
+				DuplicateCount: 1,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should reject an unknown format",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				return mocks.NewTaskRepository(t)
+			},
+			params: ImportTasksParams{Format: "xml"},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrUnknownTransferFormat)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &taskUsecase{
+				taskRepo:   tt.taskRepo(t),
+				transactor: newTestTransactor(t, nil),
+			}
+			tt.params.Data = strings.NewReader(input)
+
+			got, err := u.ImportTasks(context.Background(), tt.params)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaskUsecase_ImportTasks_Bulk(t *testing.T) {
+	t.Parallel()
+
+	const input = "## Shopping\n- [ ] Buy milk\n- [ ] Buy bread\n\n## Work\n\n## work\n"
+
+	tests := []struct {
+		name     string
+		bulkRepo func(t *testing.T) db.BulkRepository
+		params   ImportTasksParams
+		want     *ImportResult
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should report the created tasks, the duplicates and the rejects",
+			bulkRepo: func(t *testing.T) db.BulkRepository {
+				m := mocks.NewBulkRepository(t)
+				m.On("ImportTasks", mock.Anything, mock.MatchedBy(func(tasks []db.BulkTask) bool {
+					return len(tasks) == 3 && len(tasks[0].Items) == 2 && tasks[0].Items[1].Title == "Buy bread"
+				}), db.BulkImportOptions{}).Return(&db.BulkImportResult{
+					TaskIDs:   []int64{7, 8, 0},
+					ItemCount: 1,
+					Rejects: []db.BulkReject{
+						{TaskRef: 1, ItemRef: 2, Reason: "title is required"},
+						{TaskRef: 3, Reason: db.BulkDuplicateReason, Duplicate: true},
+					},
+				}, nil)
+				return m
+			},
+			params: ImportTasksParams{Format: TransferMarkdown, Bulk: true},
+			want: &ImportResult{
+				Tasks: []ImportedTaskResult{
+					{Title: "Shopping", ID: 7, ItemCount: 2},
+					{Title: "Work", ID: 8},
+					{Title: "work", Duplicate: true},
+				},
+				Rejects:        []ImportRejectResult{{Task: 1, Item: 2, Title: "Buy bread", Reason: "title is required"}},
+				CreatedCount:   2,
+				DuplicateCount: 1,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should not report the IDs of a dry run",
+			bulkRepo: func(t *testing.T) db.BulkRepository {
+				m := mocks.NewBulkRepository(t)
+				m.On("ImportTasks", mock.Anything, mock.Anything, db.BulkImportOptions{AllowDuplicates: true, DryRun: true}).
+					Return(&db.BulkImportResult{TaskIDs: []int64{7, 8, 9}, ItemCount: 2}, nil)
+				return m
+			},
+			params: ImportTasksParams{Format: TransferMarkdown, Bulk: true, AllowDuplicates: true, DryRun: true},
+			want: &ImportResult{
+				DryRun: true,
+				Tasks: []ImportedTaskResult{
+					{Title: "Shopping", ItemCount: 2},
+					{Title: "Work"},
+					{Title: "work"},
+				},
+				Rejects: []ImportRejectResult{},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should return the repository error",
+			bulkRepo: func(t *testing.T) db.BulkRepository {
+				m := mocks.NewBulkRepository(t)
+				m.On("ImportTasks", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("copy failed"))
+				return m
+			},
+			params:  ImportTasksParams{Format: TransferMarkdown, Bulk: true},
+			wantErr: assert.Error,
+		},
+		{
+			name: "should fail when bulk import is not available",
+			bulkRepo: func(t *testing.T) db.BulkRepository {
+				return nil
+			},
+			params: ImportTasksParams{Format: TransferMarkdown, Bulk: true},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrBulkImportUnavailable)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &taskUsecase{transactor: newTestTransactor(t, nil)}
+			if bulkRepo := tt.bulkRepo(t); bulkRepo != nil {
+				u.bulkRepo = bulkRepo
+			}
+			tt.params.Data = strings.NewReader(input)
+
+			got, err := u.ImportTasks(context.Background(), tt.params)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaskUsecase_ExportTasks(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewTaskRepository(t)
+	m.On("List", mock.Anything).Return([]*models.Task{
+		{ID: 1, Title: "Shopping", Description: "Weekly", Items: []*models.TaskItem{{ID: 2, TaskID: 1, Title: "Buy milk", Completed: true}}},
+	}, nil)
+	u := &taskUsecase{taskRepo: m}
+
+	var buf bytes.Buffer
+	err := u.ExportTasks(context.Background(), TransferMarkdown, &buf)
+
+	require.NoError(t, err)
+	assert.Equal(t, "## Shopping\n\nWeekly\n\n- [x] Buy milk\n", buf.String())
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
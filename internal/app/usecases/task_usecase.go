@@ -2,10 +2,13 @@ package usecases
 
 import (
 	"context"
-	"errors"
+	"io"
+	"time"
 
 	"github.com/clevertechware/todo-bun-app/internal/app/db"
 	"github.com/clevertechware/todo-bun-app/internal/app/models"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/pubsub"
 )
 
 // TaskUsecase defines the interface for task business logic
@@ -13,18 +16,53 @@ type TaskUsecase interface {
 	CreateTask(ctx context.Context, params CreateTaskParams) (*TaskResult, error)
 	DeleteTask(ctx context.Context, taskID int64) error
 	GetTask(ctx context.Context, taskID int64) (*TaskResult, error)
+	GetTaskByICalUID(ctx context.Context, uid string) (*TaskResult, error)
 	ListTasks(ctx context.Context) (*TaskListResult, error)
+	// StreamTasks calls fn with every task, in the order of ListTasks, without holding them all in memory
+	StreamTasks(ctx context.Context, fn func(task *TaskResult) error) error
+	SearchTasks(ctx context.Context, params SearchTasksParams) (*TaskPageResult, error)
+	ListTaskItems(ctx context.Context, taskIDs []int64) (map[int64][]TaskItemResult, error)
+	GetTaskStats(ctx context.Context) (*TaskStatsResult, error)
+	AddTaskItem(ctx context.Context, taskID int64, params CreateTaskItemParams) (*TaskItemResult, error)
+	UpdateTaskItem(ctx context.Context, taskID int64, itemID int64, params UpdateTaskItemParams) (*TaskItemResult, error)
+	DeleteTaskItem(ctx context.Context, taskID int64, itemID int64) error
+	UpdateTask(ctx context.Context, taskID int64, params UpdateTaskParams) (*TaskResult, error)
+	ExecuteBatch(ctx context.Context, params BatchParams) (*BatchResult, error)
+	GetChanges(ctx context.Context, params GetChangesParams) (*ChangesResult, error)
+	ApplyChanges(ctx context.Context, params ApplyChangesParams) (*ApplyChangesResult, error)
+	ExportTasks(ctx context.Context, format TransferFormat, w io.Writer) error
+	ImportTasks(ctx context.Context, params ImportTasksParams) (*ImportResult, error)
+	WatchTasks(ctx context.Context) (<-chan TaskEvent, error)
 }
 
+const (
+	// DefaultSearchLimit is the page size used when none is provided
+	DefaultSearchLimit = 20
+	// MaxSearchLimit is the largest page size accepted by SearchTasks
+	MaxSearchLimit = 100
+	// MaxBatchOperations is the largest number of operations accepted by ExecuteBatch
+	MaxBatchOperations = 100
+	// MaxSyncMutations is the largest number of mutations accepted by ApplyChanges
+	MaxSyncMutations = 500
+)
+
 // taskUsecase implements TaskUsecase
 type taskUsecase struct {
-	taskRepo db.TaskRepository
+	taskRepo   db.TaskRepository
+	syncRepo   db.SyncRepository
+	bulkRepo   db.BulkRepository
+	transactor db.Transactor
+	events     *pubsub.Broker[TaskEvent]
 }
 
 // NewTaskUsecase creates a new instance of TaskUsecase
-func NewTaskUsecase(taskRepo db.TaskRepository) TaskUsecase {
+func NewTaskUsecase(taskRepo db.TaskRepository, syncRepo db.SyncRepository, bulkRepo db.BulkRepository, transactor db.Transactor) TaskUsecase {
 	return &taskUsecase{
-		taskRepo: taskRepo,
+		taskRepo:   taskRepo,
+		syncRepo:   syncRepo,
+		bulkRepo:   bulkRepo,
+		transactor: transactor,
+		events:     pubsub.NewBroker[TaskEvent](),
 	}
 }
 
@@ -32,24 +70,27 @@ func NewTaskUsecase(taskRepo db.TaskRepository) TaskUsecase {
 func (u *taskUsecase) CreateTask(ctx context.Context, params CreateTaskParams) (*TaskResult, error) {
 	// Validate input
 	if params.Title == "" {
-		return nil, errors.New("task title is required")
+		return nil, ErrTaskTitleRequired
 	}
 
 	// Convert params to model
 	task := &models.Task{
 		Title:       params.Title,
 		Description: params.Description,
+		DueAt:       params.DueAt,
+		ICalUID:     params.ICalUID,
 		Items:       make([]*models.TaskItem, 0, len(params.Items)),
 	}
 
 	for _, itemParam := range params.Items {
 		if itemParam.Title == "" {
-			return nil, errors.New("task item title is required")
+			return nil, ErrTaskItemTitleRequired
 		}
 
 		item := &models.TaskItem{
 			Title:     itemParam.Title,
 			Completed: itemParam.Completed,
+			ICalUID:   itemParam.ICalUID,
 		}
 		task.Items = append(task.Items, item)
 	}
@@ -60,22 +101,31 @@ func (u *taskUsecase) CreateTask(ctx context.Context, params CreateTaskParams) (
 	}
 
 	// Convert model to result
-	return u.modelToResult(task), nil
+	result := u.modelToResult(task)
+	u.publish(ctx, TaskEvent{Type: TaskEventCreated, TaskID: result.ID, Task: result})
+
+	return result, nil
 }
 
 // DeleteTask deletes a task by ID
 func (u *taskUsecase) DeleteTask(ctx context.Context, taskID int64) error {
 	if taskID <= 0 {
-		return errors.New("invalid task ID")
+		return ErrInvalidTaskID
 	}
 
-	return u.taskRepo.Delete(ctx, taskID)
+	if err := u.taskRepo.Delete(ctx, taskID); err != nil {
+		return err
+	}
+
+	u.publish(ctx, TaskEvent{Type: TaskEventDeleted, TaskID: taskID})
+
+	return nil
 }
 
 // GetTask retrieves a task by ID
 func (u *taskUsecase) GetTask(ctx context.Context, taskID int64) (*TaskResult, error) {
 	if taskID <= 0 {
-		return nil, errors.New("invalid task ID")
+		return nil, ErrInvalidTaskID
 	}
 
 	task, err := u.taskRepo.GetByID(ctx, taskID)
@@ -86,6 +136,16 @@ func (u *taskUsecase) GetTask(ctx context.Context, taskID int64) (*TaskResult, e
 	return u.modelToResult(task), nil
 }
 
+// GetTaskByICalUID retrieves the task whose VTODO, or the VTODO of one of whose items, has the given UID
+func (u *taskUsecase) GetTaskByICalUID(ctx context.Context, uid string) (*TaskResult, error) {
+	task, err := u.taskRepo.GetByICalUID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.modelToResult(task), nil
+}
+
 // ListTasks retrieves all tasks
 func (u *taskUsecase) ListTasks(ctx context.Context) (*TaskListResult, error) {
 	tasks, err := u.taskRepo.List(ctx)
@@ -103,26 +163,295 @@ func (u *taskUsecase) ListTasks(ctx context.Context) (*TaskListResult, error) {
 	}, nil
 }
 
+// StreamTasks reads all tasks through a cursor, stopping at the first error of fn
+func (u *taskUsecase) StreamTasks(ctx context.Context, fn func(task *TaskResult) error) error {
+	return u.taskRepo.ForEach(ctx, func(task *models.Task) error {
+		return fn(u.modelToResult(task))
+	})
+}
+
+// SearchTasks retrieves a page of tasks matching the given criteria, loading their items only when requested
+func (u *taskUsecase) SearchTasks(ctx context.Context, params SearchTasksParams) (*TaskPageResult, error) {
+	if params.Limit == 0 {
+		params.Limit = DefaultSearchLimit
+	}
+	if params.Limit < 0 || params.Limit > MaxSearchLimit || params.Offset < 0 {
+		return nil, ErrInvalidPagination
+	}
+
+	tasks, count, err := u.taskRepo.Search(ctx, db.TaskFilter{
+		TitleContains: params.TitleContains,
+		HasOpenItems:  params.HasOpenItems,
+		CreatedAfter:  params.CreatedAfter,
+		CreatedBefore: params.CreatedBefore,
+		Limit:         params.Limit,
+		Offset:        params.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if params.IncludeItems {
+		if err = u.attachItems(ctx, tasks); err != nil {
+			return nil, err
+		}
+	}
+
+	results := make([]TaskResult, 0, len(tasks))
+	for _, task := range tasks {
+		results = append(results, *u.modelToResult(task))
+	}
+
+	return &TaskPageResult{
+		Tasks:      results,
+		TotalCount: count,
+	}, nil
+}
+
+// ListTaskItems retrieves the items of several tasks at once, grouped by task ID
+func (u *taskUsecase) ListTaskItems(ctx context.Context, taskIDs []int64) (map[int64][]TaskItemResult, error) {
+	items, err := u.taskRepo.ListItemsByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[int64][]TaskItemResult, len(taskIDs))
+	for _, item := range items {
+		results[item.TaskID] = append(results[item.TaskID], itemModelToResult(item))
+	}
+
+	return results, nil
+}
+
+// UpdateTask changes the title and/or description of a task
+func (u *taskUsecase) UpdateTask(ctx context.Context, taskID int64, params UpdateTaskParams) (*TaskResult, error) {
+	if taskID <= 0 {
+		return nil, ErrInvalidTaskID
+	}
+	if params.Title != nil && *params.Title == "" {
+		return nil, ErrTaskTitleRequired
+	}
+
+	// The task stays locked until the update is committed, so that concurrent updates do not overwrite each other
+	var task *models.Task
+	err := u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if task, err = u.taskRepo.GetForUpdate(ctx, taskID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if params.Title != nil {
+			task.Title = *params.Title
+			task.FieldVersions.Touch(now, models.FieldTitle)
+		}
+		if params.Description != nil {
+			task.Description = *params.Description
+			task.FieldVersions.Touch(now, models.FieldDescription)
+		}
+		if params.ClearDueAt {
+			task.DueAt = nil
+			task.FieldVersions.Touch(now, models.FieldDueAt)
+		} else if params.DueAt != nil {
+			task.DueAt = params.DueAt
+			task.FieldVersions.Touch(now, models.FieldDueAt)
+		}
+
+		return u.taskRepo.Update(ctx, task)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.publish(ctx, TaskEvent{Type: TaskEventUpdated, TaskID: taskID})
+
+	return u.modelToResult(task), nil
+}
+
+// AddTaskItem adds a new item to an existing task
+func (u *taskUsecase) AddTaskItem(ctx context.Context, taskID int64, params CreateTaskItemParams) (*TaskItemResult, error) {
+	if taskID <= 0 {
+		return nil, ErrInvalidTaskID
+	}
+	if params.Title == "" {
+		return nil, ErrTaskItemTitleRequired
+	}
+
+	item := &models.TaskItem{
+		TaskID:    taskID,
+		Title:     params.Title,
+		Completed: params.Completed,
+		ICalUID:   params.ICalUID,
+	}
+	if err := u.taskRepo.CreateItem(ctx, item); err != nil {
+		return nil, err
+	}
+
+	u.publish(ctx, TaskEvent{Type: TaskEventUpdated, TaskID: taskID})
+
+	result := itemModelToResult(item)
+	return &result, nil
+}
+
+// UpdateTaskItem changes the title and/or completion status of a task item
+func (u *taskUsecase) UpdateTaskItem(ctx context.Context, taskID int64, itemID int64, params UpdateTaskItemParams) (*TaskItemResult, error) {
+	if taskID <= 0 {
+		return nil, ErrInvalidTaskID
+	}
+	if itemID <= 0 {
+		return nil, ErrInvalidTaskItemID
+	}
+	if params.Title != nil && *params.Title == "" {
+		return nil, ErrTaskItemTitleRequired
+	}
+
+	// The item stays locked until the update is committed, so that concurrent updates do not overwrite each other
+	var item *models.TaskItem
+	err := u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if item, err = u.taskRepo.GetItemForUpdate(ctx, taskID, itemID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if params.Title != nil {
+			item.Title = *params.Title
+			item.FieldVersions.Touch(now, models.FieldTitle)
+		}
+		if params.Completed != nil {
+			item.Completed = *params.Completed
+			item.FieldVersions.Touch(now, models.FieldCompleted)
+		}
+
+		return u.taskRepo.UpdateItem(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u.publish(ctx, TaskEvent{Type: TaskEventUpdated, TaskID: taskID})
+
+	result := itemModelToResult(item)
+	return &result, nil
+}
+
+// DeleteTaskItem removes an item from a task
+func (u *taskUsecase) DeleteTaskItem(ctx context.Context, taskID int64, itemID int64) error {
+	if taskID <= 0 {
+		return ErrInvalidTaskID
+	}
+	if itemID <= 0 {
+		return ErrInvalidTaskItemID
+	}
+
+	if err := u.taskRepo.DeleteItem(ctx, taskID, itemID); err != nil {
+		return err
+	}
+
+	u.publish(ctx, TaskEvent{Type: TaskEventUpdated, TaskID: taskID})
+
+	return nil
+}
+
+// GetTaskStats computes aggregated counters over all tasks and items
+func (u *taskUsecase) GetTaskStats(ctx context.Context) (*TaskStatsResult, error) {
+	stats, err := u.taskRepo.Stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskStatsResult{
+		TaskCount:          stats.TaskCount,
+		ItemCount:          stats.ItemCount,
+		CompletedItemCount: stats.CompletedItemCount,
+		OpenItemCount:      stats.ItemCount - stats.CompletedItemCount,
+	}, nil
+}
+
+// attachItems loads the items of several tasks with a single repository call
+func (u *taskUsecase) attachItems(ctx context.Context, tasks []*models.Task) error {
+	taskIDs := make([]int64, 0, len(tasks))
+	byID := make(map[int64]*models.Task, len(tasks))
+	for _, task := range tasks {
+		taskIDs = append(taskIDs, task.ID)
+		byID[task.ID] = task
+		task.Items = nil
+	}
+
+	items, err := u.taskRepo.ListItemsByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		task := byID[item.TaskID]
+		task.Items = append(task.Items, item)
+	}
+
+	return nil
+}
+
+// WatchTasks subscribes to the task change feed
+// The returned channel is closed when the context is done
+func (u *taskUsecase) WatchTasks(ctx context.Context) (<-chan TaskEvent, error) {
+	if u.events == nil {
+		return nil, ErrChangeFeedUnavailable
+	}
+
+	return u.events.Subscribe(ctx), nil
+}
+
+// withoutEvents returns a copy of u publishing no events, for the operations of a transaction
+func (u *taskUsecase) withoutEvents() *taskUsecase {
+	txUsecase := *u
+	txUsecase.events = nil
+	return &txUsecase
+}
+
+// publish sends an event on the task change feed
+func (u *taskUsecase) publish(ctx context.Context, event TaskEvent) {
+	if u.events == nil {
+		return
+	}
+
+	if dropped := u.events.Publish(event); dropped > 0 {
+		log := logger.NewLogger(ctx, "task_usecase")
+		log.Warn().
+			Str("type", string(event.Type)).
+			Int64("taskID", event.TaskID).
+			Int("dropped", dropped).
+			Msg("Slow task watchers missed an event")
+	}
+}
+
 // modelToResult converts a Task model to TaskResult
 func (u *taskUsecase) modelToResult(task *models.Task) *TaskResult {
 	items := make([]TaskItemResult, 0, len(task.Items))
 	for _, item := range task.Items {
-		items = append(items, TaskItemResult{
-			ID:        item.ID,
-			TaskID:    item.TaskID,
-			Title:     item.Title,
-			Completed: item.Completed,
-			CreatedAt: item.CreatedAt,
-			UpdatedAt: item.UpdatedAt,
-		})
+		items = append(items, itemModelToResult(item))
 	}
 
 	return &TaskResult{
 		ID:          task.ID,
 		Title:       task.Title,
 		Description: task.Description,
+		DueAt:       task.DueAt,
+		ICalUID:     task.ICalUID,
 		CreatedAt:   task.CreatedAt,
 		UpdatedAt:   task.UpdatedAt,
 		Items:       items,
 	}
 }
+
+// itemModelToResult converts a TaskItem model to TaskItemResult
+func itemModelToResult(item *models.TaskItem) TaskItemResult {
+	return TaskItemResult{
+		ID:        item.ID,
+		TaskID:    item.TaskID,
+		Title:     item.Title,
+		Completed: item.Completed,
+		ICalUID:   item.ICalUID,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
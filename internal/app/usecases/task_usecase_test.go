@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/clevertechware/todo-bun-app/internal/app/db"
 	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
@@ -89,8 +91,10 @@ func TestTaskUsecase_CreateTask(t *testing.T) {
 					Description: "No title",
 				},
 			},
-			want:    nil,
-			wantErr: assert.Error,
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrTaskTitleRequired)
+			},
 		},
 		{
 			name: "should return error when item title is empty",
@@ -111,8 +115,10 @@ func TestTaskUsecase_CreateTask(t *testing.T) {
 					},
 				},
 			},
-			want:    nil,
-			wantErr: assert.Error,
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrTaskItemTitleRequired)
+			},
 		},
 		{
 			name: "should return error when repository fails",
@@ -450,3 +456,702 @@ func TestTaskUsecase_ListTasks(t *testing.T) {
 		})
 	}
 }
+
+func TestTaskUsecase_StreamTasks(t *testing.T) {
+	t.Parallel()
+
+	errStop := errors.New("stop")
+	rows := []*models.Task{
+		{ID: 1, Title: "Shopping", Items: []*models.TaskItem{{ID: 1, TaskID: 1, Title: "Buy milk"}}},
+		{ID: 2, Title: "Work", Items: []*models.TaskItem{}},
+	}
+
+	tests := []struct {
+		name    string
+		fn      func(task *TaskResult) error
+		want    []TaskResult
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should call fn with every task",
+			fn:   func(*TaskResult) error { return nil },
+			want: []TaskResult{
+				{ID: 1, Title: "Shopping", Items: []TaskItemResult{{ID: 1, TaskID: 1, Title: "Buy milk"}}},
+				{ID: 2, Title: "Work", Items: []TaskItemResult{}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should stop at the first error of fn",
+			fn:   func(*TaskResult) error { return errStop },
+			want: []TaskResult{
+				{ID: 1, Title: "Shopping", Items: []TaskItemResult{{ID: 1, TaskID: 1, Title: "Buy milk"}}},
+			},
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, errStop)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := mocks.NewTaskRepository(t)
+			m.On("ForEach", mock.Anything, mock.Anything).
+				Return(func(_ context.Context, fn func(*models.Task) error) error {
+					for _, row := range rows {
+						if err := fn(row); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+			u := &taskUsecase{
+				taskRepo: m,
+			}
+
+			var got []TaskResult
+			err := u.StreamTasks(context.Background(), func(task *TaskResult) error {
+				got = append(got, *task)
+				return tt.fn(task)
+			})
+
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaskUsecase_WatchTasks(t *testing.T) {
+	t.Parallel()
+
+	type step func(t *testing.T, u TaskUsecase)
+
+	tests := []struct {
+		name       string
+		taskRepo   func(t *testing.T) db.TaskRepository
+		step       step
+		wantEvents []TaskEvent
+	}{
+		{
+			name: "should publish created event when task is created",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					args.Get(1).(*models.Task).ID = 1
+				}).Return(nil)
+				return m
+			},
+			step: func(t *testing.T, u TaskUsecase) {
+				_, err := u.CreateTask(context.Background(), CreateTaskParams{Title: "Shopping"})
+				require.NoError(t, err)
+			},
+			wantEvents: []TaskEvent{
+				{Type: TaskEventCreated, TaskID: 1},
+			},
+		},
+		{
+			name: "should publish deleted event when task is deleted",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("Delete", mock.Anything, int64(1)).Return(nil)
+				return m
+			},
+			step: func(t *testing.T, u TaskUsecase) {
+				require.NoError(t, u.DeleteTask(context.Background(), 1))
+			},
+			wantEvents: []TaskEvent{
+				{Type: TaskEventDeleted, TaskID: 1},
+			},
+		},
+		{
+			name: "should not publish event when repository fails",
+			taskRepo: func(t *testing.T) db.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("Delete", mock.Anything, int64(1)).Return(db.ErrTaskNotFound)
+				return m
+			},
+			step: func(t *testing.T, u TaskUsecase) {
+				require.Error(t, u.DeleteTask(context.Background(), 1))
+			},
+			wantEvents: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := NewTaskUsecase(tt.taskRepo(t), nil, nil, nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			events, err := u.WatchTasks(ctx)
+			require.NoError(t, err)
+
+			tt.step(t, u)
+			cancel()
+
+			var got []TaskEvent
+			for event := range events {
+				got = append(got, TaskEvent{Type: event.Type, TaskID: event.TaskID})
+			}
+			assert.Equal(t, tt.wantEvents, got)
+		})
+	}
+}
+
+func TestTaskUsecase_SearchTasks(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		taskRepo func(t *testing.T) db.TaskRepository
+	}
+
+	type args struct {
+		ctx    context.Context
+		params SearchTasksParams
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *TaskPageResult
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should search tasks with default limit",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("Search", mock.Anything, db.TaskFilter{TitleContains: "shop", Limit: DefaultSearchLimit}).
+						Return([]*models.Task{{ID: 1, Title: "Shopping"}}, 1, nil)
+					return m
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				params: SearchTasksParams{TitleContains: "shop"},
+			},
+			want: &TaskPageResult{
+				Tasks:      []TaskResult{{ID: 1, Title: "Shopping", Items: []TaskItemResult{}}},
+				TotalCount: 1,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should attach items when requested",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("Search", mock.Anything, db.TaskFilter{Limit: 2}).
+						Return([]*models.Task{{ID: 2, Title: "Work"}, {ID: 1, Title: "Shopping"}}, 2, nil)
+					m.On("ListItemsByTaskIDs", mock.Anything, []int64{2, 1}).
+						Return([]*models.TaskItem{{ID: 7, TaskID: 1, Title: "Buy milk"}}, nil)
+					return m
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				params: SearchTasksParams{Limit: 2, IncludeItems: true},
+			},
+			want: &TaskPageResult{
+				Tasks: []TaskResult{
+					{ID: 2, Title: "Work", Items: []TaskItemResult{}},
+					{ID: 1, Title: "Shopping", Items: []TaskItemResult{{ID: 7, TaskID: 1, Title: "Buy milk"}}},
+				},
+				TotalCount: 2,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should return error when limit is too large",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					// Repository should not be called
+					return m
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				params: SearchTasksParams{Limit: MaxSearchLimit + 1},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidPagination)
+			},
+		},
+		{
+			name: "should return error when repository fails",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("Search", mock.Anything, mock.Anything).Return(nil, 0, errors.New("database error"))
+					return m
+				},
+			},
+			args: args{
+				ctx:    context.Background(),
+				params: SearchTasksParams{},
+			},
+			want:    nil,
+			wantErr: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &taskUsecase{
+				taskRepo: tt.fields.taskRepo(t),
+			}
+
+			got, err := u.SearchTasks(tt.args.ctx, tt.args.params)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaskUsecase_ListTaskItems(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewTaskRepository(t)
+	m.On("ListItemsByTaskIDs", mock.Anything, []int64{1, 2, 3}).Return([]*models.TaskItem{
+		{ID: 1, TaskID: 1, Title: "Buy milk"},
+		{ID: 2, TaskID: 1, Title: "Buy bread"},
+		{ID: 3, TaskID: 3, Title: "Write report"},
+	}, nil)
+
+	u := &taskUsecase{taskRepo: m}
+
+	got, err := u.ListTaskItems(context.Background(), []int64{1, 2, 3})
+
+	require.NoError(t, err)
+	assert.Len(t, got[1], 2)
+	assert.Empty(t, got[2])
+	assert.Len(t, got[3], 1)
+}
+
+func TestTaskUsecase_GetTaskStats(t *testing.T) {
+	t.Parallel()
+
+	m := mocks.NewTaskRepository(t)
+	m.On("Stats", mock.Anything).Return(&db.TaskStats{TaskCount: 2, ItemCount: 5, CompletedItemCount: 3}, nil)
+
+	u := &taskUsecase{taskRepo: m}
+
+	got, err := u.GetTaskStats(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &TaskStatsResult{TaskCount: 2, ItemCount: 5, CompletedItemCount: 3, OpenItemCount: 2}, got)
+}
+
+func TestTaskUsecase_AddTaskItem(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		taskRepo func(t *testing.T) db.TaskRepository
+	}
+
+	type args struct {
+		taskID int64
+		params CreateTaskItemParams
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *TaskItemResult
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should add item successfully",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("CreateItem", mock.Anything, mock.MatchedBy(func(item *models.TaskItem) bool {
+						return item.TaskID == 1 && item.Title == "Buy milk"
+					})).Run(func(args mock.Arguments) {
+						args.Get(1).(*models.TaskItem).ID = 10
+					}).Return(nil)
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				params: CreateTaskItemParams{Title: "Buy milk"},
+			},
+			want:    &TaskItemResult{ID: 10, TaskID: 1, Title: "Buy milk"},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should return error when title is empty",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					// Repository should not be called
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				params: CreateTaskItemParams{},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrTaskItemTitleRequired)
+			},
+		},
+		{
+			name: "should return error when task does not exist",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("CreateItem", mock.Anything, mock.Anything).Return(db.ErrTaskNotFound)
+					return m
+				},
+			},
+			args: args{
+				taskID: 999,
+				params: CreateTaskItemParams{Title: "Buy milk"},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, db.ErrTaskNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &taskUsecase{
+				taskRepo: tt.fields.taskRepo(t),
+			}
+
+			got, err := u.AddTaskItem(context.Background(), tt.args.taskID, tt.args.params)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaskUsecase_UpdateTaskItem(t *testing.T) {
+	t.Parallel()
+
+	completed := true
+	emptyTitle := ""
+
+	type fields struct {
+		taskRepo func(t *testing.T) db.TaskRepository
+	}
+
+	type args struct {
+		taskID int64
+		itemID int64
+		params UpdateTaskItemParams
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *TaskItemResult
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should complete item and keep its title",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("GetItemForUpdate", mock.Anything, int64(1), int64(10)).
+						Return(&models.TaskItem{ID: 10, TaskID: 1, Title: "Buy milk"}, nil)
+					m.On("UpdateItem", mock.Anything, mock.MatchedBy(func(item *models.TaskItem) bool {
+						_, touched := item.FieldVersions[models.FieldCompleted]
+						_, titleTouched := item.FieldVersions[models.FieldTitle]
+						return item.ID == 10 && item.Title == "Buy milk" && item.Completed && touched && !titleTouched
+					})).Return(nil)
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				itemID: 10,
+				params: UpdateTaskItemParams{Completed: &completed},
+			},
+			want:    &TaskItemResult{ID: 10, TaskID: 1, Title: "Buy milk", Completed: true},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should return error when title is set to empty",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					// Repository should not be called
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				itemID: 10,
+				params: UpdateTaskItemParams{Title: &emptyTitle},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrTaskItemTitleRequired)
+			},
+		},
+		{
+			name: "should return error when item ID is invalid",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					// Repository should not be called
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				itemID: 0,
+				params: UpdateTaskItemParams{Completed: &completed},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidTaskItemID)
+			},
+		},
+		{
+			name: "should return error when item does not belong to the task",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("GetItemForUpdate", mock.Anything, int64(1), int64(10)).
+						Return(nil, db.ErrTaskItemNotFound)
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				itemID: 10,
+				params: UpdateTaskItemParams{Completed: &completed},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, db.ErrTaskItemNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &taskUsecase{
+				taskRepo:   tt.fields.taskRepo(t),
+				transactor: newTestTransactor(t, nil),
+			}
+
+			got, err := u.UpdateTaskItem(context.Background(), tt.args.taskID, tt.args.itemID, tt.args.params)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTaskUsecase_DeleteTaskItem(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should delete item and publish an update event", func(t *testing.T) {
+		t.Parallel()
+
+		m := mocks.NewTaskRepository(t)
+		m.On("DeleteItem", mock.Anything, int64(1), int64(10)).Return(nil)
+
+		u := NewTaskUsecase(m, nil, nil, nil).(*taskUsecase)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events, err := u.WatchTasks(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, u.DeleteTaskItem(context.Background(), 1, 10))
+
+		event := <-events
+		assert.Equal(t, TaskEvent{Type: TaskEventUpdated, TaskID: 1}, event)
+	})
+
+	t.Run("should return error when item is not found", func(t *testing.T) {
+		t.Parallel()
+
+		m := mocks.NewTaskRepository(t)
+		m.On("DeleteItem", mock.Anything, int64(1), int64(999)).Return(db.ErrTaskItemNotFound)
+
+		u := &taskUsecase{taskRepo: m}
+
+		err := u.DeleteTaskItem(context.Background(), 1, 999)
+		assert.ErrorIs(t, err, db.ErrTaskItemNotFound)
+	})
+}
+
+func TestTaskUsecase_UpdateTask(t *testing.T) {
+	t.Parallel()
+
+	title := "Groceries"
+	emptyTitle := ""
+	dueAt := time.Date(2025, 4, 15, 18, 0, 0, 0, time.UTC)
+
+	type fields struct {
+		taskRepo func(t *testing.T) db.TaskRepository
+	}
+
+	type args struct {
+		taskID int64
+		params UpdateTaskParams
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *TaskResult
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "should rename task and keep its description and items",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Task{
+						ID:          1,
+						Title:       "Shopping",
+						Description: "Weekly",
+						Items:       []*models.TaskItem{{ID: 2, TaskID: 1, Title: "Buy milk"}},
+					}, nil)
+					m.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+						return task.Title == title && task.Description == "Weekly"
+					})).Return(nil)
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				params: UpdateTaskParams{Title: &title},
+			},
+			want: &TaskResult{
+				ID:          1,
+				Title:       title,
+				Description: "Weekly",
+				Items:       []TaskItemResult{{ID: 2, TaskID: 1, Title: "Buy milk"}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should set the due date",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Task{ID: 1, Title: "Taxes"}, nil)
+					m.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+						_, touched := task.FieldVersions[models.FieldDueAt]
+						return task.DueAt != nil && task.DueAt.Equal(dueAt) && touched
+					})).Return(nil)
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				params: UpdateTaskParams{DueAt: &dueAt},
+			},
+			want:    &TaskResult{ID: 1, Title: "Taxes", DueAt: &dueAt, Items: []TaskItemResult{}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should clear the due date",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("GetForUpdate", mock.Anything, int64(1)).Return(&models.Task{ID: 1, Title: "Taxes", DueAt: &dueAt}, nil)
+					m.On("Update", mock.Anything, mock.MatchedBy(func(task *models.Task) bool {
+						return task.DueAt == nil
+					})).Return(nil)
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				params: UpdateTaskParams{DueAt: &dueAt, ClearDueAt: true},
+			},
+			want:    &TaskResult{ID: 1, Title: "Taxes", Items: []TaskItemResult{}},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "should return error when title is set to empty",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					// Repository should not be called
+					return m
+				},
+			},
+			args: args{
+				taskID: 1,
+				params: UpdateTaskParams{Title: &emptyTitle},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrTaskTitleRequired)
+			},
+		},
+		{
+			name: "should return error when task does not exist",
+			fields: fields{
+				taskRepo: func(t *testing.T) db.TaskRepository {
+					m := mocks.NewTaskRepository(t)
+					m.On("GetForUpdate", mock.Anything, int64(999)).Return(nil, db.ErrTaskNotFound)
+					return m
+				},
+			},
+			args: args{
+				taskID: 999,
+				params: UpdateTaskParams{Title: &title},
+			},
+			want: nil,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, db.ErrTaskNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			u := &taskUsecase{
+				taskRepo:   tt.fields.taskRepo(t),
+				transactor: newTestTransactor(t, nil),
+			}
+
+			got, err := u.UpdateTask(context.Background(), tt.args.taskID, tt.args.params)
+
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
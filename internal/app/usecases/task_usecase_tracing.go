@@ -0,0 +1,176 @@
+package usecases
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/clevertechware/todo-bun-app/internal/pkg/tracing"
+)
+
+// Attributes of the usecase spans
+const (
+	taskIDKey = attribute.Key("task.id")
+	itemIDKey = attribute.Key("task.item.id")
+)
+
+// tracingTaskUsecase records a span around every method of the TaskUsecase it decorates
+type tracingTaskUsecase struct {
+	next TaskUsecase
+}
+
+// NewTracingTaskUsecase decorates next with a span per call, between the handler and the query spans
+func NewTracingTaskUsecase(next TaskUsecase) TaskUsecase {
+	return &tracingTaskUsecase{next: next}
+}
+
+// startSpan starts the span of the method name
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, "TaskUsecase."+name, trace.WithAttributes(attributes...))
+}
+
+// endSpan ends span, failed with err if any
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (u *tracingTaskUsecase) CreateTask(ctx context.Context, params CreateTaskParams) (*TaskResult, error) {
+	ctx, span := startSpan(ctx, "CreateTask")
+	result, err := u.next.CreateTask(ctx, params)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) DeleteTask(ctx context.Context, taskID int64) error {
+	ctx, span := startSpan(ctx, "DeleteTask", taskIDKey.Int64(taskID))
+	err := u.next.DeleteTask(ctx, taskID)
+	endSpan(span, err)
+	return err
+}
+
+func (u *tracingTaskUsecase) GetTask(ctx context.Context, taskID int64) (*TaskResult, error) {
+	ctx, span := startSpan(ctx, "GetTask", taskIDKey.Int64(taskID))
+	result, err := u.next.GetTask(ctx, taskID)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) GetTaskByICalUID(ctx context.Context, uid string) (*TaskResult, error) {
+	ctx, span := startSpan(ctx, "GetTaskByICalUID")
+	result, err := u.next.GetTaskByICalUID(ctx, uid)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) ListTasks(ctx context.Context) (*TaskListResult, error) {
+	ctx, span := startSpan(ctx, "ListTasks")
+	result, err := u.next.ListTasks(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) StreamTasks(ctx context.Context, fn func(task *TaskResult) error) error {
+	ctx, span := startSpan(ctx, "StreamTasks")
+	err := u.next.StreamTasks(ctx, fn)
+	endSpan(span, err)
+	return err
+}
+
+func (u *tracingTaskUsecase) SearchTasks(ctx context.Context, params SearchTasksParams) (*TaskPageResult, error) {
+	ctx, span := startSpan(ctx, "SearchTasks")
+	result, err := u.next.SearchTasks(ctx, params)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) ListTaskItems(ctx context.Context, taskIDs []int64) (map[int64][]TaskItemResult, error) {
+	ctx, span := startSpan(ctx, "ListTaskItems", taskIDKey.Int64Slice(taskIDs))
+	result, err := u.next.ListTaskItems(ctx, taskIDs)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) GetTaskStats(ctx context.Context) (*TaskStatsResult, error) {
+	ctx, span := startSpan(ctx, "GetTaskStats")
+	result, err := u.next.GetTaskStats(ctx)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) AddTaskItem(ctx context.Context, taskID int64, params CreateTaskItemParams) (*TaskItemResult, error) {
+	ctx, span := startSpan(ctx, "AddTaskItem", taskIDKey.Int64(taskID))
+	result, err := u.next.AddTaskItem(ctx, taskID, params)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) UpdateTaskItem(ctx context.Context, taskID int64, itemID int64, params UpdateTaskItemParams) (*TaskItemResult, error) {
+	ctx, span := startSpan(ctx, "UpdateTaskItem", taskIDKey.Int64(taskID), itemIDKey.Int64(itemID))
+	result, err := u.next.UpdateTaskItem(ctx, taskID, itemID, params)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) DeleteTaskItem(ctx context.Context, taskID int64, itemID int64) error {
+	ctx, span := startSpan(ctx, "DeleteTaskItem", taskIDKey.Int64(taskID), itemIDKey.Int64(itemID))
+	err := u.next.DeleteTaskItem(ctx, taskID, itemID)
+	endSpan(span, err)
+	return err
+}
+
+func (u *tracingTaskUsecase) UpdateTask(ctx context.Context, taskID int64, params UpdateTaskParams) (*TaskResult, error) {
+	ctx, span := startSpan(ctx, "UpdateTask", taskIDKey.Int64(taskID))
+	result, err := u.next.UpdateTask(ctx, taskID, params)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) ExecuteBatch(ctx context.Context, params BatchParams) (*BatchResult, error) {
+	ctx, span := startSpan(ctx, "ExecuteBatch", attribute.Int("batch.operations", len(params.Operations)))
+	result, err := u.next.ExecuteBatch(ctx, params)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) GetChanges(ctx context.Context, params GetChangesParams) (*ChangesResult, error) {
+	ctx, span := startSpan(ctx, "GetChanges")
+	result, err := u.next.GetChanges(ctx, params)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) ApplyChanges(ctx context.Context, params ApplyChangesParams) (*ApplyChangesResult, error) {
+	ctx, span := startSpan(ctx, "ApplyChanges", attribute.Int("sync.mutations", len(params.Mutations)))
+	result, err := u.next.ApplyChanges(ctx, params)
+	endSpan(span, err)
+	return result, err
+}
+
+func (u *tracingTaskUsecase) ExportTasks(ctx context.Context, format TransferFormat, w io.Writer) error {
+	ctx, span := startSpan(ctx, "ExportTasks", attribute.String("transfer.format", string(format)))
+	err := u.next.ExportTasks(ctx, format, w)
+	endSpan(span, err)
+	return err
+}
+
+func (u *tracingTaskUsecase) ImportTasks(ctx context.Context, params ImportTasksParams) (*ImportResult, error) {
+	ctx, span := startSpan(ctx, "ImportTasks", attribute.String("transfer.format", string(params.Format)))
+	result, err := u.next.ImportTasks(ctx, params)
+	endSpan(span, err)
+	return result, err
+}
+
+// WatchTasks only traces the subscription, the events flowing long after the span ends
+func (u *tracingTaskUsecase) WatchTasks(ctx context.Context) (<-chan TaskEvent, error) {
+	_, span := startSpan(ctx, "WatchTasks")
+	events, err := u.next.WatchTasks(ctx)
+	endSpan(span, err)
+	return events, err
+}
@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+)
+
+func TestTracingTaskUsecase_GetTask(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	tests := []struct {
+		name       string
+		taskID     int64
+		taskRepo   func(t *testing.T) *mocks.TaskRepository
+		wantStatus codes.Code
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:   "should record a span parent of the repository calls",
+			taskID: 1,
+			taskRepo: func(t *testing.T) *mocks.TaskRepository {
+				m := mocks.NewTaskRepository(t)
+				m.On("GetByID", mock.MatchedBy(func(ctx context.Context) bool {
+					return trace.SpanFromContext(ctx).SpanContext().IsValid()
+				}), int64(1)).Return(&models.Task{ID: 1, Title: "Buy groceries"}, nil)
+				return m
+			},
+			wantStatus: codes.Unset,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:   "should record the error of the call",
+			taskID: 0,
+			taskRepo: func(t *testing.T) *mocks.TaskRepository {
+				return mocks.NewTaskRepository(t)
+			},
+			wantStatus: codes.Error,
+			wantErr: func(t assert.TestingT, err error, _ ...interface{}) bool {
+				return assert.ErrorIs(t, err, ErrInvalidTaskID)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Reset()
+			u := NewTracingTaskUsecase(NewTaskUsecase(tt.taskRepo(t), nil, nil, nil))
+
+			_, err := u.GetTask(context.Background(), tt.taskID)
+			tt.wantErr(t, err)
+
+			spans := recorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, "TaskUsecase.GetTask", spans[0].Name())
+			assert.Equal(t, tt.wantStatus, spans[0].Status().Code)
+			assert.Contains(t, spans[0].Attributes(), taskIDKey.Int64(tt.taskID))
+		})
+	}
+}
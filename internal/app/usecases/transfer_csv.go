@@ -0,0 +1,120 @@
+package usecases
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// csvHeader lists the columns of a CSV export, one row per item
+// Tasks without items are exported as a single row with an empty item
+var csvHeader = []string{"task", "description", "item", "completed"}
+
+func encodeCSVTasks(w io.Writer, tasks []TaskResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		if len(task.Items) == 0 {
+			if err := writer.Write([]string{task.Title, task.Description, "", ""}); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, item := range task.Items {
+			if err := writer.Write([]string{task.Title, task.Description, item.Title, strconv.FormatBool(item.Completed)}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// decodeCSVTasks reads rows with a header naming at least the task column, in any order
+// Consecutive rows of the same task are merged
+func decodeCSVTasks(r io.Reader) ([]CreateTaskParams, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return []CreateTaskParams{}, nil
+	}
+	if err != nil {
+		return nil, invalidImportf("%v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	taskColumn, ok := columns["task"]
+	if !ok {
+		if taskColumn, ok = columns["title"]; !ok {
+			return nil, invalidImportf("missing task column in CSV header")
+		}
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	params := make([]CreateTaskParams, 0)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return params, nil
+		}
+		if err != nil {
+			return nil, invalidImportf("%v", err)
+		}
+
+		title := ""
+		if taskColumn < len(record) {
+			title = strings.TrimSpace(record[taskColumn])
+		}
+		if title == "" {
+			line, _ := reader.FieldPos(0)
+			return nil, invalidImportf("line %d: missing task title", line)
+		}
+
+		if len(params) == 0 || params[len(params)-1].Title != title {
+			params = append(params, CreateTaskParams{
+				Title:       title,
+				Description: field(record, "description"),
+				Items:       make([]CreateTaskItemParams, 0),
+			})
+		}
+
+		if itemTitle := field(record, "item"); itemTitle != "" {
+			completed, err := parseCompleted(field(record, "completed"))
+			if err != nil {
+				line, _ := reader.FieldPos(0)
+				return nil, invalidImportf("line %d: %v", line, err)
+			}
+			task := &params[len(params)-1]
+			task.Items = append(task.Items, CreateTaskItemParams{Title: itemTitle, Completed: completed})
+		}
+	}
+}
+
+// parseCompleted accepts the usual spellings of a boolean in spreadsheets
+func parseCompleted(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "", "false", "0", "no", "n":
+		return false, nil
+	case "true", "1", "yes", "y", "x", "done":
+		return true, nil
+	default:
+		return false, errors.New("invalid completed value " + strconv.Quote(value))
+	}
+}
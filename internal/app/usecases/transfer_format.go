@@ -0,0 +1,124 @@
+package usecases
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// TransferFormat identifies a file format tasks are exported to and imported from
+type TransferFormat string
+
+const (
+	TransferJSON     TransferFormat = "json"
+	TransferCSV      TransferFormat = "csv"
+	TransferMarkdown TransferFormat = "markdown"
+	TransferTodoTxt  TransferFormat = "todotxt"
+	TransferICal     TransferFormat = "ical"
+)
+
+// TransferFormats lists the supported formats
+var TransferFormats = []TransferFormat{TransferJSON, TransferCSV, TransferMarkdown, TransferTodoTxt, TransferICal}
+
+// ParseTransferFormat returns the format named s
+func ParseTransferFormat(s string) (TransferFormat, error) {
+	for _, format := range TransferFormats {
+		if strings.EqualFold(s, string(format)) {
+			return format, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrUnknownTransferFormat, s)
+}
+
+// TransferFormatFromFilename guesses the format of a file from its extension
+func TransferFormatFromFilename(name string) (TransferFormat, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return TransferJSON, nil
+	case ".csv":
+		return TransferCSV, nil
+	case ".md", ".markdown":
+		return TransferMarkdown, nil
+	case ".txt":
+		return TransferTodoTxt, nil
+	case ".ics", ".ical":
+		return TransferICal, nil
+	default:
+		return "", fmt.Errorf("%w: cannot guess the format of %q", ErrUnknownTransferFormat, name)
+	}
+}
+
+// ContentType returns the MIME type of the format
+func (f TransferFormat) ContentType() string {
+	switch f {
+	case TransferJSON:
+		return "application/json"
+	case TransferCSV:
+		return "text/csv; charset=utf-8"
+	case TransferMarkdown:
+		return "text/markdown; charset=utf-8"
+	case TransferICal:
+		return "text/calendar; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Extension returns the file extension of the format, including the dot
+func (f TransferFormat) Extension() string {
+	switch f {
+	case TransferJSON:
+		return ".json"
+	case TransferCSV:
+		return ".csv"
+	case TransferMarkdown:
+		return ".md"
+	case TransferICal:
+		return ".ics"
+	default:
+		return ".txt"
+	}
+}
+
+// encodeTasks writes tasks with their items in the given format
+func encodeTasks(w io.Writer, format TransferFormat, tasks []TaskResult) error {
+	switch format {
+	case TransferJSON:
+		return encodeJSONTasks(w, tasks)
+	case TransferCSV:
+		return encodeCSVTasks(w, tasks)
+	case TransferMarkdown:
+		return encodeMarkdownTasks(w, tasks)
+	case TransferTodoTxt:
+		return encodeTodoTxtTasks(w, tasks)
+	case TransferICal:
+		return encodeICalTasks(w, tasks)
+	default:
+		return fmt.Errorf("%w: %q", ErrUnknownTransferFormat, format)
+	}
+}
+
+// decodeTasks reads tasks with their items in the given format
+func decodeTasks(r io.Reader, format TransferFormat) ([]CreateTaskParams, error) {
+	switch format {
+	case TransferJSON:
+		return decodeJSONTasks(r)
+	case TransferCSV:
+		return decodeCSVTasks(r)
+	case TransferMarkdown:
+		return decodeMarkdownTasks(r)
+	case TransferTodoTxt:
+		return decodeTodoTxtTasks(r)
+	case TransferICal:
+		return decodeICalTasks(r)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTransferFormat, format)
+	}
+}
+
+// invalidImportf reports a malformed import file
+func invalidImportf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidImportFile, fmt.Sprintf(format, args...))
+}
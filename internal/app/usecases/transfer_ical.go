@@ -0,0 +1,288 @@
+package usecases
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-ical"
+)
+
+const (
+	// icalProductID identifies the application in the calendars it writes
+	icalProductID = "-//todo-bun-app//TODO API//EN"
+
+	icalStatusNeedsAction = "NEEDS-ACTION"
+	icalStatusInProcess   = "IN-PROCESS"
+	icalStatusCompleted   = "COMPLETED"
+)
+
+// taskUID returns the iCalendar UID of a task
+func taskUID(taskID int64) string {
+	return fmt.Sprintf("task-%d@todo-bun-app", taskID)
+}
+
+// taskItemUID returns the iCalendar UID of a task item
+func taskItemUID(itemID int64) string {
+	return fmt.Sprintf("item-%d@todo-bun-app", itemID)
+}
+
+// taskResultUID returns the UID of the VTODO of a task, derived from its ID when none was stored
+func taskResultUID(task TaskResult) string {
+	if task.ICalUID != "" {
+		return task.ICalUID
+	}
+
+	return taskUID(task.ID)
+}
+
+// taskItemResultUID returns the UID of the VTODO of a task item, derived from its ID when none was stored
+func taskItemResultUID(item TaskItemResult) string {
+	if item.ICalUID != "" {
+		return item.ICalUID
+	}
+
+	return taskItemUID(item.ID)
+}
+
+// encodeICalTasks writes the tasks that have a due date as RFC 5545 VTODO components
+// Items are exported as sub-to-dos related to the VTODO of their task, with the due date of the task
+func encodeICalTasks(w io.Writer, tasks []TaskResult) error {
+	cal := ical.NewCalendar()
+	cal.Props.SetText(ical.PropVersion, "2.0")
+	cal.Props.SetText(ical.PropProductID, icalProductID)
+
+	for _, task := range tasks {
+		if task.DueAt == nil {
+			continue
+		}
+		cal.Children = append(cal.Children, taskToVTodo(task))
+		for _, item := range task.Items {
+			cal.Children = append(cal.Children, taskItemToVTodo(task, item))
+		}
+	}
+
+	// The encoder rejects calendars without components, which are still valid feeds
+	if len(cal.Children) == 0 {
+		_, err := fmt.Fprintf(w, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\nPRODID:%s\r\nEND:VCALENDAR\r\n", icalProductID)
+		return err
+	}
+
+	return ical.NewEncoder(w).Encode(cal)
+}
+
+// taskToVTodo converts a task to a VTODO, completed once all its items are
+func taskToVTodo(task TaskResult) *ical.Component {
+	todo := ical.NewComponent(ical.CompToDo)
+	todo.Props.SetText(ical.PropUID, taskResultUID(task))
+	todo.Props.SetDateTime(ical.PropDateTimeStamp, task.UpdatedAt.UTC())
+	todo.Props.SetDateTime(ical.PropCreated, task.CreatedAt.UTC())
+	todo.Props.SetDateTime(ical.PropLastModified, task.UpdatedAt.UTC())
+	todo.Props.SetText(ical.PropSummary, icalText(task.Title))
+	if task.Description != "" {
+		todo.Props.SetText(ical.PropDescription, icalText(task.Description))
+	}
+	if task.DueAt != nil {
+		todo.Props.SetDateTime(ical.PropDue, task.DueAt.UTC())
+	}
+
+	completed := 0
+	for _, item := range task.Items {
+		if item.Completed {
+			completed++
+		}
+	}
+	switch {
+	case len(task.Items) > 0 && completed == len(task.Items):
+		todo.Props.SetText(ical.PropStatus, icalStatusCompleted)
+	case completed > 0:
+		todo.Props.SetText(ical.PropStatus, icalStatusInProcess)
+	default:
+		todo.Props.SetText(ical.PropStatus, icalStatusNeedsAction)
+	}
+	if len(task.Items) > 0 {
+		percent := ical.NewProp(ical.PropPercentComplete)
+		percent.Value = strconv.Itoa(completed * 100 / len(task.Items))
+		todo.Props.Set(percent)
+	}
+
+	return todo
+}
+
+// taskItemToVTodo converts a task item to a VTODO whose parent is the VTODO of its task
+func taskItemToVTodo(task TaskResult, item TaskItemResult) *ical.Component {
+	todo := ical.NewComponent(ical.CompToDo)
+	todo.Props.SetText(ical.PropUID, taskItemResultUID(item))
+	todo.Props.SetDateTime(ical.PropDateTimeStamp, item.UpdatedAt.UTC())
+	todo.Props.SetDateTime(ical.PropCreated, item.CreatedAt.UTC())
+	todo.Props.SetDateTime(ical.PropLastModified, item.UpdatedAt.UTC())
+	todo.Props.SetText(ical.PropSummary, icalText(item.Title))
+	todo.Props.SetText(ical.PropRelatedTo, taskResultUID(task))
+	if task.DueAt != nil {
+		todo.Props.SetDateTime(ical.PropDue, task.DueAt.UTC())
+	}
+
+	if item.Completed {
+		todo.Props.SetText(ical.PropStatus, icalStatusCompleted)
+		todo.Props.SetDateTime(ical.PropCompleted, item.UpdatedAt.UTC())
+	} else {
+		todo.Props.SetText(ical.PropStatus, icalStatusNeedsAction)
+	}
+
+	return todo
+}
+
+// decodeICalTasks reads the VTODO and VEVENT components of one or more calendars
+// A VTODO whose parent is in the file becomes an item of the task of its parent, completed when its status is
+// COMPLETED; every other component becomes a task, due at the DUE of a VTODO or the start of a VEVENT
+func decodeICalTasks(r io.Reader) ([]CreateTaskParams, error) {
+	components, err := readICalComponents(r)
+	if err != nil {
+		return nil, err
+	}
+
+	uids := make(map[string]bool, len(components))
+	for _, comp := range components {
+		if uid, _ := comp.Props.Text(ical.PropUID); uid != "" {
+			uids[uid] = true
+		}
+	}
+
+	tasks := make([]CreateTaskParams, 0, len(components))
+	taskIndexes := make(map[string]int, len(components))
+	var children []*ical.Component
+	for _, comp := range components {
+		if parent := icalParentUID(comp); comp.Name == ical.CompToDo && uids[parent] {
+			children = append(children, comp)
+			continue
+		}
+
+		task, err := icalComponentToTask(comp)
+		if err != nil {
+			return nil, err
+		}
+		if uid, _ := comp.Props.Text(ical.PropUID); uid != "" {
+			taskIndexes[uid] = len(tasks)
+		}
+		tasks = append(tasks, task)
+	}
+
+	for _, comp := range children {
+		index, ok := taskIndexes[icalParentUID(comp)]
+		if !ok {
+			// Sub-to-dos of sub-to-dos have no task to belong to, they become tasks of their own
+			task, err := icalComponentToTask(comp)
+			if err != nil {
+				return nil, err
+			}
+			tasks = append(tasks, task)
+			continue
+		}
+
+		title, err := comp.Props.Text(ical.PropSummary)
+		if err != nil {
+			return nil, invalidImportf("to-do %q: %v", icalUID(comp), err)
+		}
+		tasks[index].Items = append(tasks[index].Items, CreateTaskItemParams{
+			Title:     title,
+			Completed: icalCompleted(comp),
+		})
+	}
+
+	return tasks, nil
+}
+
+// readICalComponents returns the VTODO and VEVENT components of every calendar of r
+func readICalComponents(r io.Reader) ([]*ical.Component, error) {
+	var components []*ical.Component
+
+	decoder := ical.NewDecoder(r)
+	for {
+		cal, err := decoder.Decode()
+		if errors.Is(err, io.EOF) {
+			return components, nil
+		}
+		if err != nil {
+			return nil, invalidImportf("%v", err)
+		}
+
+		for _, child := range cal.Children {
+			if child.Name == ical.CompToDo || child.Name == ical.CompEvent {
+				components = append(components, child)
+			}
+		}
+	}
+}
+
+// icalComponentToTask converts a VTODO or VEVENT to a task without items
+func icalComponentToTask(comp *ical.Component) (CreateTaskParams, error) {
+	task, err := readICalTask(comp)
+	if err != nil {
+		return CreateTaskParams{}, invalidImportf("%s %q: %v", comp.Name, icalUID(comp), err)
+	}
+
+	return task, nil
+}
+
+// readICalTask reads the summary, description and due date of a VTODO or VEVENT
+func readICalTask(comp *ical.Component) (CreateTaskParams, error) {
+	title, err := comp.Props.Text(ical.PropSummary)
+	if err != nil {
+		return CreateTaskParams{}, err
+	}
+	description, err := comp.Props.Text(ical.PropDescription)
+	if err != nil {
+		return CreateTaskParams{}, err
+	}
+
+	task := CreateTaskParams{
+		Title:       title,
+		Description: description,
+		Items:       []CreateTaskItemParams{},
+	}
+
+	dueProp := ical.PropDue
+	if comp.Name == ical.CompEvent {
+		dueProp = ical.PropDateTimeStart
+	}
+	if comp.Props.Get(dueProp) != nil {
+		dueAt, err := comp.Props.DateTime(dueProp, time.UTC)
+		if err != nil {
+			return CreateTaskParams{}, err
+		}
+		dueAt = dueAt.UTC()
+		task.DueAt = &dueAt
+	}
+
+	return task, nil
+}
+
+// icalCompleted reports whether a VTODO is completed
+func icalCompleted(comp *ical.Component) bool {
+	status, _ := comp.Props.Text(ical.PropStatus)
+	return strings.EqualFold(status, icalStatusCompleted) || comp.Props.Get(ical.PropCompleted) != nil
+}
+
+// icalParentUID returns the UID of the parent of a component, if any
+func icalParentUID(comp *ical.Component) string {
+	for _, prop := range comp.Props.Values(ical.PropRelatedTo) {
+		if relType := prop.Params.Get(ical.ParamRelationshipType); relType == "" || strings.EqualFold(relType, "PARENT") {
+			return prop.Value
+		}
+	}
+
+	return ""
+}
+
+func icalUID(comp *ical.Component) string {
+	uid, _ := comp.Props.Text(ical.PropUID)
+	return uid
+}
+
+// icalText drops the carriage returns the encoder refuses, line feeds being escaped
+func icalText(s string) string {
+	return strings.ReplaceAll(s, "\r", "")
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// jsonExportVersion is bumped on breaking changes of the JSON export
+const jsonExportVersion = 1
+
+type jsonExportItem struct {
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+}
+
+type jsonExportTask struct {
+	Title       string           `json:"title"`
+	Description string           `json:"description,omitempty"`
+	DueAt       *time.Time       `json:"due_at,omitempty"`
+	CreatedAt   *time.Time       `json:"created_at,omitempty"`
+	Items       []jsonExportItem `json:"items"`
+}
+
+type jsonExport struct {
+	Version    int              `json:"version"`
+	ExportedAt time.Time        `json:"exported_at"`
+	Tasks      []jsonExportTask `json:"tasks"`
+}
+
+func encodeJSONTasks(w io.Writer, tasks []TaskResult) error {
+	export := jsonExport{
+		Version:    jsonExportVersion,
+		ExportedAt: time.Now().UTC(),
+		Tasks:      make([]jsonExportTask, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		createdAt := task.CreatedAt
+		exported := jsonExportTask{
+			Title:       task.Title,
+			Description: task.Description,
+			DueAt:       task.DueAt,
+			CreatedAt:   &createdAt,
+			Items:       make([]jsonExportItem, 0, len(task.Items)),
+		}
+		for _, item := range task.Items {
+			exported.Items = append(exported.Items, jsonExportItem{Title: item.Title, Completed: item.Completed})
+		}
+		export.Tasks = append(export.Tasks, exported)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(export)
+}
+
+// decodeJSONTasks reads a JSON export, or a bare array of tasks such as the one listed by the API
+func decodeJSONTasks(r io.Reader) ([]CreateTaskParams, error) {
+	reader := bufio.NewReader(r)
+	head, _ := reader.Peek(512)
+
+	var tasks []jsonExportTask
+	decoder := json.NewDecoder(reader)
+	if first := bytes.TrimLeft(head, " \t\r\n"); len(first) > 0 && first[0] == '[' {
+		if err := decoder.Decode(&tasks); err != nil {
+			return nil, invalidImportf("%v", err)
+		}
+	} else {
+		var export jsonExport
+		if err := decoder.Decode(&export); err != nil {
+			return nil, invalidImportf("%v", err)
+		}
+		if export.Version > jsonExportVersion {
+			return nil, invalidImportf("unsupported export version %d", export.Version)
+		}
+		tasks = export.Tasks
+	}
+
+	params := make([]CreateTaskParams, 0, len(tasks))
+	for _, task := range tasks {
+		taskParams := CreateTaskParams{
+			Title:       task.Title,
+			Description: task.Description,
+			DueAt:       task.DueAt,
+			Items:       make([]CreateTaskItemParams, 0, len(task.Items)),
+		}
+		for _, item := range task.Items {
+			taskParams.Items = append(taskParams.Items, CreateTaskItemParams{Title: item.Title, Completed: item.Completed})
+		}
+		params = append(params, taskParams)
+	}
+
+	return params, nil
+}
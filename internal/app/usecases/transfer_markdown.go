@@ -0,0 +1,105 @@
+package usecases
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// encodeMarkdownTasks writes one section per task, its description as a paragraph and its items as a checklist
+func encodeMarkdownTasks(w io.Writer, tasks []TaskResult) error {
+	buf := bufio.NewWriter(w)
+	for i, task := range tasks {
+		if i > 0 {
+			fmt.Fprintln(buf)
+		}
+		fmt.Fprintf(buf, "## %s\n", task.Title)
+		if task.Description != "" {
+			fmt.Fprintf(buf, "\n%s\n", task.Description)
+		}
+		if len(task.Items) > 0 {
+			fmt.Fprintln(buf)
+		}
+		for _, item := range task.Items {
+			mark := " "
+			if item.Completed {
+				mark = "x"
+			}
+			fmt.Fprintf(buf, "- [%s] %s\n", mark, item.Title)
+		}
+	}
+
+	return buf.Flush()
+}
+
+// decodeMarkdownTasks reads headings as tasks, checklist entries as their items and other text as descriptions
+func decodeMarkdownTasks(r io.Reader) ([]CreateTaskParams, error) {
+	params := make([]CreateTaskParams, 0)
+	var description []string
+	flushDescription := func() {
+		if len(params) > 0 && len(description) > 0 {
+			params[len(params)-1].Description = strings.TrimSpace(strings.Join(description, "\n"))
+		}
+		description = description[:0]
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), " \t")
+		trimmed := strings.TrimSpace(text)
+
+		if strings.HasPrefix(trimmed, "#") {
+			flushDescription()
+			title := strings.TrimSpace(strings.TrimLeft(trimmed, "#"))
+			if title == "" {
+				return nil, invalidImportf("line %d: empty heading", line)
+			}
+			params = append(params, CreateTaskParams{Title: title, Items: make([]CreateTaskItemParams, 0)})
+			continue
+		}
+
+		if title, completed, ok := parseChecklistEntry(trimmed); ok {
+			if len(params) == 0 {
+				return nil, invalidImportf("line %d: checklist entry outside of a task heading", line)
+			}
+			task := &params[len(params)-1]
+			task.Items = append(task.Items, CreateTaskItemParams{Title: title, Completed: completed})
+			continue
+		}
+
+		// Description lines are only kept until the first item of a task
+		if len(params) > 0 && len(params[len(params)-1].Items) == 0 && (trimmed != "" || len(description) > 0) {
+			description = append(description, text)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, invalidImportf("%v", err)
+	}
+	flushDescription()
+
+	return params, nil
+}
+
+// parseChecklistEntry parses "- [ ] title" and "- [x] title", also with "*" or "+" bullets
+func parseChecklistEntry(line string) (string, bool, bool) {
+	if len(line) < 6 || !strings.ContainsRune("-*+", rune(line[0])) || line[1] != ' ' || line[2] != '[' || line[4] != ']' {
+		return "", false, false
+	}
+
+	var completed bool
+	switch line[3] {
+	case ' ':
+	case 'x', 'X':
+		completed = true
+	default:
+		return "", false, false
+	}
+
+	title := strings.TrimSpace(line[5:])
+	if title == "" {
+		return "", false, false
+	}
+
+	return title, completed, true
+}
@@ -0,0 +1,16 @@
+package usecases
+
+import "io"
+
+// ImportTasksParams represents the input for importing tasks from a file
+type ImportTasksParams struct {
+	Format TransferFormat
+	Data   io.Reader
+	// DryRun parses the file and detects duplicates without creating anything
+	DryRun bool
+	// AllowDuplicates creates the tasks whose title already exists instead of skipping them
+	AllowDuplicates bool
+	// Bulk copies the tasks into the database in a few statements, leaving out the invalid tasks and items
+	// instead of failing the whole import
+	Bulk bool
+}
@@ -0,0 +1,31 @@
+package usecases
+
+// ImportedTaskResult represents a task read from an import file
+type ImportedTaskResult struct {
+	Title     string
+	ItemCount int
+	// Duplicate is true when a task with the same title exists, or appears earlier in the file
+	Duplicate bool
+	// ID is the ID of the created task, 0 when it was skipped or on a dry run
+	ID int64
+}
+
+// ImportRejectResult represents a task or an item left out of a bulk import for being invalid
+type ImportRejectResult struct {
+	// Task is the position of the task in the file, from 1
+	Task int
+	// Item is the position of the item in its task, from 1, or 0 when the task itself is left out
+	Item   int
+	Title  string
+	Reason string
+}
+
+// ImportResult represents the outcome of an import, one result per task of the file
+type ImportResult struct {
+	DryRun         bool
+	Tasks          []ImportedTaskResult
+	CreatedCount   int
+	DuplicateCount int
+	// Rejects lists the invalid tasks and items of a bulk import, other imports failing on the first one
+	Rejects []ImportRejectResult
+}
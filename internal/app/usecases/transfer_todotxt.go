@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// todoTxtDateLayout is the date format of todo.txt
+	todoTxtDateLayout = "2006-01-02"
+	// todoTxtInbox is the task receiving the todo.txt lines without a project
+	todoTxtInbox = "Inbox"
+)
+
+// encodeTodoTxtTasks writes one line per item, its task being the +project of the line
+// Spaces of task titles become underscores, and descriptions are not exported
+// Tasks without items are exported as a line carrying only the project
+func encodeTodoTxtTasks(w io.Writer, tasks []TaskResult) error {
+	buf := bufio.NewWriter(w)
+	for _, task := range tasks {
+		project := "+" + strings.ReplaceAll(strings.TrimSpace(task.Title), " ", "_")
+		if len(task.Items) == 0 {
+			fmt.Fprintf(buf, "%s %s\n", task.CreatedAt.Format(todoTxtDateLayout), project)
+			continue
+		}
+		for _, item := range task.Items {
+			if item.Completed {
+				fmt.Fprintf(buf, "x %s ", item.UpdatedAt.Format(todoTxtDateLayout))
+			}
+			fmt.Fprintf(buf, "%s %s %s\n", item.CreatedAt.Format(todoTxtDateLayout), item.Title, project)
+		}
+	}
+
+	return buf.Flush()
+}
+
+// decodeTodoTxtTasks reads each line as an item of the task named by its first +project,
+// or of the Inbox task for lines without a project
+// Priorities and dates are dropped, contexts are kept in the item title
+func decodeTodoTxtTasks(r io.Reader) ([]CreateTaskParams, error) {
+	params := make([]CreateTaskParams, 0)
+	taskIndex := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		completed, project, title := parseTodoTxtLine(line)
+		taskTitle := todoTxtInbox
+		if project != "" {
+			taskTitle = strings.ReplaceAll(project, "_", " ")
+		}
+
+		i, ok := taskIndex[taskTitle]
+		if !ok {
+			i = len(params)
+			taskIndex[taskTitle] = i
+			params = append(params, CreateTaskParams{Title: taskTitle, Items: make([]CreateTaskItemParams, 0)})
+		}
+		if title != "" {
+			params[i].Items = append(params[i].Items, CreateTaskItemParams{Title: title, Completed: completed})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, invalidImportf("%v", err)
+	}
+
+	return params, nil
+}
+
+// parseTodoTxtLine splits a todo.txt line into its completion mark, first project and remaining text
+func parseTodoTxtLine(line string) (bool, string, string) {
+	words := strings.Fields(line)
+	completed := len(words) > 0 && words[0] == "x"
+	if completed {
+		words = words[1:]
+	}
+	if len(words) > 0 && len(words[0]) == 3 && words[0][0] == '(' && words[0][2] == ')' {
+		words = words[1:]
+	}
+	// Completion and creation dates
+	for range 2 {
+		if len(words) == 0 {
+			break
+		}
+		if _, err := time.Parse(todoTxtDateLayout, words[0]); err != nil {
+			break
+		}
+		words = words[1:]
+	}
+
+	var project string
+	text := make([]string, 0, len(words))
+	for _, word := range words {
+		if len(word) > 1 && word[0] == '+' {
+			if project == "" {
+				project = word[1:]
+			}
+			continue
+		}
+		text = append(text, word)
+	}
+
+	return completed, project, strings.Join(text, " ")
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/clevertechware/todo-bun-app/internal/config"
+)
+
+// ConfigCommand returns the config command for inspecting the effective configuration
+func ConfigCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "config",
+		Usage: "Configuration commands",
+		Commands: []*cli.Command{
+			{
+				Name:  "print",
+				Usage: "Print the effective configuration, secrets redacted, even when it is invalid",
+				Flags: append(configFlags(),
+					&cli.BoolFlag{
+						Name:  "show-source",
+						Usage: "Print every setting with the layer it comes from: default, file, env or flag",
+					},
+				),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, sources, err := loadUnvalidatedConfig(cmd)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					cfg = cfg.Redacted()
+
+					if !cmd.Bool("show-source") {
+						out, err := yaml.Marshal(cfg)
+						if err != nil {
+							return fmt.Errorf("failed to encode config: %w", err)
+						}
+						_, err = cmd.Root().Writer.Write(out)
+						return err
+					}
+
+					w := tabwriter.NewWriter(cmd.Root().Writer, 0, 0, 2, ' ', 0)
+					_, _ = fmt.Fprintln(w, "KEY\tVALUE\tSOURCE")
+					for _, setting := range cfg.Settings() {
+						_, _ = fmt.Fprintf(w, "%s\t%v\t%s\n", setting.Key, setting.Value, sources[setting.Key])
+					}
+					return w.Flush()
+				},
+			},
+			{
+				Name:  "validate",
+				Usage: "Check the effective configuration, listing every problem found",
+				Flags: configFlags(),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, _, err := loadUnvalidatedConfig(cmd)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+
+					var validationErr *config.ValidationError
+					if err = cfg.Validate(); errors.As(err, &validationErr) {
+						for _, problem := range validationErr.Problems {
+							_, _ = fmt.Fprintln(cmd.Root().ErrWriter, problem)
+						}
+						return fmt.Errorf("invalid configuration, %d problems found", len(validationErr.Problems))
+					}
+					if err != nil {
+						return err
+					}
+
+					_, _ = fmt.Fprintln(cmd.Root().Writer, "Configuration is valid")
+					return nil
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v3"
+)
+
+// HealthcheckCommand returns the healthcheck command probing the readiness of a running server,
+// for a Docker HEALTHCHECK where no curl is available
+func HealthcheckCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "healthcheck",
+		Usage: "Exit with an error unless the server is ready",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Usage:   "Path to configuration file (YAML), giving the server port",
+				Sources: cli.EnvVars("CONFIG_FILE"),
+				Value:   "config.yaml",
+			},
+			&cli.StringFlag{
+				Name:    "url",
+				Usage:   "URL to probe, /readyz on the configured server port of localhost when omitted",
+				Sources: cli.EnvVars("HEALTHCHECK_URL"),
+			},
+			&cli.DurationFlag{
+				Name:  "timeout",
+				Usage: "Time to wait for the response",
+				Value: 5 * time.Second,
+			},
+		},
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			url := cmd.String("url")
+			if url == "" {
+				cfg, _, err := loadConfig(cmd)
+				if err != nil {
+					return fmt.Errorf("failed to load config: %w", err)
+				}
+				url = fmt.Sprintf("http://127.0.0.1:%d/readyz", cfg.Server.Port)
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, cmd.Duration("timeout"))
+			defer cancel()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+			if err != nil {
+				return fmt.Errorf("invalid health check URL: %w", err)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return fmt.Errorf("health check failed: %w", err)
+			}
+			defer resp.Body.Close()
+
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+			_, _ = fmt.Fprintln(cmd.Root().Writer, strings.TrimSpace(string(body)))
+			if resp.StatusCode != http.StatusOK {
+				return fmt.Errorf("health check failed: %s", resp.Status)
+			}
+
+			return nil
+		},
+	}
+}
@@ -4,17 +4,36 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v3"
 
 	"github.com/clevertechware/todo-bun-app/internal/config"
+	"github.com/clevertechware/todo-bun-app/migrations"
+)
+
+// nilMigrationVersion is the version of a database without any migration, as accepted by migrate force
+const nilMigrationVersion = -1
+
+var (
+	// migrationFilePattern matches the name of a migration file, capturing its version
+	migrationFilePattern = regexp.MustCompile(`^(\d+)_.+\.(up|down)\.sql$`)
+	// migrationNameReplacer turns a migration name into a file name part
+	migrationNameReplacer = regexp.MustCompile(`[^a-z0-9]+`)
 )
 
 // MigrateCommand returns the migrate command for database migrations
+// The migrations are embedded in the binary, only create needs the migrations directory
 func MigrateCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "migrate",
@@ -23,196 +42,444 @@ func MigrateCommand() *cli.Command {
 			{
 				Name:  "up",
 				Usage: "Run all up migrations",
-				Flags: []cli.Flag{
-					&cli.StringFlag{
-						Name:    "config",
-						Aliases: []string{"c"},
-						Usage:   "Path to configuration file (YAML)",
-						Sources: cli.EnvVars("CONFIG_FILE"),
-						Value:   "config.yaml",
-					},
-					&cli.StringFlag{
-						Name:    "db-host",
-						Usage:   "Database host",
-						Sources: cli.EnvVars("DB_HOST"),
-						Value:   "localhost",
-					},
-					&cli.IntFlag{
-						Name:    "db-port",
-						Usage:   "Database port",
-						Sources: cli.EnvVars("DB_PORT"),
-						Value:   5432,
-					},
-					&cli.StringFlag{
-						Name:    "db-user",
-						Usage:   "Database user",
-						Sources: cli.EnvVars("DB_USER"),
-						Value:   "postgres",
-					},
-					&cli.StringFlag{
-						Name:    "db-password",
-						Usage:   "Database password",
-						Sources: cli.EnvVars("DB_PASSWORD"),
-						Value:   "postgres",
-					},
-					&cli.StringFlag{
-						Name:    "db-name",
-						Usage:   "Database name",
-						Sources: cli.EnvVars("DB_NAME"),
-						Value:   "todo_db",
-					},
-					&cli.StringFlag{
-						Name:    "db-sslmode",
-						Usage:   "Database SSL mode",
-						Sources: cli.EnvVars("DB_SSLMODE"),
-						Value:   "disable",
-					},
-				},
+				Flags: append(configFlags(), dryRunMigrationFlag()),
 				Action: func(ctx context.Context, cmd *cli.Command) error {
-					cfg := buildDBConfigFromYAML(cmd)
+					cfg, _, err := loadConfig(cmd)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					if cmd.Bool("dry-run") {
+						return printPendingMigrations(cmd, &cfg.Database, func(versions []uint, current int) (int, error) {
+							return len(versions) - 1, nil
+						})
+					}
 					return runMigrationUp(&cfg.Database)
 				},
 			},
 			{
 				Name:  "down",
 				Usage: "Run all down migrations",
+				Flags: append(configFlags(), dryRunMigrationFlag()),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, _, err := loadConfig(cmd)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					if cmd.Bool("dry-run") {
+						return printPendingMigrations(cmd, &cfg.Database, func(versions []uint, current int) (int, error) {
+							return nilMigrationVersion, nil
+						})
+					}
+					return runMigrationDown(&cfg.Database)
+				},
+			},
+			{
+				Name:      "steps",
+				Usage:     "Run N up migrations, or -N down migrations (use -- before a negative N)",
+				ArgsUsage: "N",
+				Flags:     append(configFlags(), dryRunMigrationFlag()),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					n, err := strconv.Atoi(cmd.Args().First())
+					if err != nil || n == 0 {
+						return errors.New("missing or invalid number of steps, e.g. 2 or -- -1")
+					}
+
+					cfg, _, err := loadConfig(cmd)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					if cmd.Bool("dry-run") {
+						return printPendingMigrations(cmd, &cfg.Database, func(versions []uint, current int) (int, error) {
+							target := current + n
+							switch {
+							case target >= len(versions):
+								return 0, migrate.ErrShortLimit{Short: uint(target - len(versions) + 1)}
+							case target < nilMigrationVersion:
+								return 0, migrate.ErrShortLimit{Short: uint(nilMigrationVersion - target)}
+							}
+							return target, nil
+						})
+					}
+					return withMigrate(&cfg.Database, func(m *migrate.Migrate) error {
+						return logMigrationResult(m.Steps(n), "run migration steps")
+					})
+				},
+			},
+			{
+				Name:      "goto",
+				Usage:     "Migrate up or down to version V",
+				ArgsUsage: "V",
+				Flags:     append(configFlags(), dryRunMigrationFlag()),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					version, err := strconv.ParseUint(cmd.Args().First(), 10, 0)
+					if err != nil {
+						return errors.New("missing or invalid version to migrate to")
+					}
+
+					cfg, _, err := loadConfig(cmd)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					if cmd.Bool("dry-run") {
+						return printPendingMigrations(cmd, &cfg.Database, func(versions []uint, current int) (int, error) {
+							target := migrationIndex(versions, uint(version))
+							if target == nilMigrationVersion {
+								return 0, fmt.Errorf("migration %d: %w", version, os.ErrNotExist)
+							}
+							return target, nil
+						})
+					}
+					return withMigrate(&cfg.Database, func(m *migrate.Migrate) error {
+						return logMigrationResult(m.Migrate(uint(version)), "migrate to version "+strconv.FormatUint(version, 10))
+					})
+				},
+			},
+			{
+				Name:      "force",
+				Usage:     "Set the version to V and clear the dirty flag without running any migration, -1 meaning none",
+				ArgsUsage: "V",
+				Flags:     configFlags(),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					version, err := strconv.Atoi(cmd.Args().First())
+					if err != nil || version < nilMigrationVersion {
+						return errors.New("missing or invalid version to force, use -- -1 for none")
+					}
+
+					cfg, _, err := loadConfig(cmd)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					return withMigrate(&cfg.Database, func(m *migrate.Migrate) error {
+						if err := m.Force(version); err != nil {
+							return fmt.Errorf("failed to force version: %w", err)
+						}
+						log.Warn().Int("version", version).Msg("Version forced")
+						return nil
+					})
+				},
+			},
+			{
+				Name:  "version",
+				Usage: "Print the current migration version",
+				Flags: configFlags(),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, _, err := loadConfig(cmd)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					return withMigrate(&cfg.Database, func(m *migrate.Migrate) error {
+						version, dirty, err := m.Version()
+						if errors.Is(err, migrate.ErrNilVersion) {
+							_, _ = fmt.Fprintln(cmd.Root().Writer, "none")
+							return nil
+						}
+						if err != nil {
+							return fmt.Errorf("failed to read version: %w", err)
+						}
+
+						if dirty {
+							_, _ = fmt.Fprintf(cmd.Root().Writer, "%d (dirty)\n", version)
+							return nil
+						}
+						_, _ = fmt.Fprintln(cmd.Root().Writer, version)
+						return nil
+					})
+				},
+			},
+			{
+				Name:  "status",
+				Usage: "List the migrations, applied or pending",
+				Flags: configFlags(),
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					cfg, _, err := loadConfig(cmd)
+					if err != nil {
+						return fmt.Errorf("failed to load config: %w", err)
+					}
+					return withMigrationSource(&cfg.Database, func(m *migrate.Migrate, src source.Driver) error {
+						return printMigrationStatus(cmd.Root().Writer, m, src)
+					})
+				},
+			},
+			{
+				Name:      "create",
+				Usage:     "Create the up and down files of a new migration, embedded at the next build",
+				ArgsUsage: "NAME",
 				Flags: []cli.Flag{
 					&cli.StringFlag{
-						Name:    "config",
-						Aliases: []string{"c"},
-						Usage:   "Path to configuration file (YAML)",
-						Sources: cli.EnvVars("CONFIG_FILE"),
-						Value:   "config.yaml",
-					},
-					&cli.StringFlag{
-						Name:    "db-host",
-						Usage:   "Database host",
-						Sources: cli.EnvVars("DB_HOST"),
-						Value:   "localhost",
-					},
-					&cli.IntFlag{
-						Name:    "db-port",
-						Usage:   "Database port",
-						Sources: cli.EnvVars("DB_PORT"),
-						Value:   5432,
-					},
-					&cli.StringFlag{
-						Name:    "db-user",
-						Usage:   "Database user",
-						Sources: cli.EnvVars("DB_USER"),
-						Value:   "postgres",
-					},
-					&cli.StringFlag{
-						Name:    "db-password",
-						Usage:   "Database password",
-						Sources: cli.EnvVars("DB_PASSWORD"),
-						Value:   "postgres",
-					},
-					&cli.StringFlag{
-						Name:    "db-name",
-						Usage:   "Database name",
-						Sources: cli.EnvVars("DB_NAME"),
-						Value:   "todo_db",
-					},
-					&cli.StringFlag{
-						Name:    "db-sslmode",
-						Usage:   "Database SSL mode",
-						Sources: cli.EnvVars("DB_SSLMODE"),
-						Value:   "disable",
+						Name:  "dir",
+						Usage: "Migrations directory",
+						Value: "migrations",
 					},
 				},
 				Action: func(ctx context.Context, cmd *cli.Command) error {
-					cfg := buildDBConfigFromYAML(cmd)
-					return runMigrationDown(&cfg.Database)
+					name := strings.Trim(migrationNameReplacer.ReplaceAllString(strings.ToLower(cmd.Args().First()), "_"), "_")
+					if name == "" {
+						return errors.New("missing migration name")
+					}
+
+					return createMigration(cmd.Root().Writer, cmd.String("dir"), name)
 				},
 			},
 		},
 	}
 }
 
-func buildDBConfigFromYAML(cmd *cli.Command) *config.Config {
-	// Load configuration from YAML file
-	cfg, err := config.LoadFromFile(cmd.String("config"))
-	if err != nil {
-		log.Printf("Warning: failed to load config file, using defaults: %v", err)
-		cfg = config.NewDefaultConfig()
-	}
-
-	// Merge with flags/env vars (flags override config file)
-	cfg.MergeWithFlags(
-		cmd.String("db-host"),
-		cmd.Int("db-port"),
-		cmd.String("db-user"),
-		cmd.String("db-password"),
-		cmd.String("db-name"),
-		cmd.String("db-sslmode"),
-		0,  // pool settings not used in migrate - use defaults
-		0,  // pool settings not used in migrate - use defaults
-		0,  // pool settings not used in migrate - use defaults
-		0,  // pool settings not used in migrate - use defaults
-		0,  // server port not used in migrate
-		"", // server mode not used in migrate
-	)
-
-	return cfg
+func dryRunMigrationFlag() cli.Flag {
+	return &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Print the SQL of the migrations that would run instead of running them",
+	}
 }
 
-// RunMigrationUp runs all up migrations (exported for use in serve command)
-func RunMigrationUp(cfg *config.DatabaseConfig) error {
-	m, err := migrate.New(
-		"file://migrations",
-		cfg.GetDSN(),
-	)
+// withMigrationSource runs fn with a migration instance reading the embedded migrations, closing both afterwards
+func withMigrationSource(cfg *config.DatabaseConfig, fn func(m *migrate.Migrate, src source.Driver) error) (err error) {
+	src, err := iofs.New(migrations.FS, ".")
+	if err != nil {
+		return fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", src, cfg.GetDSN())
 	if err != nil {
+		_ = src.Close()
 		return fmt.Errorf("failed to create migration instance: %w", err)
 	}
-	defer func(m *migrate.Migrate) {
-		closeErr, _ := m.Close()
-		if closeErr != nil {
-			log.Fatal(closeErr)
+	defer func() {
+		sourceErr, databaseErr := m.Close()
+		if closeErr := errors.Join(sourceErr, databaseErr); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close migration instance: %w", closeErr)
 		}
-	}(m)
+	}()
 
-	if err = m.Up(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			log.Println("No migrations to apply")
-			return nil
-		}
-		return fmt.Errorf("failed to run migrations: %w", err)
+	return fn(m, src)
+}
+
+// withMigrate runs fn with a migration instance reading the embedded migrations
+func withMigrate(cfg *config.DatabaseConfig, fn func(m *migrate.Migrate) error) error {
+	return withMigrationSource(cfg, func(m *migrate.Migrate, _ source.Driver) error {
+		return fn(m)
+	})
+}
+
+// logMigrationResult logs the outcome of a migration, no change not being an error
+func logMigrationResult(err error, action string) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		log.Info().Msg("No migrations to apply")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to %s: %w", action, err)
 	}
 
-	log.Println("Migrations applied successfully")
+	log.Info().Msg("Migrations applied successfully")
 	return nil
 }
 
-// runMigrationUp is a wrapper for backward compatibility
+// runMigrationUp runs all up migrations
 func runMigrationUp(cfg *config.DatabaseConfig) error {
-	return RunMigrationUp(cfg)
+	return withMigrate(cfg, func(m *migrate.Migrate) error {
+		return logMigrationResult(m.Up(), "run migrations")
+	})
 }
 
 func runMigrationDown(cfg *config.DatabaseConfig) error {
-	m, err := migrate.New(
-		"file://migrations",
-		cfg.GetDSN(),
-	)
+	return withMigrate(cfg, func(m *migrate.Migrate) error {
+		if err := m.Down(); err != nil {
+			if errors.Is(err, migrate.ErrNoChange) {
+				log.Info().Msg("No migrations to rollback")
+				return nil
+			}
+			return fmt.Errorf("failed to rollback migrations: %w", err)
+		}
+
+		log.Info().Msg("Migrations rolled back successfully")
+		return nil
+	})
+}
+
+// migrationVersions returns the versions of the migrations of src, in ascending order
+func migrationVersions(src source.Driver) ([]uint, error) {
+	version, err := src.First()
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	var versions []uint
+	for err == nil {
+		versions = append(versions, version)
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	return versions, nil
+}
+
+// migrationIndex returns the index of version in versions, nilMigrationVersion when it is not found
+func migrationIndex(versions []uint, version uint) int {
+	for i, v := range versions {
+		if v == version {
+			return i
+		}
+	}
+
+	return nilMigrationVersion
+}
+
+// currentMigrationIndex returns the index of the version of the database, refusing a dirty database
+func currentMigrationIndex(m *migrate.Migrate, versions []uint) (int, error) {
+	version, dirty, err := m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return nilMigrationVersion, nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create migration instance: %w", err)
+		return 0, fmt.Errorf("failed to read version: %w", err)
+	}
+	if dirty {
+		return 0, migrate.ErrDirty{Version: int(version)}
+	}
+
+	current := migrationIndex(versions, version)
+	if current == nilMigrationVersion {
+		return 0, fmt.Errorf("version %d of the database is not among the migrations", version)
 	}
-	defer func(m *migrate.Migrate) {
-		closeErr, _ := m.Close()
-		if closeErr != nil {
-			log.Fatal(closeErr)
+
+	return current, nil
+}
+
+// printPendingMigrations prints the SQL of the migrations from the current version to the index returned by target,
+// the up migrations in ascending order or the down migrations in descending order
+func printPendingMigrations(
+	cmd *cli.Command,
+	cfg *config.DatabaseConfig,
+	target func(versions []uint, current int) (int, error),
+) error {
+	return withMigrationSource(cfg, func(m *migrate.Migrate, src source.Driver) error {
+		versions, err := migrationVersions(src)
+		if err != nil {
+			return err
+		}
+		current, err := currentMigrationIndex(m, versions)
+		if err != nil {
+			return err
+		}
+		to, err := target(versions, current)
+		if err != nil {
+			return err
 		}
-	}(m)
 
-	if err := m.Down(); err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			log.Println("No migrations to rollback")
+		w := cmd.Root().Writer
+		if to == current {
+			_, _ = fmt.Fprintln(w, "-- No pending migrations")
 			return nil
 		}
-		return fmt.Errorf("failed to rollback migrations: %w", err)
+		for i := current + 1; i <= to; i++ {
+			if err := printMigrationSQL(w, versions[i], "up", src.ReadUp); err != nil {
+				return err
+			}
+		}
+		for i := current; i > to; i-- {
+			if err := printMigrationSQL(w, versions[i], "down", src.ReadDown); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func printMigrationSQL(
+	w io.Writer,
+	version uint,
+	direction string,
+	read func(version uint) (io.ReadCloser, string, error),
+) error {
+	r, identifier, err := read(version)
+	if err != nil {
+		return fmt.Errorf("failed to read migration %d %s: %w", version, direction, err)
+	}
+	defer r.Close()
+
+	_, _ = fmt.Fprintf(w, "-- %d %s (%s)\n", version, identifier, direction)
+	if _, err = io.Copy(w, r); err != nil {
+		return fmt.Errorf("failed to read migration %d %s: %w", version, direction, err)
+	}
+	_, _ = fmt.Fprintln(w)
+
+	return nil
+}
+
+// printMigrationStatus prints one line per migration, applied or pending, and the version of the database
+func printMigrationStatus(w io.Writer, m *migrate.Migrate, src source.Driver) error {
+	versions, err := migrationVersions(src)
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	applied := err == nil
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("failed to read version: %w", err)
+	}
+
+	for _, v := range versions {
+		r, identifier, err := src.ReadUp(v)
+		if err != nil {
+			return fmt.Errorf("failed to read migration %d: %w", v, err)
+		}
+		_ = r.Close()
+
+		status := "pending"
+		switch {
+		case applied && v == version && dirty:
+			status = "dirty"
+		case applied && v <= version:
+			status = "applied"
+		}
+		_, _ = fmt.Fprintf(w, "%-8s %06d %s\n", status, v, identifier)
+	}
+
+	switch {
+	case !applied:
+		_, _ = fmt.Fprintln(w, "Version: none")
+	case dirty:
+		_, _ = fmt.Fprintf(w, "Version: %d (dirty, fix the database then run migrate force)\n", version)
+	default:
+		_, _ = fmt.Fprintf(w, "Version: %d\n", version)
+	}
+
+	return nil
+}
+
+// createMigration creates the empty up and down files of the migration following the last one of dir
+func createMigration(w io.Writer, dir, name string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+
+	var last uint64
+	for _, entry := range entries {
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		if version, err := strconv.ParseUint(match[1], 10, 0); err == nil && version > last {
+			last = version
+		}
+	}
+
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%06d_%s.%s.sql", last+1, name, direction))
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err != nil {
+			return fmt.Errorf("failed to create migration: %w", err)
+		}
+		if err = file.Close(); err != nil {
+			return fmt.Errorf("failed to create migration: %w", err)
+		}
+		_, _ = fmt.Fprintln(w, path)
 	}
 
-	log.Println("Migrations rolled back successfully")
 	return nil
 }
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog/log"
+
+	"github.com/clevertechware/todo-bun-app/internal/config"
+)
+
+// Modes of the migrations run by serve on startup
+const (
+	migrateModeAuto  = "auto"
+	migrateModeCheck = "check"
+	migrateModeOff   = "off"
+)
+
+// migrationLockID identifies the advisory lock taken by the replica migrating on startup
+const migrationLockID int64 = 0x6d696772617465
+
+// validateMigrateMode validates the --migrate flag of serve
+func validateMigrateMode(mode string) error {
+	switch mode {
+	case migrateModeAuto, migrateModeCheck, migrateModeOff:
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate mode %q, expected auto, check or off", mode)
+	}
+}
+
+// migrateOnStartup runs or checks the migrations before serve boots, according to mode
+func migrateOnStartup(ctx context.Context, cfg *config.DatabaseConfig, mode string) error {
+	switch mode {
+	case migrateModeAuto:
+		return runLockedMigrationUp(ctx, cfg)
+	case migrateModeCheck:
+		return checkMigrationVersion(cfg)
+	case migrateModeOff:
+		log.Warn().Msg("Database migrations disabled, the schema is not checked")
+		return nil
+	default:
+		return validateMigrateMode(mode)
+	}
+}
+
+// runLockedMigrationUp runs the up migrations while holding an advisory lock, so that the replicas starting
+// together migrate one at a time, the ones waiting finding the schema up to date
+func runLockedMigrationUp(ctx context.Context, cfg *config.DatabaseConfig) error {
+	conn, err := pgx.Connect(ctx, cfg.GetDSN())
+	if err != nil {
+		return fmt.Errorf("failed to connect for migrations: %w", err)
+	}
+	// Closing the session releases the lock
+	defer func() {
+		if closeErr := conn.Close(context.Background()); closeErr != nil {
+			log.Warn().Err(closeErr).Msg("Failed to close the migration lock connection")
+		}
+	}()
+
+	log.Info().Msg("Waiting for the migration lock")
+	if _, err = conn.Exec(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		return fmt.Errorf("failed to take the migration lock: %w", err)
+	}
+
+	return withMigrate(cfg, func(m *migrate.Migrate) error {
+		err := m.Up()
+		var dirtyErr migrate.ErrDirty
+		switch {
+		case errors.As(err, &dirtyErr):
+			return fmt.Errorf("database is dirty at version %d, fix it then run migrate force: %w", dirtyErr.Version, err)
+		case errors.Is(err, migrate.ErrNoChange):
+			log.Info().Msg("Database schema is up to date")
+			return nil
+		case err != nil:
+			return err
+		}
+
+		version, _, err := m.Version()
+		if err != nil {
+			return fmt.Errorf("failed to read version: %w", err)
+		}
+		log.Info().Uint("version", version).Msg("Database migrations applied")
+		return nil
+	})
+}
+
+// checkMigrationVersion refuses a schema that is dirty or behind the last migration embedded in the binary
+// A schema ahead of it, migrated by a newer release, is only warned about
+func checkMigrationVersion(cfg *config.DatabaseConfig) error {
+	return withMigrationSource(cfg, func(m *migrate.Migrate, src source.Driver) error {
+		versions, err := migrationVersions(src)
+		if err != nil || len(versions) == 0 {
+			return err
+		}
+		expected := versions[len(versions)-1]
+
+		version, dirty, err := m.Version()
+		switch {
+		case errors.Is(err, migrate.ErrNilVersion):
+			return fmt.Errorf("database schema has no migration, expected version %d: run migrate up", expected)
+		case err != nil:
+			return fmt.Errorf("failed to read version: %w", err)
+		case dirty:
+			return fmt.Errorf("database is dirty at version %d, fix it then run migrate force", version)
+		case version < expected:
+			return fmt.Errorf("database schema version %d is behind the expected version %d: run migrate up", version, expected)
+		case version > expected:
+			log.Warn().Uint("version", version).Uint("expected", expected).Msg("Database schema is ahead of the binary")
+		default:
+			log.Info().Uint("version", version).Msg("Database schema is up to date")
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v3"
+
+	"github.com/clevertechware/todo-bun-app/internal/app"
+)
+
+// configPollInterval is how often serve checks whether its configuration file changed
+// Polling follows the symlinks Kubernetes swaps when a ConfigMap is updated, which file events miss
+const configPollInterval = 5 * time.Second
+
+// watchConfig reloads the configuration on SIGHUP or when its file changes, until ctx is done
+func watchConfig(ctx context.Context, cmd *cli.Command, application *app.App) {
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	defer signal.Stop(hangups)
+
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	path := cmd.String("config")
+	modTime := configModTime(path)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangups:
+			log.Info().Msg("SIGHUP received, reloading configuration")
+		case <-ticker.C:
+			latest := configModTime(path)
+			if latest.Equal(modTime) {
+				continue
+			}
+			modTime = latest
+			log.Info().Str("path", path).Msg("Configuration file changed, reloading configuration")
+		}
+
+		reloadConfig(cmd, application)
+	}
+}
+
+// reloadConfig loads and validates the configuration again, then applies it, keeping the current one on error
+func reloadConfig(cmd *cli.Command, application *app.App) {
+	cfg, _, err := loadConfig(cmd)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to reload configuration, keeping the current one")
+		return
+	}
+
+	changes := application.Config().Changes(cfg)
+	if len(changes) == 0 {
+		log.Info().Msg("Configuration unchanged")
+		return
+	}
+
+	restartRequired := application.ApplyConfig(cfg)
+	for _, key := range restartRequired {
+		log.Warn().Str("setting", key).Msg("Setting changed, restart the server to apply it")
+	}
+	log.Info().Strs("changed", changes).Int("restartRequired", len(restartRequired)).Msg("Configuration reloaded")
+}
+
+// configModTime returns the modification time of the configuration file, zero when it does not exist
+func configModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return info.ModTime()
+}
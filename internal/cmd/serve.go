@@ -2,16 +2,27 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
 	"github.com/urfave/cli/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
 
 	"github.com/clevertechware/todo-bun-app/internal/app"
 	"github.com/clevertechware/todo-bun-app/internal/config"
 	"github.com/clevertechware/todo-bun-app/internal/pkg/logger"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/requestid"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/tracing"
 )
 
 // ServeCommand returns the serve command for running the HTTP server
@@ -19,110 +30,26 @@ func ServeCommand() *cli.Command {
 	return &cli.Command{
 		Name:  "serve",
 		Usage: "Start the HTTP server",
-		Flags: []cli.Flag{
+		Flags: append(configFlags(),
 			&cli.StringFlag{
-				Name:    "config",
-				Aliases: []string{"c"},
-				Usage:   "Path to configuration file (YAML)",
-				Sources: cli.EnvVars("CONFIG_FILE"),
-				Value:   "config.yaml",
+				Name:      "migrate",
+				Usage:     "Database migrations on startup: auto (run them, one replica at a time), check (refuse an outdated schema) or off",
+				Sources:   cli.EnvVars("MIGRATE"),
+				Value:     migrateModeAuto,
+				Validator: validateMigrateMode,
 			},
-			&cli.StringFlag{
-				Name:    "db-host",
-				Usage:   "Database host",
-				Sources: cli.EnvVars("DB_HOST"),
-				Value:   "localhost",
-			},
-			&cli.IntFlag{
-				Name:    "db-port",
-				Usage:   "Database port",
-				Sources: cli.EnvVars("DB_PORT"),
-				Value:   5432,
-			},
-			&cli.StringFlag{
-				Name:    "db-user",
-				Usage:   "Database user",
-				Sources: cli.EnvVars("DB_USER"),
-				Value:   "postgres",
-			},
-			&cli.StringFlag{
-				Name:    "db-password",
-				Usage:   "Database password",
-				Sources: cli.EnvVars("DB_PASSWORD"),
-				Value:   "postgres",
-			},
-			&cli.StringFlag{
-				Name:    "db-name",
-				Usage:   "Database name",
-				Sources: cli.EnvVars("DB_NAME"),
-				Value:   "todo_db",
-			},
-			&cli.StringFlag{
-				Name:    "db-sslmode",
-				Usage:   "Database SSL mode",
-				Sources: cli.EnvVars("DB_SSLMODE"),
-				Value:   "disable",
-			},
-			&cli.IntFlag{
-				Name:    "db-pool-min-conns",
-				Usage:   "Minimum number of connections to maintain in the pool",
-				Sources: cli.EnvVars("DB_POOL_MIN_CONNS"),
-				Value:   3,
-			},
-			&cli.IntFlag{
-				Name:    "db-pool-max-conns",
-				Usage:   "Maximum number of connections allowed in the pool",
-				Sources: cli.EnvVars("DB_POOL_MAX_CONNS"),
-				Value:   5,
-			},
-			&cli.IntFlag{
-				Name:    "db-pool-max-conn-lifetime",
-				Usage:   "Maximum lifetime of a connection in minutes",
-				Sources: cli.EnvVars("DB_POOL_MAX_CONN_LIFETIME"),
-				Value:   5,
-			},
-			&cli.IntFlag{
-				Name:    "db-pool-max-conn-idle-time",
-				Usage:   "Maximum idle time of a connection in minutes",
-				Sources: cli.EnvVars("DB_POOL_MAX_CONN_IDLE_TIME"),
-				Value:   5,
-			},
-			&cli.IntFlag{
-				Name:    "server-port",
-				Usage:   "HTTP server port",
-				Sources: cli.EnvVars("SERVER_PORT"),
-				Value:   8080,
-			},
-			&cli.StringFlag{
-				Name:    "server-mode",
-				Usage:   "Server mode (debug, release, test)",
-				Sources: cli.EnvVars("SERVER_MODE"),
-				Value:   "debug",
-			},
-		},
+		),
 		Action: func(ctx context.Context, cmd *cli.Command) error {
-			// Load configuration from YAML file
-			cfg, err := config.LoadFromFile(cmd.String("config"))
+			// SIGINT and SIGTERM start a graceful shutdown
+			ctx, stopSignals := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer stopSignals()
+
+			// Load configuration: defaults, then the YAML file, then env vars, then the flags set
+			cfg, _, err := loadConfig(cmd)
 			if err != nil {
 				return fmt.Errorf("failed to load config: %w", err)
 			}
 
-			// Merge with flags/env vars (flags override config file)
-			cfg.MergeWithFlags(
-				cmd.String("db-host"),
-				cmd.Int("db-port"),
-				cmd.String("db-user"),
-				cmd.String("db-password"),
-				cmd.String("db-name"),
-				cmd.String("db-sslmode"),
-				cmd.Int("db-pool-min-conns"),
-				cmd.Int("db-pool-max-conns"),
-				cmd.Int("db-pool-max-conn-lifetime"),
-				cmd.Int("db-pool-max-conn-idle-time"),
-				cmd.Int("server-port"),
-				cmd.String("server-mode"),
-			)
-
 			// Initialize logger
 			logger.Init(logger.Config{
 				Level:  cfg.Log.Level,
@@ -131,13 +58,32 @@ func ServeCommand() *cli.Command {
 
 			log.Info().Msg("Starting application")
 
-			// Run database migrations
-			log.Info().Msg("Running database migrations")
-			if err = RunMigrationUp(&cfg.Database); err != nil {
-				log.Error().Err(err).Msg("Failed to run migrations")
-				return fmt.Errorf("failed to run migrations: %w", err)
+			// Initialize tracing, spans left being flushed once the application is closed
+			shutdownTracing, err := tracing.Init(ctx, tracing.Config{
+				Enabled:     cfg.Tracing.Enabled,
+				Exporter:    cfg.Tracing.Exporter,
+				Endpoint:    cfg.Tracing.Endpoint,
+				Insecure:    cfg.Tracing.Insecure,
+				ServiceName: cfg.Tracing.ServiceName,
+				SampleRatio: cfg.Tracing.SampleRatio,
+			})
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to initialize tracing")
+				return fmt.Errorf("failed to initialize tracing: %w", err)
+			}
+			defer func() {
+				if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+					log.Warn().Err(shutdownErr).Msg("Failed to flush traces")
+				}
+			}()
+
+			// Run or check database migrations
+			mode := cmd.String("migrate")
+			log.Info().Str("mode", mode).Msg("Migrating database")
+			if err = migrateOnStartup(ctx, &cfg.Database, mode); err != nil {
+				log.Error().Err(err).Str("mode", mode).Msg("Failed to migrate database")
+				return fmt.Errorf("failed to migrate database: %w", err)
 			}
-			log.Info().Msg("Database migrations completed")
 
 			// Set Gin mode
 			gin.SetMode(cfg.Server.Mode)
@@ -148,46 +94,252 @@ func ServeCommand() *cli.Command {
 				log.Error().Err(err).Msg("Failed to initialize application")
 				return fmt.Errorf("failed to initialize application: %w", err)
 			}
-			defer func(application *app.App) {
-				closeAppErr := application.Close()
-				if closeAppErr != nil {
-					log.Fatal().Err(closeAppErr).Msg("Failed to close application")
-				}
-			}(application)
 
 			log.Info().Msg("Application initialized successfully")
 
+			// Background workers are only stopped once the servers are, the database being closed last
+			workersCtx, stopWorkers := context.WithCancel(context.WithoutCancel(ctx))
+			var workers sync.WaitGroup
+			defer func() {
+				stopWorkers()
+				workers.Wait()
+				if closeAppErr := application.Close(); closeAppErr != nil {
+					log.Error().Err(closeAppErr).Msg("Failed to close application")
+					return
+				}
+				log.Info().Msg("Application closed")
+			}()
+
+			// Expired idempotency keys are reused on demand, purge them so the table stays small
+			workers.Go(func() {
+				application.PurgeExpiredIdempotencyKeys(workersCtx, time.Hour)
+			})
+
+			// SIGHUP and changes of the configuration file apply the settings allowing it without restart
+			workers.Go(func() {
+				watchConfig(workersCtx, cmd, application)
+			})
+
 			// Setup routes
-			router := setupRoutes(application)
+			router := setupRoutes(application, &cfg.Metrics)
+
+			// Start gRPC server
+			grpcServer := setupGRPCServer(application)
+			grpcAddr := fmt.Sprintf(":%d", cfg.Server.GRPCPort)
+			listener, err := net.Listen("tcp", grpcAddr)
+			if err != nil {
+				log.Error().Err(err).Str("address", grpcAddr).Msg("Failed to listen for gRPC")
+				return fmt.Errorf("failed to listen for gRPC: %w", err)
+			}
+
+			serveErrs := make(chan error, 4)
+			go func() {
+				log.Info().Str("address", grpcAddr).Msg("Starting gRPC server")
+				if serveErr := grpcServer.Serve(listener); serveErr != nil {
+					serveErrs <- fmt.Errorf("gRPC server stopped: %w", serveErr)
+				}
+			}()
 
 			// Start server
-			addr := fmt.Sprintf(":%d", cfg.Server.Port)
-			log.Info().Str("address", addr).Msg("Starting HTTP server")
-			return router.Run(addr)
+			server := newHTTPServer(&cfg.Server, router)
+			go func() {
+				log.Info().Str("address", server.Addr).Msg("Starting HTTP server")
+				if serveErr := server.ListenAndServe(); !errors.Is(serveErr, http.ErrServerClosed) {
+					serveErrs <- fmt.Errorf("HTTP server stopped: %w", serveErr)
+				}
+			}()
+
+			// Serve the metrics on their own port, kept off the public one
+			servers := []*http.Server{server}
+			if cfg.Metrics.Enabled && cfg.Metrics.Port != 0 {
+				metricsServer := newMetricsServer(&cfg.Metrics, application.MetricsHandler())
+				servers = append(servers, metricsServer)
+				go func() {
+					log.Info().Str("address", metricsServer.Addr).Msg("Starting metrics server")
+					if serveErr := metricsServer.ListenAndServe(); !errors.Is(serveErr, http.ErrServerClosed) {
+						serveErrs <- fmt.Errorf("metrics server stopped: %w", serveErr)
+					}
+				}()
+			}
+
+			// Serve the admin routes on their own listener, private by default
+			if cfg.Admin.Enabled {
+				adminServer := newAdminServer(&cfg.Admin, setupAdminRoutes(application))
+				servers = append(servers, adminServer)
+				go func() {
+					log.Info().Str("address", adminServer.Addr).Msg("Starting admin server")
+					if serveErr := adminServer.ListenAndServe(); !errors.Is(serveErr, http.ErrServerClosed) {
+						serveErrs <- fmt.Errorf("admin server stopped: %w", serveErr)
+					}
+				}()
+			}
+
+			// Wait for a signal, then keep serving while the health checks fail for load balancers to notice
+			shutdownTimeout := cfg.Server.GetShutdownTimeout()
+			select {
+			case <-ctx.Done():
+				log.Info().Dur("drainPeriod", cfg.Server.GetDrainPeriod()).Msg("Shutdown requested, draining")
+				application.Drain()
+				if drainInterrupted(cfg.Server.GetDrainPeriod()) {
+					log.Warn().Msg("Second signal received, shutting down now")
+					shutdownTimeout = 0
+				}
+			case err = <-serveErrs:
+				log.Error().Err(err).Msg("Server failed, shutting down")
+			}
+			// A signal during the shutdown kills the process
+			stopSignals()
+
+			shutdownServers(servers, grpcServer, shutdownTimeout)
+			return err
 		},
 	}
 }
 
+// newHTTPServer returns the HTTP server of handler, with the timeouts and limits of cfg
+func newHTTPServer(cfg *config.ServerConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		Handler:           handler,
+		ReadTimeout:       cfg.GetReadTimeout(),
+		ReadHeaderTimeout: cfg.GetReadHeaderTimeout(),
+		WriteTimeout:      cfg.GetWriteTimeout(),
+		IdleTimeout:       cfg.GetIdleTimeout(),
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+}
+
+// newMetricsServer returns the server of the metrics handler, listening on the port of cfg
+func newMetricsServer(cfg *config.MetricsConfig, handler http.Handler) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", handler)
+
+	return &http.Server{
+		Addr:              fmt.Sprintf(":%d", cfg.Port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// newAdminServer returns the server of the admin routes, listening on the address of cfg
+// Its responses have no write timeout, CPU profiles and traces being recorded for as long as requested
+func newAdminServer(cfg *config.AdminConfig, handler http.Handler) *http.Server {
+	return &http.Server{
+		Addr:              cfg.GetAddress(),
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// drainInterrupted waits for the drain period, returning true early when a second SIGINT or SIGTERM arrives
+func drainInterrupted(period time.Duration) bool {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	timer := time.NewTimer(period)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return false
+	case <-signals:
+		return true
+	}
+}
+
+// shutdownServers lets the HTTP and gRPC servers finish their in-flight requests within timeout, then closes
+// the connections left, such as subscriptions
+func shutdownServers(servers []*http.Server, grpcServer *grpc.Server, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		wg.Go(func() {
+			if err := server.Shutdown(ctx); err != nil {
+				log.Warn().Err(err).Str("address", server.Addr).Msg("HTTP server did not stop in time, closing its connections")
+				_ = server.Close()
+			}
+		})
+	}
+	wg.Go(func() {
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+
+		select {
+		case <-stopped:
+		case <-ctx.Done():
+			log.Warn().Msg("gRPC server did not stop in time, closing its connections")
+			grpcServer.Stop()
+		}
+	})
+	wg.Wait()
+
+	log.Info().Msg("Servers stopped")
+}
+
 // setupRoutes configures all HTTP routes
-func setupRoutes(app *app.App) *gin.Engine {
+func setupRoutes(app *app.App, metricsCfg *config.MetricsConfig) *gin.Engine {
 	router := gin.New()
 
 	// Add recovery middleware
 	router.Use(gin.Recovery())
 
+	// Record the request metrics, served here unless they have their own port
+	if metricsCfg.Enabled {
+		router.Use(app.MetricsMiddleware())
+		if metricsCfg.Port == 0 {
+			router.GET("/metrics", gin.WrapH(app.MetricsHandler()))
+		}
+	}
+
+	// Trace requests, ahead of the logs for them to carry the trace IDs
+	router.Use(tracing.GinMiddleware())
+
+	// Propagate or generate the request ID
+	router.Use(requestid.GinMiddleware())
+
 	// Add zerolog middleware
 	router.Use(zerologMiddleware())
+
+	// Validate traffic against the OpenAPI spec while developing
+	if gin.Mode() == gin.DebugMode {
+		router.Use(app.OpenAPIValidationMiddleware())
+	}
+
 	app.RegisterRoutes(router)
 
 	return router
 }
 
+// setupAdminRoutes configures the routes of the admin listener
+func setupAdminRoutes(app *app.App) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.Use(requestid.GinMiddleware())
+	router.Use(zerologMiddleware())
+
+	app.RegisterAdminRoutes(router)
+
+	return router
+}
+
 // zerologMiddleware logs HTTP requests using zerolog
+// The request logger, carrying the request ID, is attached to the request context for the layers below
 func zerologMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Start timer
 		start := time.Now()
 
+		// Attach the request logger
+		ctx := c.Request.Context()
+		requestLogger := log.With().Ctx(ctx).Str("requestId", requestid.FromContext(ctx)).Logger()
+		c.Request = c.Request.WithContext(logger.WithContext(ctx, requestLogger))
+
 		// Process request
 		c.Next()
 
@@ -195,7 +347,7 @@ func zerologMiddleware() gin.HandlerFunc {
 		duration := time.Since(start)
 
 		// Log request
-		log.Info().
+		requestLogger.Info().
 			Str("method", c.Request.Method).
 			Str("path", c.Request.URL.Path).
 			Int("status", c.Writer.Status()).
@@ -205,3 +357,50 @@ func zerologMiddleware() gin.HandlerFunc {
 			Msg("HTTP request")
 	}
 }
+
+// setupGRPCServer configures the gRPC server and registers all services
+func setupGRPCServer(app *app.App) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(requestid.GRPCUnaryInterceptor(), zerologUnaryInterceptor()),
+		grpc.ChainStreamInterceptor(requestid.GRPCStreamInterceptor(), zerologStreamInterceptor()),
+	)
+	app.RegisterGRPCServices(server)
+
+	return server
+}
+
+// zerologUnaryInterceptor logs unary gRPC calls using zerolog
+func zerologUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		log.Info().
+			Str("requestId", requestid.FromContext(ctx)).
+			Str("method", info.FullMethod).
+			Str("code", status.Code(err).String()).
+			Dur("duration", time.Since(start)).
+			Msg("gRPC request")
+
+		return resp, err
+	}
+}
+
+// zerologStreamInterceptor logs streaming gRPC calls using zerolog
+func zerologStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		err := handler(srv, ss)
+
+		log.Info().
+			Str("requestId", requestid.FromContext(ss.Context())).
+			Str("method", info.FullMethod).
+			Str("code", status.Code(err).String()).
+			Dur("duration", time.Since(start)).
+			Msg("gRPC stream")
+
+		return err
+	}
+}
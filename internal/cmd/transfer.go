@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/urfave/cli/v3"
+
+	"github.com/clevertechware/todo-bun-app/internal/app"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+)
+
+// ExportCommand returns the export command for writing all tasks to a file
+func ExportCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "export",
+		Usage: "Export all tasks with their items",
+		Flags: append(configFlags(),
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "Export format (json, csv, markdown, todotxt, ical)",
+				Value:   string(usecases.TransferJSON),
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "Path to the export file, standard output when omitted",
+			},
+		),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			format, err := usecases.ParseTransferFormat(cmd.String("format"))
+			if err != nil {
+				return err
+			}
+
+			application, err := newTransferApp(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			defer application.Close()
+
+			var out io.Writer = cmd.Root().Writer
+			if path := cmd.String("output"); path != "" {
+				file, err := os.Create(path)
+				if err != nil {
+					return fmt.Errorf("failed to create export file: %w", err)
+				}
+				defer file.Close()
+				out = file
+			}
+
+			if err = application.ExportTasks(ctx, format, out); err != nil {
+				return fmt.Errorf("failed to export tasks: %w", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+// ImportCommand returns the import command for creating tasks from a file
+func ImportCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "import",
+		Usage:     "Import tasks from a file, - reading standard input",
+		ArgsUsage: "FILE",
+		Flags: append(configFlags(),
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Usage:   "Import format (json, csv, markdown, todotxt, ical), guessed from the file extension when omitted",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Only report what would be imported",
+			},
+			&cli.BoolFlag{
+				Name:  "allow-duplicates",
+				Usage: "Import tasks whose title already exists instead of skipping them",
+			},
+			&cli.BoolFlag{
+				Name:  "bulk",
+				Usage: "Load the file through PostgreSQL COPY, rejecting invalid tasks and items instead of failing",
+			},
+		),
+		Action: func(ctx context.Context, cmd *cli.Command) error {
+			path := cmd.Args().First()
+			if path == "" {
+				return errors.New("missing file to import, use - for standard input")
+			}
+
+			format, err := importFormat(cmd.String("format"), path)
+			if err != nil {
+				return err
+			}
+
+			var in io.Reader = cmd.Root().Reader
+			if path != "-" {
+				file, err := os.Open(path)
+				if err != nil {
+					return fmt.Errorf("failed to open import file: %w", err)
+				}
+				defer file.Close()
+				in = file
+			}
+
+			application, err := newTransferApp(ctx, cmd)
+			if err != nil {
+				return err
+			}
+			defer application.Close()
+
+			result, err := application.ImportTasks(ctx, usecases.ImportTasksParams{
+				Format:          format,
+				Data:            in,
+				DryRun:          cmd.Bool("dry-run"),
+				AllowDuplicates: cmd.Bool("allow-duplicates"),
+				Bulk:            cmd.Bool("bulk"),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to import tasks: %w", err)
+			}
+
+			printImportResult(cmd.Root().Writer, result, cmd.Bool("allow-duplicates"))
+			return nil
+		},
+	}
+}
+
+// importFormat returns the format given by the flag, or guesses it from the file name
+func importFormat(flag, path string) (usecases.TransferFormat, error) {
+	if flag != "" {
+		return usecases.ParseTransferFormat(flag)
+	}
+	if path == "-" {
+		return "", errors.New("--format is required when reading standard input")
+	}
+
+	return usecases.TransferFormatFromFilename(path)
+}
+
+// newTransferApp connects to the database without running migrations nor starting any server
+func newTransferApp(ctx context.Context, cmd *cli.Command) (*app.App, error) {
+	cfg, _, err := loadConfig(cmd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	application, err := app.NewApp(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize application: %w", err)
+	}
+
+	return application, nil
+}
+
+// printImportResult prints one line per task of the file, the rejects of a bulk import and a summary
+func printImportResult(w io.Writer, result *usecases.ImportResult, allowDuplicates bool) {
+	rejected := make(map[int]bool, len(result.Rejects))
+	for _, reject := range result.Rejects {
+		if reject.Item == 0 {
+			rejected[reject.Task] = true
+		}
+	}
+
+	skipped := 0
+	for i, task := range result.Tasks {
+		switch {
+		case rejected[i+1]:
+			skipped++
+		case task.Duplicate && !allowDuplicates:
+			skipped++
+			_, _ = fmt.Fprintf(w, "skip     %s (%d items, duplicate)\n", task.Title, task.ItemCount)
+		case result.DryRun:
+			_, _ = fmt.Fprintf(w, "create   %s (%d items)\n", task.Title, task.ItemCount)
+		default:
+			_, _ = fmt.Fprintf(w, "created  %s (%d items) #%d\n", task.Title, task.ItemCount, task.ID)
+		}
+	}
+	for _, reject := range result.Rejects {
+		if reject.Item == 0 {
+			_, _ = fmt.Fprintf(w, "reject   task %d %q: %s\n", reject.Task, reject.Title, reject.Reason)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "reject   task %d item %d %q: %s\n", reject.Task, reject.Item, reject.Title, reject.Reason)
+	}
+
+	rejects := ""
+	if len(result.Rejects) > 0 {
+		rejects = fmt.Sprintf(", %d rejects", len(result.Rejects))
+	}
+	if result.DryRun {
+		_, _ = fmt.Fprintf(w, "Dry run: %d tasks would be created, %d skipped%s\n", len(result.Tasks)-skipped, skipped, rejects)
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%d tasks created, %d skipped%s\n", result.CreatedCount, skipped, rejects)
+}
@@ -2,10 +2,12 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // Config holds all configuration for the application
@@ -13,8 +15,14 @@ type Config struct {
 	Database DatabaseConfig `yaml:"db"`
 	Server   ServerConfig   `yaml:"server"`
 	Log      LogConfig      `yaml:"log"`
+	Metrics  MetricsConfig  `yaml:"metrics"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+	Admin    AdminConfig    `yaml:"admin"`
 }
 
+// redactedSecret replaces the secrets of the configuration when it is dumped
+const redactedSecret = "<redacted>"
+
 // PoolConfig holds connection pool configuration
 type PoolConfig struct {
 	MinConns        int32 `yaml:"minConns"`        // Minimum number of connections to maintain in the pool
@@ -33,21 +41,105 @@ func (p *PoolConfig) GetMaxConnIdleTime() time.Duration {
 	return time.Duration(p.MaxConnIdleTime) * time.Minute
 }
 
+// SlowQueryConfig holds slow query logging configuration
+type SlowQueryConfig struct {
+	Threshold          int     `yaml:"threshold"`          // Duration from which a query is logged as slow in milliseconds, 0 disabling it
+	Explain            bool    `yaml:"explain"`            // Capture the EXPLAIN (ANALYZE, BUFFERS) plan of slow SELECT statements
+	ExplainSampleRatio float64 `yaml:"explainSampleRatio"` // Share of the slow statements explained, from 0 to 1
+}
+
+// GetThreshold returns Threshold as time.Duration
+func (s *SlowQueryConfig) GetThreshold() time.Duration {
+	return time.Duration(s.Threshold) * time.Millisecond
+}
+
 // DatabaseConfig holds database connection configuration
 type DatabaseConfig struct {
-	Host     string     `yaml:"host"`
-	Port     int        `yaml:"port"`
-	User     string     `yaml:"user"`
-	Password string     `yaml:"password"`
-	Database string     `yaml:"name"`
-	SSLMode  string     `yaml:"sslMode"`
-	Pool     PoolConfig `yaml:"pool"` // Connection pool settings
+	DSN          string          `yaml:"dsn"`  // postgres:// URL replacing the connection settings below, e.g. from a secret
+	Host         string          `yaml:"host"` // Host name, or directory of the unix socket when starting with /
+	Port         int             `yaml:"port"`
+	User         string          `yaml:"user"`
+	Password     string          `yaml:"password"`
+	PasswordFile string          `yaml:"passwordFile"` // File holding the password, e.g. a mounted secret, replacing password
+	Database     string          `yaml:"name"`
+	SSLMode      string          `yaml:"sslMode"`     // disable, allow, prefer, require, verify-ca, verify-full
+	SSLRootCert  string          `yaml:"sslRootCert"` // CA certificate file verifying the server
+	SSLCert      string          `yaml:"sslCert"`     // Client certificate file
+	SSLKey       string          `yaml:"sslKey"`      // Client private key file
+	Pool         PoolConfig      `yaml:"pool"`        // Connection pool settings
+	SlowQuery    SlowQueryConfig `yaml:"slowQuery"`   // Slow query logging settings
 }
 
 // ServerConfig holds HTTP server configuration
 type ServerConfig struct {
-	Port int    `yaml:"port"`
-	Mode string `yaml:"mode"` // gin mode: debug, release, test
+	Port              int    `yaml:"port"`
+	GRPCPort          int    `yaml:"grpcPort"`          // gRPC server port
+	Mode              string `yaml:"mode"`              // gin mode: debug, release, test
+	ReadTimeout       int    `yaml:"readTimeout"`       // Maximum duration for reading a request, body included, in seconds
+	ReadHeaderTimeout int    `yaml:"readHeaderTimeout"` // Maximum duration for reading the request headers in seconds
+	WriteTimeout      int    `yaml:"writeTimeout"`      // Maximum duration for writing a response in seconds, lifted by subscriptions
+	IdleTimeout       int    `yaml:"idleTimeout"`       // Maximum time to wait for the next request on a keep-alive connection in seconds
+	MaxHeaderBytes    int    `yaml:"maxHeaderBytes"`    // Maximum size of the request headers in bytes
+	DrainPeriod       int    `yaml:"drainPeriod"`       // Time health checks fail before shutting down, in seconds
+	ShutdownTimeout   int    `yaml:"shutdownTimeout"`   // Maximum time to finish in-flight requests on shutdown, in seconds
+}
+
+// GetReadTimeout returns ReadTimeout as time.Duration
+func (s *ServerConfig) GetReadTimeout() time.Duration {
+	return time.Duration(s.ReadTimeout) * time.Second
+}
+
+// GetReadHeaderTimeout returns ReadHeaderTimeout as time.Duration
+func (s *ServerConfig) GetReadHeaderTimeout() time.Duration {
+	return time.Duration(s.ReadHeaderTimeout) * time.Second
+}
+
+// GetWriteTimeout returns WriteTimeout as time.Duration
+func (s *ServerConfig) GetWriteTimeout() time.Duration {
+	return time.Duration(s.WriteTimeout) * time.Second
+}
+
+// GetIdleTimeout returns IdleTimeout as time.Duration
+func (s *ServerConfig) GetIdleTimeout() time.Duration {
+	return time.Duration(s.IdleTimeout) * time.Second
+}
+
+// GetDrainPeriod returns DrainPeriod as time.Duration
+func (s *ServerConfig) GetDrainPeriod() time.Duration {
+	return time.Duration(s.DrainPeriod) * time.Second
+}
+
+// GetShutdownTimeout returns ShutdownTimeout as time.Duration
+func (s *ServerConfig) GetShutdownTimeout() time.Duration {
+	return time.Duration(s.ShutdownTimeout) * time.Second
+}
+
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	Port    int  `yaml:"port"` // Separate port for /metrics, 0 serving it on the HTTP server port
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled"`
+	Exporter    string  `yaml:"exporter"`    // otlp, stdout
+	Endpoint    string  `yaml:"endpoint"`    // OTLP gRPC collector address
+	Insecure    bool    `yaml:"insecure"`    // Disable TLS towards the collector
+	ServiceName string  `yaml:"serviceName"` // service.name of the spans
+	SampleRatio float64 `yaml:"sampleRatio"` // Share of the root traces recorded, from 0 to 1
+}
+
+// AdminConfig holds the configuration of the admin listener, serving pprof and the runtime settings
+type AdminConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Host    string `yaml:"host"` // Interface the admin listener binds to, keep it private
+	Port    int    `yaml:"port"` // Admin port, distinct from the HTTP server port
+}
+
+// GetAddress returns the address the admin listener binds to
+func (a *AdminConfig) GetAddress() string {
+	return net.JoinHostPort(a.Host, strconv.Itoa(a.Port))
 }
 
 // LogConfig holds logging configuration
@@ -56,16 +148,76 @@ type LogConfig struct {
 	Pretty bool   `yaml:"pretty"` // Enable pretty console output
 }
 
-// GetDSN returns the PostgreSQL connection string
+// GetDSN returns the PostgreSQL connection URL, credentials escaped
+// DSN is returned as is, with the password of PasswordFile when it has none
 func (c *DatabaseConfig) GetDSN() string {
-	return fmt.Sprintf("postgres://%s:%s@%s:%d/%s?sslmode=%s",
-		c.User,
-		c.Password,
-		c.Host,
-		c.Port,
-		c.Database,
-		c.SSLMode,
-	)
+	if c.DSN != "" {
+		u, err := url.Parse(c.DSN)
+		if err != nil || c.PasswordFile == "" || u.User == nil {
+			return c.DSN
+		}
+		if _, ok := u.User.Password(); ok {
+			return c.DSN
+		}
+		u.User = url.UserPassword(u.User.Username(), c.Password)
+		return u.String()
+	}
+
+	query := url.Values{}
+	query.Set("sslmode", c.SSLMode)
+	if c.SSLRootCert != "" {
+		query.Set("sslrootcert", c.SSLRootCert)
+	}
+	if c.SSLCert != "" {
+		query.Set("sslcert", c.SSLCert)
+	}
+	if c.SSLKey != "" {
+		query.Set("sslkey", c.SSLKey)
+	}
+
+	u := url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.User, c.Password),
+		Host:   net.JoinHostPort(c.Host, strconv.Itoa(c.Port)),
+		Path:   "/" + c.Database,
+	}
+	// A unix socket directory goes in the query, a URL host having no slash
+	if c.IsUnixSocket() {
+		u.Host = ""
+		query.Set("host", c.Host)
+		query.Set("port", strconv.Itoa(c.Port))
+	}
+	u.RawQuery = query.Encode()
+
+	return u.String()
+}
+
+// IsUnixSocket tells whether Host is the directory of a unix socket rather than a host name
+func (c *DatabaseConfig) IsUnixSocket() bool {
+	return strings.HasPrefix(c.Host, "/")
+}
+
+// Redacted returns a copy of the configuration whose secrets are replaced, to be dumped
+func (c *Config) Redacted() *Config {
+	redacted := *c
+	if redacted.Database.Password != "" {
+		redacted.Database.Password = redactedSecret
+	}
+	if redacted.Database.DSN != "" {
+		redacted.Database.DSN = redactDSN(redacted.Database.DSN)
+	}
+
+	return &redacted
+}
+
+// redactDSN replaces the password of a connection URL, the whole URL when it cannot be parsed
+func redactDSN(dsn string) string {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return redactedSecret
+	}
+
+	return u.Redacted()
 }
 
 // NewDefaultConfig returns a Config with default values
@@ -84,83 +236,62 @@ func NewDefaultConfig() *Config {
 				MaxConnLifetime: 5, // 5 minutes
 				MaxConnIdleTime: 5, // 5 minutes
 			},
+			SlowQuery: SlowQueryConfig{
+				Threshold:          200,   // 200 milliseconds
+				Explain:            false, // EXPLAIN ANALYZE runs the statement again
+				ExplainSampleRatio: 0.1,   // One slow statement in ten
+			},
 		},
 		Server: ServerConfig{
-			Port: 8080,
-			Mode: "debug",
+			Port:              8080,
+			GRPCPort:          9090,
+			Mode:              "debug",
+			ReadTimeout:       30,      // 30 seconds, imports being the largest bodies
+			ReadHeaderTimeout: 10,      // 10 seconds
+			WriteTimeout:      60,      // 1 minute
+			IdleTimeout:       120,     // 2 minutes
+			MaxHeaderBytes:    1 << 20, // 1 MiB
+			DrainPeriod:       5,       // 5 seconds
+			ShutdownTimeout:   20,      // 20 seconds, so that drain and shutdown fit in the 30 seconds Kubernetes grants
 		},
 		Log: LogConfig{
 			Level:  "info",
 			Pretty: true,
 		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Port:    0, // Served on the HTTP server port
+		},
+		Tracing: TracingConfig{
+			Enabled:     false,
+			Exporter:    "otlp",
+			Endpoint:    "localhost:4317",
+			Insecure:    true,
+			ServiceName: "todo-app",
+			SampleRatio: 1, // Every trace
+		},
+		Admin: AdminConfig{
+			Enabled: true,
+			Host:    "127.0.0.1", // Only reachable from the host or the pod
+			Port:    6060,
+		},
 	}
 }
 
-// LoadFromFile loads configuration from a YAML file
-// If the file doesn't exist, it returns the default config
+// LoadFromFile loads configuration from a YAML file, without the environment variables nor the flags
+// Unlike Load, a missing file is an error
 func LoadFromFile(path string) (*Config, error) {
-	cfg := NewDefaultConfig()
-
-	// If file doesn't exist, return default config
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return cfg, nil
-	}
-
-	// Read file
-	data, err := os.ReadFile(path)
-	if err != nil {
+	if _, err := os.Stat(path); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	// Parse YAML
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	cfg := NewDefaultConfig()
+	if _, err := loadFile(cfg, path); err != nil {
+		return nil, err
+	}
+	if err := cfg.Database.readPasswordFile(); err != nil {
+		return nil, err
 	}
 
 	return cfg, nil
 }
-
-// MergeWithFlags merges configuration with values from flags/env vars
-// Non-zero/non-empty flag values override config file values
-func (c *Config) MergeWithFlags(
-	dbHost string, dbPort int, dbUser string, dbPassword string, dbName string, dbSSLMode string,
-	poolMinConns int, poolMaxConns int, poolMaxConnLifetime int, poolMaxConnIdleTime int,
-	serverPort int, serverMode string,
-) {
-	if dbHost != "" && dbHost != "localhost" {
-		c.Database.Host = dbHost
-	}
-	if dbPort != 0 && dbPort != 5432 {
-		c.Database.Port = dbPort
-	}
-	if dbUser != "" && dbUser != "postgres" {
-		c.Database.User = dbUser
-	}
-	if dbPassword != "" && dbPassword != "postgres" {
-		c.Database.Password = dbPassword
-	}
-	if dbName != "" && dbName != "todo_db" {
-		c.Database.Database = dbName
-	}
-	if dbSSLMode != "" && dbSSLMode != "disable" {
-		c.Database.SSLMode = dbSSLMode
-	}
-	if poolMinConns != 0 && poolMinConns != 3 {
-		c.Database.Pool.MinConns = int32(poolMinConns)
-	}
-	if poolMaxConns != 0 && poolMaxConns != 5 {
-		c.Database.Pool.MaxConns = int32(poolMaxConns)
-	}
-	if poolMaxConnLifetime != 0 && poolMaxConnLifetime != 5 {
-		c.Database.Pool.MaxConnLifetime = poolMaxConnLifetime
-	}
-	if poolMaxConnIdleTime != 0 && poolMaxConnIdleTime != 5 {
-		c.Database.Pool.MaxConnIdleTime = poolMaxConnIdleTime
-	}
-	if serverPort != 0 && serverPort != 8080 {
-		c.Server.Port = serverPort
-	}
-	if serverMode != "" && serverMode != "debug" {
-		c.Server.Mode = serverMode
-	}
-}
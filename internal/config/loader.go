@@ -0,0 +1,302 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source is the layer a setting was last set by
+type Source string
+
+// Layers of the configuration, each one overriding the previous ones
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+	SourceFlag    Source = "flag"
+)
+
+// Sources maps the YAML path of every setting, e.g. db.pool.maxConns, to the layer it was set by
+type Sources map[string]Source
+
+// FlagSet is the command line overriding the settings, such as a *cli.Command
+type FlagSet interface {
+	IsSet(name string) bool
+	Value(name string) any
+}
+
+// binding binds a setting to the environment variable and the flag overriding it
+type binding struct {
+	key  string // YAML path of the setting
+	env  string
+	flag string
+}
+
+// bindings lists the settings overridable by environment variables and flags
+var bindings = []binding{
+	{key: "db.dsn", env: "DB_DSN", flag: "db-dsn"},
+	{key: "db.host", env: "DB_HOST", flag: "db-host"},
+	{key: "db.port", env: "DB_PORT", flag: "db-port"},
+	{key: "db.user", env: "DB_USER", flag: "db-user"},
+	{key: "db.password", env: "DB_PASSWORD", flag: "db-password"},
+	{key: "db.passwordFile", env: "DB_PASSWORD_FILE", flag: "db-password-file"},
+	{key: "db.name", env: "DB_NAME", flag: "db-name"},
+	{key: "db.sslMode", env: "DB_SSLMODE", flag: "db-sslmode"},
+	{key: "db.sslRootCert", env: "DB_SSLROOTCERT", flag: "db-sslrootcert"},
+	{key: "db.sslCert", env: "DB_SSLCERT", flag: "db-sslcert"},
+	{key: "db.sslKey", env: "DB_SSLKEY", flag: "db-sslkey"},
+	{key: "db.pool.minConns", env: "DB_POOL_MIN_CONNS", flag: "db-pool-min-conns"},
+	{key: "db.pool.maxConns", env: "DB_POOL_MAX_CONNS", flag: "db-pool-max-conns"},
+	{key: "db.pool.maxConnLifetime", env: "DB_POOL_MAX_CONN_LIFETIME", flag: "db-pool-max-conn-lifetime"},
+	{key: "db.pool.maxConnIdleTime", env: "DB_POOL_MAX_CONN_IDLE_TIME", flag: "db-pool-max-conn-idle-time"},
+	{key: "server.port", env: "SERVER_PORT", flag: "server-port"},
+	{key: "server.grpcPort", env: "SERVER_GRPC_PORT", flag: "server-grpc-port"},
+	{key: "server.mode", env: "SERVER_MODE", flag: "server-mode"},
+	{key: "log.level", env: "LOG_LEVEL", flag: "log-level"},
+	{key: "metrics.port", env: "METRICS_PORT", flag: "metrics-port"},
+}
+
+// Load builds the configuration from the defaults, then the YAML file at path, then the environment variables,
+// then the flags explicitly set on the command line, path being empty without file and flags nil without command line
+// It returns the layer each setting comes from along with the configuration, which is not validated
+func Load(path string, flags FlagSet) (*Config, Sources, error) {
+	cfg := NewDefaultConfig()
+	fields := settingFields(cfg)
+
+	sources := make(Sources, len(fields))
+	for key := range fields {
+		sources[key] = SourceDefault
+	}
+
+	if path != "" {
+		keys, err := loadFile(cfg, path)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, key := range keys {
+			if _, ok := fields[key]; ok {
+				sources[key] = SourceFile
+			}
+		}
+	}
+
+	for _, b := range bindings {
+		value, ok := os.LookupEnv(b.env)
+		if !ok {
+			continue
+		}
+		if err := setField(fields[b.key], value); err != nil {
+			return nil, nil, fmt.Errorf("invalid %s: %w", b.env, err)
+		}
+		sources[b.key] = SourceEnv
+	}
+
+	if flags != nil {
+		for _, b := range bindings {
+			if !flags.IsSet(b.flag) {
+				continue
+			}
+			if err := setField(fields[b.key], fmt.Sprint(flags.Value(b.flag))); err != nil {
+				return nil, nil, fmt.Errorf("invalid --%s: %w", b.flag, err)
+			}
+			sources[b.key] = SourceFlag
+		}
+	}
+
+	if err := cfg.Database.readPasswordFile(); err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, sources, nil
+}
+
+// Setting is a single value of the configuration
+type Setting struct {
+	Key   string
+	Value any
+}
+
+// Settings returns the YAML path of every setting with its value, in declaration order
+func (c *Config) Settings() []Setting {
+	var settings []Setting
+	walkSettings(reflect.ValueOf(c).Elem(), "", func(key string, field reflect.Value) {
+		settings = append(settings, Setting{Key: key, Value: field.Interface()})
+	})
+
+	return settings
+}
+
+// Changes returns the YAML paths of the settings whose value differs in other, in declaration order
+func (c *Config) Changes(other *Config) []string {
+	otherFields := settingFields(other)
+
+	var changes []string
+	for _, setting := range c.Settings() {
+		if !reflect.DeepEqual(setting.Value, otherFields[setting.Key].Interface()) {
+			changes = append(changes, setting.Key)
+		}
+	}
+
+	return changes
+}
+
+// loadFile unmarshals the YAML file at path into cfg, returning the YAML paths it sets
+// ${VAR} and ${VAR:-default} in its values are replaced by the environment variables
+func loadFile(cfg *Config, path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	// An empty file sets nothing
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	if err := interpolate(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if err := doc.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+
+	var keys []string
+	collectKeys(doc.Content[0], "", &keys)
+
+	return keys, nil
+}
+
+// envReference matches ${VAR} and ${VAR:-default}
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// interpolate replaces the environment variable references of the scalars below node
+func interpolate(node *yaml.Node) error {
+	var undefined []string
+	var walk func(node *yaml.Node)
+	walk = func(node *yaml.Node) {
+		if node.Kind == yaml.ScalarNode {
+			node.Value = envReference.ReplaceAllStringFunc(node.Value, func(ref string) string {
+				match := envReference.FindStringSubmatch(ref)
+				if value, ok := os.LookupEnv(match[1]); ok {
+					return value
+				}
+				if strings.Contains(ref, ":-") {
+					return match[2]
+				}
+				undefined = append(undefined, match[1])
+				return ref
+			})
+		}
+		for _, child := range node.Content {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	if len(undefined) > 0 {
+		return fmt.Errorf("undefined environment variables %s", strings.Join(undefined, ", "))
+	}
+
+	return nil
+}
+
+// collectKeys appends the YAML path of every scalar or sequence below node
+func collectKeys(node *yaml.Node, prefix string, keys *[]string) {
+	if node.Kind != yaml.MappingNode {
+		*keys = append(*keys, prefix)
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		collectKeys(node.Content[i+1], joinKey(prefix, node.Content[i].Value), keys)
+	}
+}
+
+// settingFields indexes the fields of cfg by their YAML path
+func settingFields(cfg *Config) map[string]reflect.Value {
+	fields := make(map[string]reflect.Value)
+	walkSettings(reflect.ValueOf(cfg).Elem(), "", func(key string, field reflect.Value) {
+		fields[key] = field
+	})
+
+	return fields
+}
+
+// walkSettings calls fn with the YAML path and the field of every setting below v, a struct
+func walkSettings(v reflect.Value, prefix string, fn func(key string, field reflect.Value)) {
+	for i := range v.NumField() {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		key, field := joinKey(prefix, name), v.Field(i)
+		if field.Kind() == reflect.Struct {
+			walkSettings(field, key, fn)
+			continue
+		}
+		fn(key, field)
+	}
+}
+
+// setField parses value into field according to its kind
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Type())
+	}
+
+	return nil
+}
+
+// joinKey appends name to the YAML path prefix
+func joinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "." + name
+}
+
+// readPasswordFile replaces Password by the content of PasswordFile, trailing newlines trimmed
+func (c *DatabaseConfig) readPasswordFile() error {
+	if c.PasswordFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.PasswordFile)
+	if err != nil {
+		return fmt.Errorf("failed to read db.passwordFile: %w", err)
+	}
+	c.Password = strings.TrimRight(string(data), "\r\n")
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFlags is a command line whose flags are all explicitly set
+type fakeFlags map[string]any
+
+func (f fakeFlags) IsSet(name string) bool {
+	_, ok := f[name]
+	return ok
+}
+
+func (f fakeFlags) Value(name string) any {
+	return f[name]
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+db:
+  host: file-host
+  port: 5433
+  user: file-user
+  pool:
+    maxConns: 10
+server:
+  port: 8081
+`), 0o600))
+	t.Setenv("DB_PORT", "5434")
+	t.Setenv("DB_USER", "env-user")
+
+	cfg, sources, err := Load(path, fakeFlags{"db-user": "flag-user", "db-pool-max-conns": 20, "server-mode": "release"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "file-host", cfg.Database.Host)
+	assert.Equal(t, 5434, cfg.Database.Port)
+	assert.Equal(t, "flag-user", cfg.Database.User)
+	assert.Equal(t, int32(20), cfg.Database.Pool.MaxConns)
+	assert.Equal(t, 8081, cfg.Server.Port)
+	assert.Equal(t, "release", cfg.Server.Mode)
+	assert.Equal(t, "todo_db", cfg.Database.Database)
+
+	assert.Equal(t, SourceFile, sources["db.host"])
+	assert.Equal(t, SourceEnv, sources["db.port"])
+	assert.Equal(t, SourceFlag, sources["db.user"])
+	assert.Equal(t, SourceFlag, sources["db.pool.maxConns"])
+	assert.Equal(t, SourceFile, sources["server.port"])
+	assert.Equal(t, SourceDefault, sources["db.name"])
+	assert.Equal(t, SourceDefault, sources["tracing.sampleRatio"])
+}
+
+func TestLoad_FlagSetToDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("db:\n  host: file-host\n"), 0o600))
+
+	cfg, sources, err := Load(path, fakeFlags{"db-host": "localhost"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "localhost", cfg.Database.Host, "a flag set to the default value still overrides the file")
+	assert.Equal(t, SourceFlag, sources["db.host"])
+}
+
+func TestLoad_NoFile(t *testing.T) {
+	cfg, sources, err := Load("", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, NewDefaultConfig(), cfg)
+	assert.Equal(t, SourceDefault, sources["db.host"])
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	_, _, err := Load(filepath.Join(t.TempDir(), "missing.yaml"), nil)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestLoad_InvalidEnv(t *testing.T) {
+	t.Setenv("SERVER_PORT", "http")
+
+	_, _, err := Load("", nil)
+	assert.ErrorContains(t, err, "SERVER_PORT")
+}
+
+func TestLoad_Interpolation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+db:
+  host: ${TEST_DB_HOST}
+  user: ${TEST_DB_USER:-fallback}
+  name: todo_${TEST_DB_SUFFIX:-}
+`), 0o600))
+	t.Setenv("TEST_DB_HOST", "db.internal")
+
+	cfg, _, err := Load(path, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "db.internal", cfg.Database.Host)
+	assert.Equal(t, "fallback", cfg.Database.User)
+	assert.Equal(t, "todo_", cfg.Database.Database)
+}
+
+func TestLoad_UndefinedInterpolation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("db:\n  host: ${TEST_UNDEFINED_HOST}\n"), 0o600))
+
+	_, _, err := Load(path, nil)
+	assert.ErrorContains(t, err, "TEST_UNDEFINED_HOST")
+}
+
+func TestLoad_PasswordFile(t *testing.T) {
+	passwordFile := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(passwordFile, []byte("s3cret\n"), 0o600))
+	t.Setenv("DB_PASSWORD_FILE", passwordFile)
+
+	cfg, _, err := Load("", nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "s3cret", cfg.Database.Password)
+}
+
+func TestConfig_Changes(t *testing.T) {
+	current := NewDefaultConfig()
+	next := NewDefaultConfig()
+	next.Log.Level = "debug"
+	next.Database.Pool.MaxConns = 10
+
+	assert.Equal(t, []string{"db.pool.maxConns", "log.level"}, current.Changes(next))
+	assert.Empty(t, current.Changes(NewDefaultConfig()))
+}
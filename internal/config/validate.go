@@ -0,0 +1,146 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	// sslModes are the sslmode values accepted by libpq and pgx
+	sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+	// serverModes are the gin modes
+	serverModes = []string{"debug", "release", "test"}
+	// tracingExporters are the span exporters of the tracing package
+	tracingExporters = []string{"otlp", "stdout"}
+)
+
+// ValidationError lists every problem of an invalid configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// validator collects the problems of a configuration
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, format string, args ...any) {
+	if !ok {
+		v.problems = append(v.problems, fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *validator) port(key string, port int) {
+	v.check(port > 0 && port <= 65535, "%s must be between 1 and 65535, got %d", key, port)
+}
+
+func (v *validator) oneOf(key, value string, allowed []string) {
+	v.check(slices.Contains(allowed, value), "%s must be one of %s, got %q", key, strings.Join(allowed, ", "), value)
+}
+
+func (v *validator) nonNegative(key string, value int) {
+	v.check(value >= 0, "%s must not be negative, got %d", key, value)
+}
+
+func (v *validator) ratio(key string, value float64) {
+	v.check(value >= 0 && value <= 1, "%s must be between 0 and 1, got %g", key, value)
+}
+
+func (v *validator) file(key, path string) {
+	if path == "" {
+		return
+	}
+	_, err := os.Stat(path)
+	v.check(err == nil, "%s: %v", key, err)
+}
+
+// Validate checks every setting, returning a *ValidationError listing all the problems found
+func (c *Config) Validate() error {
+	v := &validator{}
+	c.Database.validate(v)
+	c.Server.validate(v)
+
+	level, err := zerolog.ParseLevel(c.Log.Level)
+	v.check(err == nil && level != zerolog.NoLevel,
+		"log.level must be one of trace, debug, info, warn, error, fatal, panic, disabled, got %q", c.Log.Level)
+
+	if c.Metrics.Port != 0 {
+		v.port("metrics.port", c.Metrics.Port)
+		v.check(c.Metrics.Port != c.Server.Port, "metrics.port must differ from server.port %d", c.Server.Port)
+	}
+
+	if c.Tracing.Enabled {
+		v.oneOf("tracing.exporter", c.Tracing.Exporter, tracingExporters)
+		v.check(c.Tracing.Exporter != "otlp" || c.Tracing.Endpoint != "", "tracing.endpoint is required by the otlp exporter")
+		v.check(c.Tracing.ServiceName != "", "tracing.serviceName must not be empty")
+	}
+	v.ratio("tracing.sampleRatio", c.Tracing.SampleRatio)
+
+	if c.Admin.Enabled {
+		v.check(c.Admin.Host != "", "admin.host must not be empty")
+		v.port("admin.port", c.Admin.Port)
+		// Admin routes must never be reachable on the public port
+		v.check(c.Admin.Port != c.Server.Port, "admin.port must differ from server.port %d", c.Server.Port)
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+
+	return nil
+}
+
+func (c *DatabaseConfig) validate(v *validator) {
+	if c.DSN != "" {
+		// golang-migrate only takes URLs, unlike pgx which also parses key=value strings
+		u, err := url.Parse(c.DSN)
+		v.check(err == nil && (u.Scheme == "postgres" || u.Scheme == "postgresql"),
+			"db.dsn must be a postgres:// URL")
+	} else {
+		v.check(c.Host != "", "db.host must not be empty")
+		v.port("db.port", c.Port)
+		v.check(c.User != "", "db.user must not be empty")
+		v.check(c.Database != "", "db.name must not be empty")
+		v.oneOf("db.sslMode", c.SSLMode, sslModes)
+		v.check(c.SSLMode != "disable" || c.SSLRootCert+c.SSLCert+c.SSLKey == "",
+			"db.sslRootCert, db.sslCert and db.sslKey require an sslMode other than disable")
+		v.check((c.SSLCert == "") == (c.SSLKey == ""), "db.sslCert and db.sslKey must be set together")
+		v.file("db.sslRootCert", c.SSLRootCert)
+		v.file("db.sslCert", c.SSLCert)
+		v.file("db.sslKey", c.SSLKey)
+	}
+
+	v.check(c.Pool.MinConns >= 0, "db.pool.minConns must not be negative, got %d", c.Pool.MinConns)
+	v.check(c.Pool.MaxConns > 0, "db.pool.maxConns must be positive, got %d", c.Pool.MaxConns)
+	v.check(c.Pool.MinConns <= c.Pool.MaxConns,
+		"db.pool.minConns %d must not exceed db.pool.maxConns %d", c.Pool.MinConns, c.Pool.MaxConns)
+	v.nonNegative("db.pool.maxConnLifetime", c.Pool.MaxConnLifetime)
+	v.nonNegative("db.pool.maxConnIdleTime", c.Pool.MaxConnIdleTime)
+
+	v.nonNegative("db.slowQuery.threshold", c.SlowQuery.Threshold)
+	v.ratio("db.slowQuery.explainSampleRatio", c.SlowQuery.ExplainSampleRatio)
+}
+
+func (c *ServerConfig) validate(v *validator) {
+	v.port("server.port", c.Port)
+	v.port("server.grpcPort", c.GRPCPort)
+	v.check(c.GRPCPort != c.Port, "server.grpcPort must differ from server.port %d", c.Port)
+	v.oneOf("server.mode", c.Mode, serverModes)
+
+	v.nonNegative("server.readTimeout", c.ReadTimeout)
+	v.nonNegative("server.readHeaderTimeout", c.ReadHeaderTimeout)
+	v.nonNegative("server.writeTimeout", c.WriteTimeout)
+	v.nonNegative("server.idleTimeout", c.IdleTimeout)
+	v.check(c.MaxHeaderBytes > 0, "server.maxHeaderBytes must be positive, got %d", c.MaxHeaderBytes)
+	v.nonNegative("server.drainPeriod", c.DrainPeriod)
+	v.nonNegative("server.shutdownTimeout", c.ShutdownTimeout)
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(c *Config)
+		problems []string
+	}{
+		{
+			name:   "defaults",
+			modify: func(c *Config) {},
+		},
+		{
+			name: "aggregated problems",
+			modify: func(c *Config) {
+				c.Database.Pool.MinConns = 10
+				c.Database.SSLMode = "on"
+				c.Server.Mode = "production"
+			},
+			problems: []string{
+				`db.sslMode must be one of disable, allow, prefer, require, verify-ca, verify-full, got "on"`,
+				"db.pool.minConns 10 must not exceed db.pool.maxConns 5",
+				`server.mode must be one of debug, release, test, got "production"`,
+			},
+		},
+		{
+			name: "TLS files without TLS",
+			modify: func(c *Config) {
+				c.Database.SSLCert = "/certs/client.crt"
+			},
+			problems: []string{
+				"db.sslRootCert, db.sslCert and db.sslKey require an sslMode other than disable",
+				"db.sslCert and db.sslKey must be set together",
+				"db.sslCert: stat /certs/client.crt: no such file or directory",
+			},
+		},
+		{
+			name: "DSN replacing the connection settings",
+			modify: func(c *Config) {
+				c.Database.DSN = "postgres://app@db/app"
+				c.Database.Host = ""
+				c.Database.SSLMode = ""
+			},
+		},
+		{
+			name: "key=value DSN",
+			modify: func(c *Config) {
+				c.Database.DSN = "host=db user=app"
+			},
+			problems: []string{"db.dsn must be a postgres:// URL"},
+		},
+		{
+			name: "clashing ports",
+			modify: func(c *Config) {
+				c.Admin.Port = 8080
+				c.Metrics.Port = 8080
+			},
+			problems: []string{
+				"metrics.port must differ from server.port 8080",
+				"admin.port must differ from server.port 8080",
+			},
+		},
+		{
+			name: "invalid log level",
+			modify: func(c *Config) {
+				c.Log.Level = "verbose"
+			},
+			problems: []string{`log.level must be one of trace, debug, info, warn, error, fatal, panic, disabled, got "verbose"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			tt.modify(cfg)
+
+			err := cfg.Validate()
+			if tt.problems == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *ValidationError
+			require.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.problems, validationErr.Problems)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// queryValues matches the string and number literals Bun inlines in the queries it formats
+var queryValues = regexp.MustCompile(`'(?:[^']|'')*'|\b\d+(?:\.\d+)?\b`)
+
+// planConditions matches the conditions of the nodes of a plan, e.g. "Filter: (title ~~* '%milk%'::text)", whose
+// values are those of the statement; "Rows Removed by Filter: 3" is left as is
+var planConditions = regexp.MustCompile(`(?m)^(\s*(?:->\s*)?(?:[A-Z][\w-]* )?(?:Cond|Filter): )(.*)$`)
+
+// QueryHookConfig holds the configuration of the query logging hook
+type QueryHookConfig struct {
+	SlowThreshold time.Duration // Duration from which a query is logged as slow, 0 disabling it
+	// OnSlowQuery is called with every slow query, e.g. to capture its plan, if set
+	OnSlowQuery func(ctx context.Context, event *bun.QueryEvent, duration time.Duration)
+}
+
+// queryHook logs the queries run through Bun with the logger of their context
+type queryHook struct {
+	cfg QueryHookConfig
+}
+
+// QueryHook returns the Bun hook logging the failed and slow queries with the request ID of their context,
+// every query being logged at trace level
+func QueryHook(cfg QueryHookConfig) bun.QueryHook {
+	return &queryHook{cfg: cfg}
+}
+
+func (h *queryHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery logs the query with its values redacted, no rows not being a failure
+func (h *queryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	duration := time.Since(event.StartTime)
+	log := FromContext(ctx)
+
+	switch {
+	case event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows):
+		log.Warn().
+			Err(event.Err).
+			Str("operation", event.Operation()).
+			Dur("duration", duration).
+			Str("query", RedactQuery(event.Query)).
+			Msg("Query failed")
+	case h.cfg.SlowThreshold > 0 && duration >= h.cfg.SlowThreshold:
+		log.Warn().
+			Str("operation", event.Operation()).
+			Dur("duration", duration).
+			Dur("threshold", h.cfg.SlowThreshold).
+			Str("query", RedactQuery(event.Query)).
+			Msg("Slow query")
+		if h.cfg.OnSlowQuery != nil {
+			h.cfg.OnSlowQuery(ctx, event, duration)
+		}
+	default:
+		// Redacting every query costs, only when traced
+		if e := log.Trace(); e.Enabled() {
+			e.Str("operation", event.Operation()).
+				Dur("duration", duration).
+				Str("query", RedactQuery(event.Query)).
+				Msg("Query")
+		}
+	}
+}
+
+// RedactQuery replaces the string and number literals of query with question marks, so that the values of the
+// tasks do not end up in the logs
+func RedactQuery(query string) string {
+	return queryValues.ReplaceAllLiteralString(query, "?")
+}
+
+// RedactPlan replaces the literals in the conditions of an EXPLAIN plan with question marks, keeping the costs,
+// row counts and timings
+func RedactPlan(plan string) string {
+	return planConditions.ReplaceAllStringFunc(plan, func(line string) string {
+		match := planConditions.FindStringSubmatch(line)
+		return match[1] + RedactQuery(match[2])
+	})
+}
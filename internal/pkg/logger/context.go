@@ -0,0 +1,24 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// WithContext returns a copy of ctx carrying l, returned by FromContext down the call chain
+func WithContext(ctx context.Context, l zerolog.Logger) context.Context {
+	return l.WithContext(ctx)
+}
+
+// FromContext returns the logger carried by ctx, such as the one of a request with its ID, or the global logger
+func FromContext(ctx context.Context) *zerolog.Logger {
+	if l := zerolog.Ctx(ctx); l.GetLevel() != zerolog.Disabled {
+		return l
+	}
+
+	// Entries of the global logger still carry the trace IDs of ctx
+	l := log.Logger.With().Ctx(ctx).Logger()
+	return &l
+}
@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"context"
+	"fmt"
 	"io"
 	"os"
 	"time"
@@ -41,8 +43,24 @@ func Init(cfg Config) {
 		}
 	}
 
-	// Set global logger
-	log.Logger = zerolog.New(output).With().Timestamp().Caller().Logger()
+	// Set global logger, entries logged with a context carrying their trace IDs
+	log.Logger = zerolog.New(output).With().Timestamp().Caller().Logger().Hook(traceHook{})
+}
+
+// SetLevel changes the level of every logger at runtime, e.g. to debug a running server
+func SetLevel(level string) error {
+	parsed, err := zerolog.ParseLevel(level)
+	if err != nil || parsed == zerolog.NoLevel {
+		return fmt.Errorf("expected trace, debug, info, warn, error, fatal, panic or disabled, got %q", level)
+	}
+
+	zerolog.SetGlobalLevel(parsed)
+	return nil
+}
+
+// Level returns the level of every logger
+func Level() string {
+	return zerolog.GlobalLevel().String()
 }
 
 // GetLogger returns the global logger
@@ -50,7 +68,7 @@ func GetLogger() *zerolog.Logger {
 	return &log.Logger
 }
 
-// NewLogger creates a new logger with a specific context
-func NewLogger(component string) zerolog.Logger {
-	return log.Logger.With().Str("component", component).Logger()
+// NewLogger creates a new logger with a specific context, carrying the request ID of ctx if any
+func NewLogger(ctx context.Context, component string) zerolog.Logger {
+	return FromContext(ctx).With().Str("component", component).Logger()
 }
@@ -0,0 +1,18 @@
+package logger
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceHook adds the trace and span IDs of the context of an entry, set through Ctx, to the entry
+type traceHook struct{}
+
+func (traceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	spanContext := trace.SpanContextFromContext(e.GetCtx())
+	if !spanContext.IsValid() {
+		return
+	}
+
+	e.Str("traceId", spanContext.TraceID().String()).Str("spanId", spanContext.SpanID().String())
+}
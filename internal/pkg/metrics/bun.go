@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/uptrace/bun"
+)
+
+// queryHook records the duration of the queries run through Bun
+type queryHook struct {
+	metrics *Metrics
+}
+
+// QueryHook returns the Bun hook recording the duration of the queries
+func (m *Metrics) QueryHook() bun.QueryHook {
+	return &queryHook{metrics: m}
+}
+
+func (h *queryHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	return ctx
+}
+
+// AfterQuery labels the query with its operation, e.g. SELECT, and whether it failed
+// No rows is not a failure, most lookups expecting it at times
+func (h *queryHook) AfterQuery(_ context.Context, event *bun.QueryEvent) {
+	status := "ok"
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		status = "error"
+	}
+
+	h.metrics.queryDuration.WithLabelValues(strings.ToLower(event.Operation()), status).
+		Observe(time.Since(event.StartTime).Seconds())
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// businessScrapeTimeout bounds the queries counting the tasks at every scrape
+const businessScrapeTimeout = 2 * time.Second
+
+// businessCollector exposes the business gauges, counted at every scrape
+type businessCollector struct {
+	count     func(ctx context.Context) (tasks int64, openItems int64, err error)
+	tasks     *prometheus.Desc
+	openItems *prometheus.Desc
+}
+
+// NewBusinessCollector returns a collector of the counts returned by count
+// Failing counts are left out of the scrape rather than exposed as zero
+func NewBusinessCollector(count func(ctx context.Context) (tasks int64, openItems int64, err error)) prometheus.Collector {
+	return &businessCollector{
+		count:     count,
+		tasks:     prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "tasks"), "Tasks", nil, nil),
+		openItems: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "open_items"), "Task items not completed", nil, nil),
+	}
+}
+
+func (c *businessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.tasks
+	ch <- c.openItems
+}
+
+func (c *businessCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), businessScrapeTimeout)
+	defer cancel()
+
+	tasks, openItems, err := c.count(ctx)
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(c.tasks, err)
+		ch <- prometheus.NewInvalidMetric(c.openItems, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.tasks, prometheus.GaugeValue, float64(tasks))
+	ch <- prometheus.MustNewConstMetric(c.openItems, prometheus.GaugeValue, float64(openItems))
+}
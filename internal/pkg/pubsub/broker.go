@@ -0,0 +1,62 @@
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultBufferSize is the number of messages buffered per subscriber
+const defaultBufferSize = 64
+
+// Broker fans out published messages to all active subscribers
+// Slow subscribers never block publishers: messages are dropped when their buffer is full
+type Broker[T any] struct {
+	mu          sync.RWMutex
+	subscribers map[chan T]struct{}
+	bufferSize  int
+}
+
+// NewBroker creates a new Broker instance
+func NewBroker[T any]() *Broker[T] {
+	return &Broker[T]{
+		subscribers: make(map[chan T]struct{}),
+		bufferSize:  defaultBufferSize,
+	}
+}
+
+// Subscribe registers a new subscriber
+// The returned channel is closed when the context is done
+func (b *Broker[T]) Subscribe(ctx context.Context) <-chan T {
+	ch := make(chan T, b.bufferSize)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		b.mu.Lock()
+		delete(b.subscribers, ch)
+		close(ch)
+		b.mu.Unlock()
+	}()
+
+	return ch
+}
+
+// Publish sends a message to all subscribers and reports how many of them missed it
+func (b *Broker[T]) Publish(msg T) (dropped int) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- msg:
+		default:
+			dropped++
+		}
+	}
+
+	return dropped
+}
@@ -0,0 +1,107 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Header carries the request ID, from the client or a proxy, and back in the response
+const Header = "X-Request-ID"
+
+// MetadataKey carries the request ID of gRPC calls, in the request metadata and back in the response header
+const MetadataKey = "x-request-id"
+
+// maxLength bounds the request IDs accepted from clients, longer ones being replaced
+const maxLength = 128
+
+type contextKey struct{}
+
+// New generates a request ID
+func New() string {
+	return uuid.NewString()
+}
+
+// NewContext returns a copy of ctx carrying the request ID id
+func NewContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID carried by ctx, empty if none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// GinMiddleware propagates the request ID sent in the X-Request-ID header, or generates one, through the request
+// context and back in the response header
+func GinMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(Header)
+		if !valid(id) {
+			id = New()
+		}
+
+		c.Header(Header, id)
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// GRPCUnaryInterceptor propagates the request ID sent in the x-request-id metadata, or generates one, through the
+// call context and back in the response header
+func GRPCUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(grpcContext(ctx), req)
+	}
+}
+
+// GRPCStreamInterceptor is the GRPCUnaryInterceptor of streaming calls
+func GRPCStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: grpcContext(ss.Context())})
+	}
+}
+
+// serverStream overrides the context of a gRPC stream
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// grpcContext returns a copy of the call context ctx carrying its request ID, sent back in the response header
+func grpcContext(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			id = values[0]
+		}
+	}
+	if !valid(id) {
+		id = New()
+	}
+
+	// Only fails outside of a gRPC call, where there is no header to send
+	_ = grpc.SetHeader(ctx, metadata.Pairs(MetadataKey, id))
+	return NewContext(ctx, id)
+}
+
+// valid accepts the non-empty IDs of visible ASCII characters, so that clients cannot forge log lines
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -5,14 +5,12 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
-	"os"
-	"path/filepath"
-	"runtime"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -21,12 +19,16 @@ import (
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/driver/pgdriver"
+
+	"github.com/clevertechware/todo-bun-app/migrations"
 )
 
 // PostgresTestDatabase provides a PostgreSQL test database using testcontainers
 type PostgresTestDatabase struct {
 	container *postgres.PostgresContainer
 	DB        *bun.DB
+	// Pool connects to the same database through pgx, for the repositories using it directly
+	Pool *pgxpool.Pool
 }
 
 // NewPostgresDatabase creates a new PostgreSQL test database container
@@ -44,9 +46,15 @@ func NewPostgresDatabase() *PostgresTestDatabase {
 		log.Fatal("failed to setup bun db:", err)
 	}
 
+	pool, err := createPool(ctx, container)
+	if err != nil {
+		log.Fatal("failed to setup pgx pool:", err)
+	}
+
 	return &PostgresTestDatabase{
 		container: container,
 		DB:        bunDB,
+		Pool:      pool,
 	}
 }
 
@@ -82,16 +90,12 @@ func createBunDB(ctx context.Context, container *postgres.PostgresContainer) (*b
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	// Obtenir la racine du projet et construire le chemin des migrations
-	projectRoot, err := getProjectRoot()
+	// Run the embedded migrations
+	source, err := iofs.New(migrations.FS, ".")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get project root: %w", err)
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
 	}
-
-	migrationPath := fmt.Sprintf("file://%s/migrations", projectRoot)
-
-	// Run migrations
-	m, err := migrate.New(migrationPath, connStr)
+	m, err := migrate.NewWithSourceInstance("iofs", source, connStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create migration instance: %w", err)
 	}
@@ -108,23 +112,13 @@ func createBunDB(ctx context.Context, container *postgres.PostgresContainer) (*b
 	return bunDB, nil
 }
 
-func getProjectRoot() (string, error) {
-	_, filename, _, _ := runtime.Caller(0)
-	dir := filepath.Dir(filename)
-
-	// Remonte jusqu'à trouver go.mod
-	for {
-		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
-			return dir, nil
-		}
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			break
-		}
-		dir = parent
+func createPool(ctx context.Context, container *postgres.PostgresContainer) (*pgxpool.Pool, error) {
+	connStr, err := container.ConnectionString(ctx, "sslmode=disable")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get connection string: %w", err)
 	}
 
-	return "", fmt.Errorf("impossible de trouver la racine du projet (go.mod introuvable)")
+	return pgxpool.New(ctx, connStr)
 }
 
 // TxBegin starts a new transaction
@@ -140,6 +134,7 @@ func (testContainer *PostgresTestDatabase) TearDown() error {
 	if err := testContainer.DB.Close(); err != nil {
 		log.Printf("failed to close database: %v", err)
 	}
+	testContainer.Pool.Close()
 
 	return testContainer.container.Terminate(ctx)
 }
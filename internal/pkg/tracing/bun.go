@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/uptrace/bun"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// dbStatementKey records the query of a span, under the name most tracing backends display
+const dbStatementKey = attribute.Key("db.statement")
+
+// maxStatementLength truncates the queries recorded, bulk inserts inlining all their values
+const maxStatementLength = 4096
+
+// queryHook records a span per query run through Bun
+type queryHook struct{}
+
+// QueryHook returns the Bun hook recording a span per query, child of the span of its context
+func QueryHook() bun.QueryHook {
+	return queryHook{}
+}
+
+func (queryHook) BeforeQuery(ctx context.Context, event *bun.QueryEvent) context.Context {
+	statement := event.Query
+	if len(statement) > maxStatementLength {
+		statement = statement[:maxStatementLength]
+	}
+
+	operation := event.Operation()
+	ctx, _ = Tracer().Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(event.StartTime),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(operation),
+			dbStatementKey.String(statement),
+		),
+	)
+	return ctx
+}
+
+// AfterQuery ends the span of the query, failed unless it only found no rows
+func (queryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	span := trace.SpanFromContext(ctx)
+	if event.Err != nil && !errors.Is(event.Err, sql.ErrNoRows) {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+	}
+	span.End()
+}
@@ -18,6 +18,10 @@ func main() {
 		Commands: []*cli.Command{
 			cmd.ServeCommand(),
 			cmd.MigrateCommand(),
+			cmd.ExportCommand(),
+			cmd.ImportCommand(),
+			cmd.HealthcheckCommand(),
+			cmd.ConfigCommand(),
 		},
 	}
 
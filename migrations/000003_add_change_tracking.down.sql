@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS tombstones;
+
+ALTER TABLE task_items
+    DROP COLUMN IF EXISTS field_versions,
+    DROP COLUMN IF EXISTS change_seq;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS field_versions,
+    DROP COLUMN IF EXISTS change_seq;
+
+DROP SEQUENCE IF EXISTS change_seq;
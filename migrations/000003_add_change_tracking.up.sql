@@ -0,0 +1,26 @@
+-- Create the sequence ordering every change made to tasks and task items, for delta sync
+CREATE SEQUENCE IF NOT EXISTS change_seq;
+
+-- Track the last change of each task and item, and when each of their fields was last modified
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT nextval('change_seq'),
+    ADD COLUMN IF NOT EXISTS field_versions JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE task_items
+    ADD COLUMN IF NOT EXISTS change_seq BIGINT NOT NULL DEFAULT nextval('change_seq'),
+    ADD COLUMN IF NOT EXISTS field_versions JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_tasks_change_seq ON tasks(change_seq);
+CREATE INDEX IF NOT EXISTS idx_task_items_change_seq ON task_items(change_seq);
+
+-- Create tombstones table recording deleted tasks and items
+CREATE TABLE IF NOT EXISTS tombstones (
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    task_id BIGINT NOT NULL,
+    change_seq BIGINT NOT NULL DEFAULT nextval('change_seq'),
+    deleted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (entity_type, entity_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tombstones_change_seq ON tombstones(change_seq);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS calendar_feeds;
+
+DROP INDEX IF EXISTS idx_tasks_due_at;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS due_at;
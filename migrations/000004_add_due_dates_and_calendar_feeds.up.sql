@@ -0,0 +1,13 @@
+-- Add an optional due date to tasks, exported to calendars
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS due_at TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON tasks(due_at) WHERE due_at IS NOT NULL;
+
+-- Create calendar_feeds table holding the secret tokens of the iCalendar feed URLs
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,17 @@
+ALTER TABLE tombstones
+    DROP COLUMN IF EXISTS ical_uid;
+
+DROP TRIGGER IF EXISTS task_items_set_ical_uid ON task_items;
+DROP TRIGGER IF EXISTS tasks_set_ical_uid ON tasks;
+
+DROP FUNCTION IF EXISTS set_task_item_ical_uid();
+DROP FUNCTION IF EXISTS set_task_ical_uid();
+
+DROP INDEX IF EXISTS idx_task_items_ical_uid;
+DROP INDEX IF EXISTS idx_tasks_ical_uid;
+
+ALTER TABLE task_items
+    DROP COLUMN IF EXISTS ical_uid;
+
+ALTER TABLE tasks
+    DROP COLUMN IF EXISTS ical_uid;
@@ -0,0 +1,47 @@
+-- Keep the UID of the VTODO of each task and item, so that the CalDAV clients that created them find them again
+ALTER TABLE tasks
+    ADD COLUMN IF NOT EXISTS ical_uid VARCHAR(255);
+
+ALTER TABLE task_items
+    ADD COLUMN IF NOT EXISTS ical_uid VARCHAR(255);
+
+UPDATE tasks SET ical_uid = 'task-' || id || '@todo-bun-app' WHERE ical_uid IS NULL;
+UPDATE task_items SET ical_uid = 'item-' || id || '@todo-bun-app' WHERE ical_uid IS NULL;
+
+ALTER TABLE tasks
+    ALTER COLUMN ical_uid SET NOT NULL;
+
+ALTER TABLE task_items
+    ALTER COLUMN ical_uid SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tasks_ical_uid ON tasks(ical_uid);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_items_ical_uid ON task_items(ical_uid);
+
+-- Rows inserted without a UID get one derived from their ID
+CREATE OR REPLACE FUNCTION set_task_ical_uid() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.ical_uid := COALESCE(NEW.ical_uid, 'task-' || NEW.id || '@todo-bun-app');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION set_task_item_ical_uid() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.ical_uid := COALESCE(NEW.ical_uid, 'item-' || NEW.id || '@todo-bun-app');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS tasks_set_ical_uid ON tasks;
+CREATE TRIGGER tasks_set_ical_uid
+    BEFORE INSERT ON tasks
+    FOR EACH ROW EXECUTE FUNCTION set_task_ical_uid();
+
+DROP TRIGGER IF EXISTS task_items_set_ical_uid ON task_items;
+CREATE TRIGGER task_items_set_ical_uid
+    BEFORE INSERT ON task_items
+    FOR EACH ROW EXECUTE FUNCTION set_task_item_ical_uid();
+
+-- Remember the UID of deleted tasks and items for CalDAV sync-collection reports
+ALTER TABLE tombstones
+    ADD COLUMN IF NOT EXISTS ical_uid VARCHAR(255) NOT NULL DEFAULT '';
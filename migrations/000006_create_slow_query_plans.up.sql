@@ -0,0 +1,11 @@
+-- Create slow_query_plans table holding the EXPLAIN plans captured for slow statements
+CREATE TABLE IF NOT EXISTS slow_query_plans (
+    id BIGSERIAL PRIMARY KEY,
+    query TEXT NOT NULL,
+    operation VARCHAR(32) NOT NULL DEFAULT '',
+    duration_ms DOUBLE PRECISION NOT NULL,
+    plan TEXT NOT NULL,
+    captured_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_slow_query_plans_captured_at ON slow_query_plans(captured_at);
@@ -0,0 +1,3 @@
+-- Identify the request holding an idempotency key, so that a request whose claim was taken over cannot store its response
+ALTER TABLE idempotency_keys
+    ADD COLUMN IF NOT EXISTS claim_token VARCHAR(36) NOT NULL DEFAULT '';
@@ -0,0 +1,38 @@
+// Package migrations embeds the SQL migrations so that the binary does not depend on its working directory
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// FS holds the up and down migrations, named VERSION_NAME.up.sql and VERSION_NAME.down.sql
+//
+//go:embed *.sql
+var FS embed.FS
+
+// LatestVersion returns the version of the last migration, which the schema is expected to be at
+func LatestVersion() (uint, error) {
+	src, err := iofs.New(FS, ".")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	for err == nil {
+		var next uint
+		if next, err = src.Next(version); err == nil {
+			version = next
+		}
+	}
+	if !errors.Is(err, os.ErrNotExist) || version == 0 {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	return version, nil
+}
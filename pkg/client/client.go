@@ -0,0 +1,264 @@
+// Package client is a typed Go client for the TODO HTTP API
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultMinBackoff = 100 * time.Millisecond
+	defaultMaxBackoff = 5 * time.Second
+	defaultUserAgent  = "todo-bun-app-client/1.0"
+
+	// IdempotencyKeyHeader is sent on every POST so that retried requests are applied once
+	IdempotencyKeyHeader = "Idempotency-Key"
+)
+
+// Client calls the TODO HTTP API
+// It is safe for concurrent use
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	userAgent  string
+	maxRetries int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the underlying HTTP client, http.DefaultClient by default
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRetry sets how many times a failed request is retried and the bounds of the exponential backoff
+// A maxRetries of 0 disables retries
+func WithRetry(maxRetries int, minBackoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.minBackoff = minBackoff
+		c.maxBackoff = maxBackoff
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New creates a new Client for the API served at baseURL, e.g. http://localhost:8080
+func New(baseURL string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme and host are required", baseURL)
+	}
+	parsed.Path = strings.TrimSuffix(parsed.Path, "/")
+
+	c := &Client{
+		baseURL:    parsed,
+		httpClient: http.DefaultClient,
+		userAgent:  defaultUserAgent,
+		maxRetries: defaultMaxRetries,
+		minBackoff: defaultMinBackoff,
+		maxBackoff: defaultMaxBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey makes POST requests issued with ctx use the given key instead of a random one
+// Reuse the same key to safely resubmit a request after a crash or timeout
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// do sends a request, retrying on network errors, 429 and 5xx responses, and decodes the JSON response into out
+// A POST also retries on 409, answered while an earlier attempt with the same idempotency key is still running
+// A retried DELETE answered 404 succeeds, an earlier attempt having deleted the resource before its response was lost
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, in, out any) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	// The key is generated once so that every attempt carries the same one
+	var idempotencyKey string
+	if method == http.MethodPost {
+		idempotencyKey = idempotencyKeyFromContext(ctx)
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, query, body, idempotencyKey)
+		if err != nil {
+			if ctx.Err() != nil || attempt >= c.maxRetries {
+				return err
+			}
+			if err = c.wait(ctx, attempt, 0); err != nil {
+				return err
+			}
+			continue
+		}
+
+		inProgress := idempotencyKey != "" && resp.StatusCode == http.StatusConflict
+		if (shouldRetry(resp.StatusCode) || inProgress) && attempt < c.maxRetries {
+			retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+			drain(resp)
+			if err = c.wait(ctx, attempt, retryAfter); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if method == http.MethodDelete && attempt > 0 && resp.StatusCode == http.StatusNotFound {
+			drain(resp)
+			return nil
+		}
+
+		return decodeResponse(resp, out)
+	}
+}
+
+// send performs a single HTTP round trip
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, body []byte, idempotencyKey string) (*http.Response, error) {
+	endpoint := c.baseURL.JoinPath(path)
+	endpoint.RawQuery = query.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(IdempotencyKeyHeader, idempotencyKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", method, endpoint.Path, err)
+	}
+
+	return resp, nil
+}
+
+// wait sleeps before the next attempt, honouring Retry-After when the server sent one
+func (c *Client) wait(ctx context.Context, attempt int, retryAfter time.Duration) error {
+	delay := retryAfter
+	if delay <= 0 {
+		delay = c.backoff(attempt)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoff computes an exponential delay with full jitter
+func (c *Client) backoff(attempt int) time.Duration {
+	ceiling := float64(c.minBackoff) * math.Pow(2, float64(attempt))
+	if ceiling > float64(c.maxBackoff) {
+		ceiling = float64(c.maxBackoff)
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return time.Duration(mathrand.Int64N(int64(ceiling)) + 1)
+}
+
+func shouldRetry(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests ||
+		(statusCode >= http.StatusInternalServerError && statusCode != http.StatusNotImplemented)
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
+
+// decodeResponse turns error statuses into an *APIError and decodes successful bodies into out
+func decodeResponse(resp *http.Response, out any) error {
+	defer drain(resp)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return newAPIError(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+// drain reads the rest of the body so that the connection can be reused
+func drain(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	if key, ok := ctx.Value(idempotencyKeyContextKey{}).(string); ok && key != "" {
+		return key
+	}
+
+	return rand.Text()
+}
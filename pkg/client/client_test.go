@@ -0,0 +1,538 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clevertechware/todo-bun-app/internal/app/db"
+	"github.com/clevertechware/todo-bun-app/internal/app/handlers"
+	"github.com/clevertechware/todo-bun-app/internal/app/models"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases"
+	"github.com/clevertechware/todo-bun-app/internal/app/usecases/mocks"
+	"github.com/clevertechware/todo-bun-app/internal/pkg/requestid"
+)
+
+// newTestServer serves the real HTTP handler backed by a mocked usecase
+// wrap lets tests put middleware in front of the handler
+func newTestServer(t *testing.T, mockUsecase *mocks.TaskUsecase, wrap func(http.Handler) http.Handler) *Client {
+	t.Helper()
+
+	openAPIHandler, err := handlers.NewOpenAPIHandler()
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(requestid.GinMiddleware())
+	idempotencyUsecase := usecases.NewIdempotencyUsecase(newMemoryIdempotencyRepository(), time.Hour)
+	handlers.NewHTTPHandler(
+		handlers.NewHTTPTaskHandler(mockUsecase),
+		handlers.NewHTTPIdempotencyHandler(idempotencyUsecase),
+		nil,
+		nil,
+		handlers.NewGraphQLHandler(mockUsecase),
+		openAPIHandler,
+		nil,
+	).RegisterRoutes(router)
+
+	var handler http.Handler = router
+	if wrap != nil {
+		handler = wrap(handler)
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL, WithHTTPClient(server.Client()), WithRetry(3, time.Millisecond, 5*time.Millisecond))
+	require.NoError(t, err)
+
+	return c
+}
+
+// memoryIdempotencyRepository keeps idempotency keys in memory, serialising all calls with one lock
+type memoryIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[string]models.IdempotencyKey
+}
+
+func newMemoryIdempotencyRepository() *memoryIdempotencyRepository {
+	return &memoryIdempotencyRepository{records: make(map[string]models.IdempotencyKey)}
+}
+
+func (r *memoryIdempotencyRepository) Claim(_ context.Context, claim *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record, ok := r.records[claim.Key]; ok && record.ExpiresAt.After(claim.CreatedAt) {
+		return &record, nil
+	}
+	r.records[claim.Key] = *claim
+
+	return nil, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(_ context.Context, record *models.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if claim, ok := r.records[record.Key]; !ok || claim.ClaimToken != record.ClaimToken {
+		return db.ErrIdempotencyClaimLost
+	}
+	r.records[record.Key] = *record
+
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Release(_ context.Context, key string, claimToken string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if record := r.records[key]; record.ClaimToken == claimToken && record.ResponseStatus == 0 {
+		delete(r.records, key)
+	}
+
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpired(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+// loseFirstResponse runs the first request but answers 502 as a proxy losing the upstream response would
+func loseFirstResponse(calls *atomic.Int32) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				next.ServeHTTP(httptest.NewRecorder(), r)
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// failFirst answers the first n requests with the given status before letting requests through
+func failFirst(n int32, status int, calls *atomic.Int32) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) <= n {
+				w.WriteHeader(status)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		baseURL string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "should accept a base URL", baseURL: "http://localhost:8080", wantErr: assert.NoError},
+		{name: "should accept a base URL with a path prefix", baseURL: "https://example.com/todo/", wantErr: assert.NoError},
+		{name: "should reject a base URL without scheme", baseURL: "localhost:8080", wantErr: assert.Error},
+		{name: "should reject an empty base URL", baseURL: "", wantErr: assert.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := New(tt.baseURL)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestClient_CreateTask(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should create a task", func(t *testing.T) {
+		t.Parallel()
+
+		mockUsecase := mocks.NewTaskUsecase(t)
+		mockUsecase.On("CreateTask", mock.Anything, usecases.CreateTaskParams{
+			Title: "Shopping",
+			Items: []usecases.CreateTaskItemParams{{Title: "Buy milk"}},
+		}).Return(&usecases.TaskResult{
+			ID:    1,
+			Title: "Shopping",
+			Items: []usecases.TaskItemResult{{ID: 1, TaskID: 1, Title: "Buy milk"}},
+		}, nil).Once()
+		c := newTestServer(t, mockUsecase, nil)
+
+		task, err := c.CreateTask(context.Background(), CreateTaskRequest{
+			Title: "Shopping",
+			Items: []CreateTaskItemRequest{{Title: "Buy milk"}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), task.ID)
+		require.Len(t, task.Items, 1)
+		assert.Equal(t, "Buy milk", task.Items[0].Title)
+	})
+
+	t.Run("should return field errors on validation failure", func(t *testing.T) {
+		t.Parallel()
+
+		c := newTestServer(t, mocks.NewTaskUsecase(t), nil)
+
+		_, err := c.CreateTask(context.Background(), CreateTaskRequest{})
+
+		require.True(t, IsValidationError(err))
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, map[string]string{"title": "required"}, apiErr.Fields)
+		assert.NotEmpty(t, apiErr.RequestID)
+	})
+
+	t.Run("should retry with the same idempotency key", func(t *testing.T) {
+		t.Parallel()
+
+		var keysMu sync.Mutex
+		var keys []string
+		var calls atomic.Int32
+		recordKeys := func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				keysMu.Lock()
+				keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+				keysMu.Unlock()
+				next.ServeHTTP(w, r)
+			})
+		}
+
+		mockUsecase := mocks.NewTaskUsecase(t)
+		mockUsecase.On("CreateTask", mock.Anything, mock.Anything).
+			Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+		c := newTestServer(t, mockUsecase, func(next http.Handler) http.Handler {
+			return recordKeys(failFirst(2, http.StatusServiceUnavailable, &calls)(next))
+		})
+
+		_, err := c.CreateTask(context.Background(), CreateTaskRequest{Title: "Shopping"})
+
+		require.NoError(t, err)
+		require.Len(t, keys, 3)
+		assert.NotEmpty(t, keys[0])
+		assert.Equal(t, keys[0], keys[1])
+		assert.Equal(t, keys[0], keys[2])
+	})
+
+	t.Run("should not create a duplicate when the response is lost", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		mockUsecase := mocks.NewTaskUsecase(t)
+		mockUsecase.On("CreateTask", mock.Anything, mock.Anything).
+			Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+		c := newTestServer(t, mockUsecase, loseFirstResponse(&calls))
+
+		task, err := c.CreateTask(context.Background(), CreateTaskRequest{Title: "Shopping"})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), task.ID)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("should return an error when the key is reused with a different body", func(t *testing.T) {
+		t.Parallel()
+
+		mockUsecase := mocks.NewTaskUsecase(t)
+		mockUsecase.On("CreateTask", mock.Anything, mock.Anything).
+			Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+		c := newTestServer(t, mockUsecase, nil)
+		ctx := WithIdempotencyKey(context.Background(), "order-7")
+
+		_, err := c.CreateTask(ctx, CreateTaskRequest{Title: "Shopping"})
+		require.NoError(t, err)
+
+		_, err = c.CreateTask(ctx, CreateTaskRequest{Title: "Work"})
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusUnprocessableEntity, apiErr.StatusCode)
+	})
+
+	t.Run("should retry while an earlier attempt with the key is in progress", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		mockUsecase := mocks.NewTaskUsecase(t)
+		mockUsecase.On("CreateTask", mock.Anything, mock.Anything).
+			Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+		c := newTestServer(t, mockUsecase, failFirst(1, http.StatusConflict, &calls))
+
+		task, err := c.CreateTask(context.Background(), CreateTaskRequest{Title: "Shopping"})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), task.ID)
+		assert.Equal(t, int32(2), calls.Load())
+	})
+
+	t.Run("should use the idempotency key from the context", func(t *testing.T) {
+		t.Parallel()
+
+		var got string
+		mockUsecase := mocks.NewTaskUsecase(t)
+		mockUsecase.On("CreateTask", mock.Anything, mock.Anything).
+			Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+		c := newTestServer(t, mockUsecase, func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header.Get(IdempotencyKeyHeader)
+				next.ServeHTTP(w, r)
+			})
+		})
+
+		_, err := c.CreateTask(WithIdempotencyKey(context.Background(), "order-42"), CreateTaskRequest{Title: "Shopping"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "order-42", got)
+	})
+}
+
+func TestClient_GetTask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		setup   func(mockUsecase *mocks.TaskUsecase)
+		wantErr func(t *testing.T, err error)
+	}{
+		{
+			name: "should get a task",
+			setup: func(mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetTask", mock.Anything, int64(1)).
+					Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+			},
+			wantErr: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "should return a not found error",
+			setup: func(mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetTask", mock.Anything, int64(1)).
+					Return(nil, db.ErrTaskNotFound).Once()
+			},
+			wantErr: func(t *testing.T, err error) {
+				assert.True(t, IsNotFound(err))
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, "task not found", apiErr.Message)
+				assert.Empty(t, apiErr.Fields)
+				assert.NotEmpty(t, apiErr.RequestID)
+			},
+		},
+		{
+			name: "should give up after the maximum number of retries",
+			setup: func(mockUsecase *mocks.TaskUsecase) {
+				mockUsecase.On("GetTask", mock.Anything, int64(1)).
+					Return(nil, errors.New("database down")).Times(4)
+			},
+			wantErr: func(t *testing.T, err error) {
+				var apiErr *APIError
+				require.ErrorAs(t, err, &apiErr)
+				assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+				assert.Equal(t, "internal server error", apiErr.Message)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mockUsecase := mocks.NewTaskUsecase(t)
+			tt.setup(mockUsecase)
+			c := newTestServer(t, mockUsecase, nil)
+
+			_, err := c.GetTask(context.Background(), 1)
+			tt.wantErr(t, err)
+		})
+	}
+}
+
+func TestClient_ListTasks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should iterate over all pages", func(t *testing.T) {
+		t.Parallel()
+
+		mockUsecase := mocks.NewTaskUsecase(t)
+		mockUsecase.On("SearchTasks", mock.Anything, usecases.SearchTasksParams{Limit: 2, Offset: 0, IncludeItems: true}).
+			Return(&usecases.TaskPageResult{
+				Tasks:      []usecases.TaskResult{{ID: 3, Title: "C"}, {ID: 2, Title: "B"}},
+				TotalCount: 3,
+			}, nil).Once()
+		mockUsecase.On("SearchTasks", mock.Anything, usecases.SearchTasksParams{Limit: 2, Offset: 2, IncludeItems: true}).
+			Return(&usecases.TaskPageResult{
+				Tasks:      []usecases.TaskResult{{ID: 1, Title: "A"}},
+				TotalCount: 3,
+			}, nil).Once()
+		c := newTestServer(t, mockUsecase, nil)
+
+		var ids []int64
+		for task, err := range c.ListTasks(context.Background(), 2) {
+			require.NoError(t, err)
+			ids = append(ids, task.ID)
+		}
+
+		assert.Equal(t, []int64{3, 2, 1}, ids)
+	})
+
+	t.Run("should stop fetching pages when the caller breaks", func(t *testing.T) {
+		t.Parallel()
+
+		mockUsecase := mocks.NewTaskUsecase(t)
+		mockUsecase.On("SearchTasks", mock.Anything, usecases.SearchTasksParams{Limit: 2, Offset: 0, IncludeItems: true}).
+			Return(&usecases.TaskPageResult{
+				Tasks:      []usecases.TaskResult{{ID: 3, Title: "C"}, {ID: 2, Title: "B"}},
+				TotalCount: 3,
+			}, nil).Once()
+		c := newTestServer(t, mockUsecase, nil)
+
+		for task, err := range c.ListTasks(context.Background(), 2) {
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), task.ID)
+			break
+		}
+	})
+
+	t.Run("should yield the error of a failed page", func(t *testing.T) {
+		t.Parallel()
+
+		c := newTestServer(t, mocks.NewTaskUsecase(t), nil)
+
+		var gotErr error
+		for _, err := range c.ListTasks(context.Background(), 1000) {
+			gotErr = err
+		}
+
+		assert.True(t, IsValidationError(gotErr))
+	})
+}
+
+func TestClient_DeleteTask(t *testing.T) {
+	t.Parallel()
+
+	mockUsecase := mocks.NewTaskUsecase(t)
+	mockUsecase.On("DeleteTask", mock.Anything, int64(1)).Return(nil).Once()
+	mockUsecase.On("DeleteTask", mock.Anything, int64(2)).Return(db.ErrTaskNotFound).Once()
+	c := newTestServer(t, mockUsecase, nil)
+
+	require.NoError(t, c.DeleteTask(context.Background(), 1))
+	assert.True(t, IsNotFound(c.DeleteTask(context.Background(), 2)))
+}
+
+func TestClient_DeleteTask_LostResponse(t *testing.T) {
+	t.Parallel()
+
+	// The first attempt deletes the task but its response is lost, the retry then finds nothing to delete
+	mockUsecase := mocks.NewTaskUsecase(t)
+	mockUsecase.On("DeleteTask", mock.Anything, int64(1)).Return(nil).Once()
+	mockUsecase.On("DeleteTask", mock.Anything, int64(1)).Return(db.ErrTaskNotFound).Once()
+	var calls atomic.Int32
+	c := newTestServer(t, mockUsecase, loseFirstResponse(&calls))
+
+	require.NoError(t, c.DeleteTask(context.Background(), 1))
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestClient_TaskItems(t *testing.T) {
+	t.Parallel()
+
+	completed := true
+	mockUsecase := mocks.NewTaskUsecase(t)
+	mockUsecase.On("AddTaskItem", mock.Anything, int64(1), usecases.CreateTaskItemParams{Title: "Buy milk"}).
+		Return(&usecases.TaskItemResult{ID: 10, TaskID: 1, Title: "Buy milk"}, nil).Once()
+	mockUsecase.On("UpdateTaskItem", mock.Anything, int64(1), int64(10), usecases.UpdateTaskItemParams{Completed: &completed}).
+		Return(&usecases.TaskItemResult{ID: 10, TaskID: 1, Title: "Buy milk", Completed: true}, nil).Once()
+	mockUsecase.On("DeleteTaskItem", mock.Anything, int64(1), int64(10)).Return(nil).Once()
+	mockUsecase.On("DeleteTaskItem", mock.Anything, int64(1), int64(11)).Return(db.ErrTaskItemNotFound).Once()
+	c := newTestServer(t, mockUsecase, nil)
+
+	item, err := c.AddTaskItem(context.Background(), 1, CreateTaskItemRequest{Title: "Buy milk"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), item.ID)
+
+	item, err = c.SetTaskItemCompleted(context.Background(), 1, 10, true)
+	require.NoError(t, err)
+	assert.True(t, item.Completed)
+
+	require.NoError(t, c.DeleteTaskItem(context.Background(), 1, 10))
+	assert.True(t, IsNotFound(c.DeleteTaskItem(context.Background(), 1, 11)))
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should retry on 429 and 5xx responses", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		mockUsecase := mocks.NewTaskUsecase(t)
+		mockUsecase.On("GetTask", mock.Anything, int64(1)).
+			Return(&usecases.TaskResult{ID: 1, Title: "Shopping"}, nil).Once()
+		c := newTestServer(t, mockUsecase, failFirst(2, http.StatusTooManyRequests, &calls))
+
+		task, err := c.GetTask(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), task.ID)
+		assert.Equal(t, int32(3), calls.Load())
+	})
+
+	t.Run("should not retry client errors", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		c := newTestServer(t, mocks.NewTaskUsecase(t), failFirst(1, http.StatusConflict, &calls))
+
+		_, err := c.GetTask(context.Background(), 1)
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusConflict, apiErr.StatusCode)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+
+	t.Run("should stop retrying when the context is cancelled", func(t *testing.T) {
+		t.Parallel()
+
+		var calls atomic.Int32
+		c := newTestServer(t, mocks.NewTaskUsecase(t), failFirst(100, http.StatusServiceUnavailable, &calls))
+		c.minBackoff = time.Hour
+		c.maxBackoff = time.Hour
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := c.GetTask(ctx, 1)
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, int32(1), calls.Load())
+	})
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 2*time.Second, parseRetryAfter("2"))
+	assert.Zero(t, parseRetryAfter(""))
+	assert.Zero(t, parseRetryAfter("soon"))
+	assert.InDelta(t, float64(time.Minute), float64(parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))), float64(2*time.Second))
+}
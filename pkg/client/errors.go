@@ -0,0 +1,106 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned when the server answers with a 4xx or 5xx status
+// The server either sends {"error": "message"} or a map of JSON field names to validation messages, both carrying
+// the request_id
+type APIError struct {
+	StatusCode int
+	Message    string
+	Fields     map[string]string
+	RequestID  string // To be quoted when reporting the error
+}
+
+// errorBody is the JSON error body, the keys other than error and request_id being validation messages
+type errorBody struct {
+	Error     string
+	RequestID string
+	Fields    map[string]string
+}
+
+// UnmarshalJSON splits the error message and the request ID from the validation messages
+func (b *errorBody) UnmarshalJSON(data []byte) error {
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	b.Error = raw["error"]
+	b.RequestID = raw["request_id"]
+	delete(raw, "error")
+	delete(raw, "request_id")
+	if len(raw) > 0 {
+		b.Fields = raw
+	}
+
+	return nil
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	message := e.Message
+	if len(e.Fields) > 0 {
+		fields := make([]string, 0, len(e.Fields))
+		for field, fieldMessage := range e.Fields {
+			fields = append(fields, field+": "+fieldMessage)
+		}
+		sort.Strings(fields)
+		message = strings.Join(fields, ", ")
+	}
+
+	if e.RequestID == "" {
+		return fmt.Sprintf("todo api: %d %s", e.StatusCode, message)
+	}
+	return fmt.Sprintf("todo api: %d %s (request %s)", e.StatusCode, message, e.RequestID)
+}
+
+// IsNotFound reports whether err is an API error with status 404
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsValidationError reports whether err is an API error with status 400
+func IsValidationError(err error) bool {
+	return hasStatus(err, http.StatusBadRequest)
+}
+
+func hasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// newAPIError builds an APIError from an error response
+func newAPIError(resp *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    http.StatusText(resp.StatusCode),
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil || len(raw) == 0 {
+		return apiErr
+	}
+
+	var body errorBody
+	if err = json.Unmarshal(raw, &body); err != nil {
+		apiErr.Message = strings.TrimSpace(string(raw))
+		return apiErr
+	}
+
+	apiErr.RequestID = body.RequestID
+	apiErr.Fields = body.Fields
+	if body.Error != "" {
+		apiErr.Message = body.Error
+	}
+
+	return apiErr
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// DefaultPageSize is the page size used by ListTasks when none is given
+const DefaultPageSize = 20
+
+// CreateTask creates a task with its items
+func (c *Client) CreateTask(ctx context.Context, req CreateTaskRequest) (*Task, error) {
+	var task Task
+	if err := c.do(ctx, http.MethodPost, "/api/tasks", nil, req, &task); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// GetTask retrieves a task by ID
+func (c *Client) GetTask(ctx context.Context, id int64) (*Task, error) {
+	var task Task
+	if err := c.do(ctx, http.MethodGet, taskPath(id), nil, nil, &task); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// ListTasksPage retrieves a single page of tasks, newest first
+func (c *Client) ListTasksPage(ctx context.Context, limit, offset int) (*TaskPage, error) {
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	query.Set("offset", strconv.Itoa(offset))
+
+	var page TaskPage
+	if err := c.do(ctx, http.MethodGet, "/api/tasks", query, nil, &page); err != nil {
+		return nil, err
+	}
+
+	return &page, nil
+}
+
+// ListTasks iterates over all tasks, newest first, fetching pageSize tasks per request
+// Iteration stops after the first error, which is yielded with a nil task
+func (c *Client) ListTasks(ctx context.Context, pageSize int) iter.Seq2[*Task, error] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+
+	return func(yield func(*Task, error) bool) {
+		offset := 0
+		for {
+			page, err := c.ListTasksPage(ctx, pageSize, offset)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for i := range page.Tasks {
+				if !yield(&page.Tasks[i], nil) {
+					return
+				}
+			}
+
+			if page.NextOffset == nil {
+				return
+			}
+			offset = *page.NextOffset
+		}
+	}
+}
+
+// DeleteTask deletes a task and its items
+func (c *Client) DeleteTask(ctx context.Context, id int64) error {
+	return c.do(ctx, http.MethodDelete, taskPath(id), nil, nil, nil)
+}
+
+// AddTaskItem adds an item to a task
+func (c *Client) AddTaskItem(ctx context.Context, taskID int64, req CreateTaskItemRequest) (*TaskItem, error) {
+	var item TaskItem
+	if err := c.do(ctx, http.MethodPost, taskPath(taskID)+"/items", nil, req, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// UpdateTaskItem renames a task item and/or changes its completion status
+func (c *Client) UpdateTaskItem(ctx context.Context, taskID, itemID int64, req UpdateTaskItemRequest) (*TaskItem, error) {
+	var item TaskItem
+	if err := c.do(ctx, http.MethodPatch, itemPath(taskID, itemID), nil, req, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// SetTaskItemCompleted marks a task item as completed or not
+func (c *Client) SetTaskItemCompleted(ctx context.Context, taskID, itemID int64, completed bool) (*TaskItem, error) {
+	return c.UpdateTaskItem(ctx, taskID, itemID, UpdateTaskItemRequest{Completed: &completed})
+}
+
+// DeleteTaskItem deletes a task item
+func (c *Client) DeleteTaskItem(ctx context.Context, taskID, itemID int64) error {
+	return c.do(ctx, http.MethodDelete, itemPath(taskID, itemID), nil, nil, nil)
+}
+
+func taskPath(id int64) string {
+	return "/api/tasks/" + strconv.FormatInt(id, 10)
+}
+
+func itemPath(taskID, itemID int64) string {
+	return taskPath(taskID) + "/items/" + strconv.FormatInt(itemID, 10)
+}
@@ -0,0 +1,53 @@
+package client
+
+import "time"
+
+// TaskItem is an item of a task
+type TaskItem struct {
+	ID        int64     `json:"id"`
+	TaskID    int64     `json:"task_id"`
+	Title     string    `json:"title"`
+	Completed bool      `json:"completed"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Task is a task with its items
+type Task struct {
+	ID          int64      `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	DueAt       *time.Time `json:"due_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	Items       []TaskItem `json:"items"`
+}
+
+// CreateTaskItemRequest is the input for creating a task item
+type CreateTaskItemRequest struct {
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+}
+
+// CreateTaskRequest is the input for creating a task
+type CreateTaskRequest struct {
+	Title       string                  `json:"title"`
+	Description string                  `json:"description,omitempty"`
+	DueAt       *time.Time              `json:"due_at,omitempty"`
+	Items       []CreateTaskItemRequest `json:"items,omitempty"`
+}
+
+// UpdateTaskItemRequest is the input for updating a task item
+// Nil fields are left unchanged
+type UpdateTaskItemRequest struct {
+	Title     *string `json:"title,omitempty"`
+	Completed *bool   `json:"completed,omitempty"`
+}
+
+// TaskPage is a page of tasks
+type TaskPage struct {
+	Tasks      []Task `json:"tasks"`
+	TotalCount int    `json:"total_count"`
+	// NextOffset is nil on the last page
+	NextOffset *int `json:"next_offset,omitempty"`
+}